@@ -3,24 +3,31 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/chybatronik/goUserAPI/internal/audit"
 	"github.com/chybatronik/goUserAPI/internal/config"
 	"github.com/chybatronik/goUserAPI/internal/database"
+	internalerrors "github.com/chybatronik/goUserAPI/internal/errors"
 	"github.com/chybatronik/goUserAPI/internal/handlers"
 	"github.com/chybatronik/goUserAPI/internal/logging"
 	"github.com/chybatronik/goUserAPI/internal/middleware"
 	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tracing"
 	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
 )
 
 var (
@@ -30,24 +37,83 @@ var (
 )
 
 // DatabaseAdapter implements the handlers.DatabaseService interface
-type DatabaseAdapter struct{}
+type DatabaseAdapter struct {
+	// RetryReads retries reads once on connection errors (DB_QUERY_RETRY); CreateUser, UpsertUser and StreamReports never are
+	RetryReads bool
+}
+
+// read runs fn through database.WithQueryRetry when RetryReads is set
+func (da *DatabaseAdapter) read(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !da.RetryReads {
+		return fn(ctx)
+	}
+	return database.WithQueryRetry(ctx, fn)
+}
 
 // CreateUser implements the DatabaseService interface
 func (da *DatabaseAdapter) CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
 	return database.CreateUser(ctx, pool, user)
 }
 
+// UpsertUser implements the DatabaseService interface
+func (da *DatabaseAdapter) UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	return database.UpsertUser(ctx, pool, user)
+}
+
 // GetUsers implements the DatabaseService interface
-func (da *DatabaseAdapter) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
-	return database.GetUsers(ctx, pool, params)
+func (da *DatabaseAdapter) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) (users []models.User, totalCount int64, err error) {
+	err = da.read(ctx, func(ctx context.Context) error {
+		users, totalCount, err = database.GetUsers(ctx, pool, params)
+		return err
+	})
+	return users, totalCount, err
+}
+
+// GetUserByID implements the DatabaseService interface
+func (da *DatabaseAdapter) GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (user *models.User, err error) {
+	err = da.read(ctx, func(ctx context.Context) error {
+		user, err = database.GetUserByID(ctx, pool, id)
+		return err
+	})
+	return user, err
+}
+
+// GetUsersByIDs implements the DatabaseService interface
+func (da *DatabaseAdapter) GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) (users []models.User, err error) {
+	err = da.read(ctx, func(ctx context.Context) error {
+		users, err = database.GetUsersByIDs(ctx, pool, ids)
+		return err
+	})
+	return users, err
 }
 
 // GetReports implements the DatabaseService interface (Story 3.1)
-func (da *DatabaseAdapter) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
-	return database.GetReports(ctx, pool, params)
+func (da *DatabaseAdapter) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) (users []models.User, totalCount int64, err error) {
+	err = da.read(ctx, func(ctx context.Context) error {
+		users, totalCount, err = database.GetReports(ctx, pool, params)
+		return err
+	})
+	return users, totalCount, err
+}
+
+// StreamReports implements the DatabaseService interface
+// Streams are not retried: rows already handed to fn would be delivered twice
+func (da *DatabaseAdapter) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	return database.StreamReports(ctx, pool, params, fn)
 }
 
 func main() {
+	check := flag.Bool("check", false, "Validate configuration, print a report and exit without starting the server")
+	flag.Parse()
+
+	// -check exits 0 when the configuration would start the server and 1 otherwise
+	if *check {
+		if err := checkConfig(os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Initialize configuration first
 	appConfig, err := config.Load()
 	if err != nil {
@@ -60,6 +126,32 @@ func main() {
 	// Log startup events
 	logStartupEvents(logger, appConfig)
 
+	// Allow LOG_LEVEL to be changed at runtime via SIGHUP
+	go watchLogLevelReload(logger)
+
+	// Prune log files older than LOG_RETENTION_DAYS once a day
+	if appConfig.Logging.RetentionDays > 0 {
+		go logging.RunLogCleanup(context.Background(), logging.LogDir(appConfig.Logging.Dir),
+			appConfig.Logging.RetentionDays, logging.LogCleanupInterval, logger)
+	}
+
+	// Audit entries use a dedicated info-level logger so LOG_LEVEL never filters them out
+	audit.SetLogger(logging.NewStructuredLogger(logging.LevelInfo, "goUserAPI", Version))
+
+	// Setup distributed tracing (no-op when OTEL_EXPORTER_OTLP_ENDPOINT is unset)
+	shutdownTracing, err := tracing.Setup(context.Background(), appConfig.Tracing.OTLPEndpoint, "goUserAPI", Version)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", logging.FieldError, err)
+		log.Fatalf("FATAL: Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Failed to flush traces", logging.FieldError, err)
+		}
+	}()
+
 	logger.Startup("Initializing database connection...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -67,33 +159,77 @@ func main() {
 
 	// Create connection pool
 	pool, err := database.NewConnectionPool(appConfig)
+	databaseConnected := err == nil
 	if err != nil {
-		logger.Error("Failed to create database connection pool", logging.FieldError, err)
-		log.Fatalf("FATAL: Failed to create database connection pool: %v", err)
+		if !appConfig.Application.StartWithoutDB {
+			logger.Error("Failed to create database connection pool", logging.FieldError, err)
+			log.Fatalf("FATAL: Failed to create database connection pool: %v", err)
+		}
+
+		// START_WITHOUT_DB: serve probes and keep retrying instead of crash-looping
+		logger.Error("Database unavailable at startup, retrying in the background", logging.FieldError, err)
+		pool, err = database.NewLazyConnectionPool(appConfig)
+		if err != nil {
+			logger.Error("Failed to create database connection pool", logging.FieldError, err)
+			log.Fatalf("FATAL: Failed to create database connection pool: %v", err)
+		}
 	}
 	defer pool.Close()
 
-	// Validate database connection
-	if err := database.ValidateConnection(ctx, pool); err != nil {
-		logger.Error("Database connection validation failed", logging.FieldError, err)
-		log.Fatalf("FATAL: Database connection validation failed: %v", err)
-	}
+	migrationRunner := database.NewMigrationRunner(pool, "./migrations")
+	if databaseConnected {
+		// Validate database connection
+		if err := database.ValidateConnection(ctx, pool); err != nil {
+			logger.Error("Database connection validation failed", logging.FieldError, err)
+			log.Fatalf("FATAL: Database connection validation failed: %v", err)
+		}
 
-	logger.Database("Database connection established successfully")
+		logger.Database("Database connection established successfully")
 
-	// Run automatic migrations before starting HTTP server
-	logger.Startup("Running database migrations...")
-	migrationRunner := database.NewMigrationRunner(pool, "./migrations")
+		// Run automatic migrations before starting HTTP server
+		logger.Startup("Running database migrations...")
+		if err := migrationRunner.RunMigrations(ctx); err != nil {
+			logger.Error("Database migration failed", logging.FieldError, err)
+			log.Fatalf("FATAL: Database migration failed: %v", err)
+		}
 
-	if err := migrationRunner.RunMigrations(ctx); err != nil {
-		logger.Error("Database migration failed", logging.FieldError, err)
-		log.Fatalf("FATAL: Database migration failed: %v", err)
-	}
+		logger.Database("Database migrations completed successfully")
 
-	logger.Database("Database migrations completed successfully")
+		if appConfig.Database.VerifyIndexes {
+			verifyIndexes(ctx, pool, logger)
+		}
+	}
 
 	// Setup HTTP server with graceful shutdown
-	server := setupHTTPServer(appConfig, pool, logger)
+	inFlight := middleware.NewInFlightTracker()
+	probes := handlers.NewProbeHandler()
+	probes.SetTimeout(appConfig.HealthCheck.Timeout)
+	// Optionally fail readiness while migration files are pending
+	if appConfig.HealthCheck.Migrations {
+		probes.AddChecker(database.NewMigrationHealthChecker(migrationRunner))
+	}
+	// Without a database, /readyz fails until the background connection and migrations succeed
+	if !databaseConnected {
+		startupCtx, stopStartup := context.WithCancel(context.Background())
+		defer stopStartup()
+
+		startup := &databaseStartup{}
+		probes.AddChecker(startup)
+		go startup.run(startupCtx,
+			func(ctx context.Context) error { return database.ValidateConnection(ctx, pool) },
+			migrationRunner.RunMigrations, databaseRetryInterval, logger)
+	}
+	// Report exports run in the background and are cancelled before the pool closes
+	exports := handlers.NewExportManager(logger, pool, &DatabaseAdapter{RetryReads: appConfig.Database.QueryRetry}, "",
+		handlers.DefaultExportWorkers, handlers.DefaultExportQueueSize, handlers.DefaultExportTTL)
+	server := setupHTTPServer(appConfig, pool, logger, inFlight, probes, exports, migrationRunner)
+
+	// Periodic pool saturation logs stop on the shutdown signal, before the pool is closed
+	if appConfig.Database.PoolStatsInterval > 0 {
+		statsCtx, stopStats := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stopStats()
+		go database.LogPoolStats(statsCtx, pool, appConfig.Database.PoolStatsInterval, logger)
+	}
 
 	// Start server in a goroutine
 	go func() {
@@ -110,13 +246,18 @@ func main() {
 	logger.Startup("goUserAPI service started successfully")
 
 	// Graceful shutdown handling
-	gracefulShutdown(server, pool, appConfig.Application.ShutdownTimeout, logger)
+	readinessDelay := time.Duration(appConfig.Application.ShutdownReadinessDelay) * time.Second
+	gracefulShutdown(server, pool, inFlight, probes, exports, readinessDelay, shutdownTimeouts{
+		Server: appConfig.Application.ServerShutdownTimeout,
+		Pool:   appConfig.Application.PoolShutdownTimeout,
+	}, logger)
 }
 
 // setupHTTPServer configures and returns an HTTP server with structured logging and middleware
-func setupHTTPServer(appConfig *config.Config, pool *pgxpool.Pool, logger *logging.Logger) *http.Server {
+func setupHTTPServer(appConfig *config.Config, pool *pgxpool.Pool, logger *logging.Logger, inFlight *middleware.InFlightTracker, probes *handlers.ProbeHandler, exports *handlers.ExportManager, migrations handlers.MigrationStatusSource) *http.Server {
 	// Setup health check handler with structured logging
 	healthHandler := handlers.NewHealthHandler("goUserAPI", Version, logger)
+	healthHandler.SetTimeout(appConfig.HealthCheck.Timeout)
 
 	// Add database health checker if enabled
 	if appConfig.HealthCheck.Enabled {
@@ -124,85 +265,153 @@ func setupHTTPServer(appConfig *config.Config, pool *pgxpool.Pool, logger *loggi
 		healthHandler.AddChecker(dbHealthChecker)
 	}
 
+	// Shared age range for handler and database validation
+	ageBounds := validation.AgeBounds{Min: appConfig.Application.MinAge, Max: appConfig.Application.MaxAge}
+	database.SetAgeBounds(ageBounds)
+
+	// Shared page size limits for handler and database validation
+	pageSize := validation.PageSize{
+		Default:   appConfig.Application.DefaultPageSize,
+		Max:       appConfig.Application.MaxPageSize,
+		MaxOffset: appConfig.Application.MaxOffset,
+	}
+	database.SetPageSize(pageSize)
+
+	// Hard cap on rows per read, enforced in the database layer whatever the caller asked for
+	database.SetMaxResultRows(appConfig.Application.MaxResultRows)
+
+	// Optional best-effort uniqueness of first/last name pairs
+	database.SetRejectDuplicateNames(appConfig.Application.RejectDuplicateNames)
+
+	// Optional tenant isolation: every user query is scoped to the request's X-Tenant-ID
+	database.SetMultiTenant(appConfig.Application.MultiTenant)
+	if appConfig.Application.MultiTenant {
+		logger.Startup("Multi-tenant mode enabled, X-Tenant-ID is required")
+	}
+
+	// Fail fast with POOL_EXHAUSTED instead of queueing for a connection until the operation times out
+	database.SetAcquireTimeout(appConfig.Database.AcquireTimeout)
+
+	// Optional approximate GET /users total_count that skips COUNT(*) on every request
+	database.SetUserCountCacheTTL(appConfig.Database.UserCountCacheTTL)
+
+	// Query plans of slow reads are a debugging aid; EXPLAIN doubles the round trips, so never in production
+	if appConfig.Database.ExplainSlowQueries {
+		if appConfig.Application.Environment == "production" {
+			logger.Warn("EXPLAIN_SLOW_QUERIES is ignored in production")
+		} else {
+			database.SetExplainSlowQueries(logger)
+		}
+	}
+
+	// Name length and payload size limits reported in validation errors
+	limits := validation.Limits{MaxNameLength: appConfig.Application.MaxNameLength, MaxPayloadBytes: appConfig.Application.MaxPayloadBytes}
+
+	// Optional restriction of names to specific Unicode scripts
+	scripts, err := validation.ParseScriptPolicy(appConfig.Application.AllowedNameScripts)
+	if err != nil {
+		logger.Error("Invalid allowed name scripts", logging.FieldError, err)
+		log.Fatalf("FATAL: Invalid allowed name scripts: %v", err)
+	}
+	limits.Scripts = scripts
+
+	// ?pretty=true is a debugging aid and never honoured in production
+	allowPrettyJSON := appConfig.Application.Environment != "production"
+
+	// Key case of user objects in every JSON response
+	handlers.SetResponseFieldCase(appConfig.Application.ResponseFieldCase)
+
+	// Optional {data, meta} envelope for list responses; the flat shape stays the default
+	handlers.SetEnvelopeResponses(appConfig.Application.EnvelopeResponses)
+
 	// Setup user handler
-	dbAdapter := &DatabaseAdapter{}
+	dbAdapter := &DatabaseAdapter{RetryReads: appConfig.Database.QueryRetry}
 	userHandler := handlers.NewUserHandler(logger, pool, dbAdapter)
+	userHandler.SetAggregateValidationErrors(appConfig.Application.AggregateValidationErrors)
+	userHandler.SetStrictSchema(appConfig.Application.StrictSchema)
+	userHandler.SetAgeBounds(ageBounds)
+	userHandler.SetPageSize(pageSize)
+	userHandler.SetLimits(limits)
+	userHandler.SetNameNormalizer(validation.Normalizer(appConfig.Application.NameNormalization))
+	userHandler.SetImportAPIKey(appConfig.Application.ImportAPIKey)
+	userHandler.SetPrettyJSON(allowPrettyJSON)
 
 	// Setup report handler (Story 3.1)
 	reportHandler := handlers.NewReportHandler(logger, pool, dbAdapter)
+	reportHandler.SetAgeBounds(ageBounds)
+	reportHandler.SetPageSize(pageSize)
+	reportHandler.SetExportManager(exports)
+	reportHandler.SetPrettyJSON(allowPrettyJSON)
+	reportHandler.SetMaxQueryParams(appConfig.Application.MaxQueryParams)
+
+	// Resolve client IPs through configured proxies for rate limiting and logging
+	trustedProxies, err := middleware.ParseTrustedProxies(appConfig.Server.TrustedProxies)
+	if err != nil {
+		logger.Error("Invalid trusted proxy configuration", logging.FieldError, err)
+		log.Fatalf("FATAL: Invalid trusted proxy configuration: %v", err)
+	}
+	middleware.SetTrustedProxies(trustedProxies)
+	middleware.SetDuplicateRequestIDDetection(logger, appConfig.Logging.RequestIDDedupWindow)
+	middleware.SetRateLimitLogger(logger)
+	middleware.SetSlowRequestThreshold(appConfig.Logging.SlowRequestThreshold)
+	middleware.SetAccessLogFormat(appConfig.Logging.AccessFormat, os.Stdout)
 
 	// Create rate limiters once at startup (NOT per request)
 	// Reports endpoint gets stricter rate limiting due to potential resource intensity
 	reportsRateLimiter := middleware.SecurityRateLimit(50.0/60.0, 10) // 50 req/min, burst 10 (stricter than global 100/min)
 
+	// Setup OpenAPI contract handler
+	openAPIHandler, err := handlers.NewOpenAPIHandler(logger)
+	if err != nil {
+		logger.Error("Failed to build OpenAPI spec", logging.FieldError, err)
+		log.Fatalf("FATAL: Failed to build OpenAPI spec: %v", err)
+	}
+
 	// Create router
 	mux := http.NewServeMux()
 
 	// Register routes
-	mux.HandleFunc("/health", healthHandler.ServeHTTP)
-	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			userHandler.GetUsers(w, r)    // NEW from Story 2.3
-		case http.MethodPost:
-			userHandler.CreateUser(w, r)  // EXISTING from Story 2.2
-		default:
-			// Comprehensive Method Not Allowed response with proper headers
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Allow", "GET, POST") // Explicitly list allowed methods
-			w.WriteHeader(http.StatusMethodNotAllowed)
-
-			errorResponse := map[string]interface{}{
-				"error":   "Method not allowed",
-				"code":    "METHOD_NOT_ALLOWED",
-				"details": fmt.Sprintf("Method %s is not allowed. Supported methods: GET, POST", r.Method),
-			}
-
-			if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
-				// Fallback to simple error response if JSON encoding fails
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			}
-		}
-	})
-
-	mux.HandleFunc("/reports", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			// SECURITY: Apply pre-created Story 2.4 endpoint-specific rate limiting for reports
-			// Reports endpoint gets stricter rate limiting due to potential resource intensity
-			reportsRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				reportHandler.GetReports(w, r)  // NEW from Story 3.1
-			})).ServeHTTP(w, r)
-		default:
-			// Comprehensive Method Not Allowed response with proper headers
-			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("Allow", "GET") // Explicitly list allowed methods
-			w.WriteHeader(http.StatusMethodNotAllowed)
-
-			errorResponse := map[string]interface{}{
-				"error":   "Method not allowed",
-				"code":    "METHOD_NOT_ALLOWED",
-				"details": fmt.Sprintf("Method %s is not allowed. Supported methods: GET", r.Method),
-			}
+	registerHealthRoutes(mux, healthHandler)
+	mux.HandleFunc("GET /livez", probes.Livez)
+	mux.HandleFunc("GET /readyz", probes.Readyz)
+	mux.Handle("/openapi.json", openAPIHandler)
+	mux.Handle("GET /metrics/db", handlers.NewDBMetricsHandler(logger, database.Metrics))
+	// Maintenance mode blocks writes while reads continue; SIGUSR1/SIGUSR2 and /admin/maintenance toggle it at runtime
+	maintenance := middleware.NewMaintenanceMode(appConfig.Application.MaintenanceMode)
+	if maintenance.Enabled() {
+		logger.Startup("Maintenance mode enabled, writes are rejected")
+	}
+	go watchMaintenanceSignals(maintenance, logger)
+	registerAdminRoutes(mux, appConfig, migrations, maintenance, logger)
+	requireTenant := middleware.RequireTenant(appConfig.Application.MultiTenant)
+	mux.Handle("/users", requireTenant(usersRoute(http.HandlerFunc(userHandler.GetUsers), http.HandlerFunc(userHandler.CreateUser), http.HandlerFunc(userHandler.UpsertUser))))
+	mux.Handle("/users/{id}", requireTenant(userRoute(http.HandlerFunc(userHandler.GetUserByID))))
 
-			if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
-				// Fallback to simple error response if JSON encoding fails
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			}
-		}
-	})
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("goUserAPI is running"))
-	})
+	registerReportRoutes(mux, appConfig, reportHandler, reportsRateLimiter, logger)
+	mux.HandleFunc("/", rootHandler)
 
 	// Setup middleware chain with request ID, security, and structured logging
-	// Order matters: Security -> RequestID -> Logging -> Router
+	// Order matters: InFlight -> ResponseTime -> SecurityHeaders -> CORS -> Security -> RequestID -> CorrelationID -> Tracing -> Logging -> Gzip -> ValidateHeaders -> BodyLimit -> Decompress -> LogBodies -> Maintenance -> Router
 	// Security middleware should be first to validate input and enforce rate limits
+	compress := middleware.Gzip(appConfig.Server.GzipMinSize, appConfig.Server.GzipContentTypes)
+	decompress := middleware.DecompressRequest(appConfig.Server.RequestContentEncodings, appConfig.Server.MaxBodySize)
+	validateHeaders := middleware.ValidateHeaders(appConfig.Server.ValidatedHeaders)
 	handler := http.Handler(mux)
-	handler = middleware.NewLoggingMiddleware(logger, handler)     // Apply logging last
-	handler = middleware.RequestIDMiddleware(handler)             // Apply request ID second
-	handler = middleware.SecurityRateLimit(100.0/60.0, 20)(handler) // Apply security rate limiting first (100 req/min, burst 20)
+	handler = maintenance.Middleware(handler)                               // Reject writes with 503 before the body is read
+	handler = middleware.LogBodies(logger)(handler)                         // Log debug bodies as plaintext, inside the compression layers
+	handler = decompress(handler)                                           // Decode gzip bodies, bounding the decoded size as well
+	handler = middleware.MaxBodySize(appConfig.Server.MaxBodySize)(handler) // Bound request bodies before handlers read them
+	handler = validateHeaders(handler)                                      // Reject forged header values before handlers use them
+	handler = compress(handler)                                             // Compress inside logging so logged sizes are wire sizes
+	handler = middleware.NewLoggingMiddleware(logger, handler)              // Apply logging last
+	handler = middleware.Tracing(otel.GetTracerProvider())(handler)         // Start a server span once the request ID is known
+	handler = middleware.CorrelationIDMiddleware(handler)                   // Carry X-Correlation-ID into every log line of the request
+	handler = middleware.RequestIDMiddleware(handler)                       // Apply request ID second
+	handler = middleware.SecurityRateLimit(100.0/60.0, 20)(handler)         // Apply security rate limiting first (100 req/min, burst 20)
+	handler = middleware.CORS(appConfig.Server.CORSAllowedOrigins)(handler) // Let browsers read rate-limit rejections and answer preflights early
+	handler = middleware.SecurityHeaders(handler)                           // Harden every response, including rate-limit rejections
+	handler = middleware.ResponseTime(handler)                              // Report server time on every response as X-Response-Time
+	handler = inFlight.Middleware(handler)                                  // Count every accepted request for shutdown draining
 
 	// Configure server with timeouts
 	server := &http.Server{
@@ -216,8 +425,100 @@ func setupHTTPServer(appConfig *config.Config, pool *pgxpool.Pool, logger *loggi
 	return server
 }
 
+// registerHealthRoutes serves the health handler on /health and on /healthz, the path many orchestrators probe
+func registerHealthRoutes(mux *http.ServeMux, health http.Handler) {
+	mux.Handle("/health", health)
+	mux.Handle("/healthz", health)
+}
+
+// registerAdminRoutes adds the API-key-gated /admin/* routes when ADMIN_ENDPOINTS_ENABLED is set
+// Disabled routes are never registered, so they fall through to the JSON 404 of rootHandler
+func registerAdminRoutes(mux *http.ServeMux, appConfig *config.Config, migrations handlers.MigrationStatusSource, maintenance *middleware.MaintenanceMode, logger *logging.Logger) {
+	if !appConfig.Application.AdminEndpointsEnabled {
+		return
+	}
+
+	logger.Startup("Admin endpoints enabled", "environment", appConfig.Application.Environment)
+	requireAPIKey := middleware.RequireAPIKey(appConfig.Application.AdminAPIKey)
+	mux.Handle("GET /admin/migrations", requireAPIKey(handlers.NewMigrationStatusHandler(logger, migrations)))
+
+	maintenanceHandler := requireAPIKey(handlers.NewMaintenanceHandler(logger, maintenance))
+	mux.Handle("GET /admin/maintenance", maintenanceHandler)
+	mux.Handle("PUT /admin/maintenance", maintenanceHandler)
+
+	logCleanup := handlers.NewLogCleanupHandler(logger, logging.LogDir(appConfig.Logging.Dir), appConfig.Logging.RetentionDays)
+	mux.Handle("POST /admin/logs/cleanup", requireAPIKey(logCleanup))
+}
+
+// registerReportRoutes adds /reports and the report export routes unless REPORTS_ENABLED is false
+// Disabled routes are never registered, so they fall through to the JSON 404 of rootHandler
+func registerReportRoutes(mux *http.ServeMux, appConfig *config.Config, reportHandler *handlers.ReportHandler, rateLimiter func(http.Handler) http.Handler, logger *logging.Logger) {
+	if !appConfig.Application.ReportsEnabled {
+		logger.Startup("Reports endpoints disabled")
+		return
+	}
+
+	// SECURITY: Apply pre-created Story 2.4 endpoint-specific rate limiting for reports
+	// Reports endpoint gets stricter rate limiting due to potential resource intensity
+	requireTenant := middleware.RequireTenant(appConfig.Application.MultiTenant)
+	mux.Handle("/reports", requireTenant(reportsRoute(rateLimiter(http.HandlerFunc(reportHandler.GetReports)))))
+	mux.Handle("POST /reports/export", requireTenant(rateLimiter(http.HandlerFunc(reportHandler.CreateExport))))
+	mux.Handle("GET /reports/export/{job_id}", requireTenant(http.HandlerFunc(reportHandler.GetExport)))
+}
+
+// usersRoute serves GET and HEAD /users with getUsers, POST with createUser and PUT with upsertUser; other methods get a 405
+func usersRoute(getUsers, createUser, upsertUser http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			getUsers.ServeHTTP(w, r)
+		case http.MethodPost:
+			createUser.ServeHTTP(w, r)
+		case http.MethodPut:
+			upsertUser.ServeHTTP(w, r)
+		default:
+			handlers.MethodNotAllowed(w, r, handlers.UsersMethods)
+		}
+	}
+}
+
+// userRoute serves GET and HEAD /users/{id} with getUser and answers any other method with a 405
+func userRoute(getUser http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			getUser.ServeHTTP(w, r)
+		default:
+			handlers.MethodNotAllowed(w, r, handlers.UserMethods)
+		}
+	}
+}
+
+// reportsRoute serves GET and HEAD /reports with getReports and answers any other method with a 405
+func reportsRoute(getReports http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			getReports.ServeHTTP(w, r)
+		default:
+			handlers.MethodNotAllowed(w, r, handlers.ReportsMethods)
+		}
+	}
+}
+
+// rootHandler greets requests for exactly "/" and answers every unmatched route with a JSON 404
+// so a mistyped path such as /user is not mistaken for success
+func rootHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		internalerrors.WriteNotFoundError(w, r, "Route")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("goUserAPI is running"))
+}
+
 // gracefulShutdown handles graceful shutdown of the service with structured logging
-func gracefulShutdown(server *http.Server, pool *pgxpool.Pool, shutdownTimeout int, logger *logging.Logger) {
+func gracefulShutdown(server *http.Server, pool *pgxpool.Pool, inFlight *middleware.InFlightTracker, probes *handlers.ProbeHandler, exports *handlers.ExportManager, readinessDelay time.Duration, timeouts shutdownTimeouts, logger *logging.Logger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -225,24 +526,111 @@ func gracefulShutdown(server *http.Server, pool *pgxpool.Pool, shutdownTimeout i
 	sig := <-sigChan
 	logger.Startup("Received signal, initiating graceful shutdown", "signal", sig.String())
 
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(shutdownTimeout)*time.Second)
+	// Fail readiness first so load balancers stop routing new traffic here
+	drainReadiness(probes, readinessDelay, logger)
+
+	runShutdownStages(server, pool, inFlight, exports, timeouts, logger)
+
+	logger.Startup("goUserAPI service shutdown completed")
+}
+
+// shutdownTimeouts bounds each graceful shutdown stage separately
+type shutdownTimeouts struct {
+	Server time.Duration // HTTP server drain
+	Pool   time.Duration // Database pool close
+}
+
+// httpShutdowner is the part of *http.Server used during shutdown
+type httpShutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// poolCloser is the part of *pgxpool.Pool used during shutdown
+type poolCloser interface {
+	Close()
+}
+
+// exportStopper is the part of *handlers.ExportManager used during shutdown
+type exportStopper interface {
+	Stop()
+}
+
+// runShutdownStages drains the HTTP server, stops report exports and closes the database pool, in that order
+// The server stage is bounded by timeouts.Server and the pool stage by timeouts.Pool; each stage logs its duration.
+func runShutdownStages(server httpShutdowner, pool poolCloser, inFlight *middleware.InFlightTracker, exports exportStopper, timeouts shutdownTimeouts, logger *logging.Logger) {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeouts.Server)
 	defer cancel()
 
-	// Shutdown HTTP server
+	// Stop accepting requests before anything they depend on goes away
+	start := time.Now()
+	shutdownHTTPServer(shutdownCtx, server, inFlight, logger)
+	logShutdownStage(logger, "server", start)
+
+	// Cancel report exports before their queries lose the pool
+	start = time.Now()
+	exports.Stop()
+	logger.Startup("Report exports stopped")
+	logShutdownStage(logger, "exports", start)
+
+	start = time.Now()
+	closePool(pool, timeouts.Pool, logger)
+	logShutdownStage(logger, "pool", start)
+}
+
+// logShutdownStage records how long a shutdown stage took
+func logShutdownStage(logger *logging.Logger, stage string, start time.Time) {
+	logger.Startup("Shutdown stage finished", "stage", stage, "duration_ms", time.Since(start).Milliseconds())
+}
+
+// closePool closes the database pool, waiting at most timeout
+// pgxpool's Close blocks until every acquired connection is released, so it runs in the background and
+// shutdown moves on with a warning once the budget is spent.
+func closePool(pool poolCloser, timeout time.Duration, logger *logging.Logger) {
+	logger.Startup("Closing database connections...")
+
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-closed:
+		logger.Startup("Database connections closed")
+	case <-timer.C:
+		logger.Warn("Database pool close exceeded shutdown timeout, continuing", "timeout_ms", timeout.Milliseconds())
+	}
+}
+
+// drainReadiness flips /readyz to 503 and keeps serving for delay so load balancers can deregister the instance
+func drainReadiness(probes *handlers.ProbeHandler, delay time.Duration, logger *logging.Logger) {
+	probes.SetShuttingDown(true)
+	if delay <= 0 {
+		return
+	}
+
+	logger.Startup("Readiness set to failing, waiting before shutdown", "readiness_delay_ms", delay.Milliseconds())
+	time.Sleep(delay)
+}
+
+// shutdownHTTPServer stops accepting connections and waits for in-flight requests to drain
+func shutdownHTTPServer(ctx context.Context, server httpShutdowner, inFlight *middleware.InFlightTracker, logger *logging.Logger) {
 	logger.Startup("Shutting down HTTP server...")
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Error("HTTP server shutdown failed", logging.FieldError, err)
+
+	pending := inFlight.Count()
+	logger.Startup(fmt.Sprintf("Waiting for %d in-flight requests", pending), "in_flight", pending)
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("HTTP server shutdown failed",
+			logging.FieldError, err,
+			"in_flight", inFlight.Count(),
+		)
 	} else {
 		logger.Startup("HTTP server shutdown completed")
 	}
-
-	// Close database connections
-	logger.Startup("Closing database connections...")
-	pool.Close()
-	logger.Startup("Database connections closed")
-
-	logger.Startup("goUserAPI service shutdown completed")
 }
 
 // setupStructuredLogging initializes the structured logger based on configuration
@@ -252,10 +640,36 @@ func setupStructuredLogging(cfg *config.Config) *logging.Logger {
 		"goUserAPI",
 		Version,
 	)
+	logger.SetRedactFields(cfg.Logging.RedactFields)
 
 	return logger.WithServiceContext()
 }
 
+// watchLogLevelReload re-applies LOG_LEVEL from the environment each time the process receives SIGHUP
+func watchLogLevelReload(logger *logging.Logger) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	for range sigChan {
+		reloadLogLevel(logger)
+	}
+}
+
+// reloadLogLevel swaps the logger level to the current LOG_LEVEL, keeping the old level when it is invalid
+func reloadLogLevel(logger *logging.Logger) {
+	if err := config.ValidateLogLevel(); err != nil {
+		logger.Warn("Ignoring log level reload", logging.FieldError, err)
+		return
+	}
+
+	level := os.Getenv("LOG_LEVEL")
+	if level == "" {
+		level = logging.LevelInfo
+	}
+	logger.SetLevel(level)
+	logger.Startup("Log level reloaded", "log_level", level)
+}
+
 // logStartupEvents logs comprehensive startup information
 func logStartupEvents(logger *logging.Logger, cfg *config.Config) {
 	logger.Startup("goUserAPI service starting up",
@@ -271,6 +685,110 @@ func logStartupEvents(logger *logging.Logger, cfg *config.Config) {
 		"db_host", cfg.Database.Host,
 		"db_port", cfg.Database.Port,
 		"db_name", cfg.Database.Database,
+		"db_url_set", cfg.Database.URL != "",
 		"health_check_enabled", cfg.HealthCheck.Enabled,
 	)
+
+	// Full configuration audit with secrets masked
+	logger.Startup("effective configuration", "config", cfg.Redacted())
+}
+
+// checkConfig loads and validates configuration as the server would at startup and writes a report to w
+// Every missing required variable and invalid environment value found by config.ValidateAll is listed;
+// otherwise the first invalid setting rejected by config.Load is reported.
+func checkConfig(w io.Writer) error {
+	appConfig, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(w, "Configuration check failed:")
+
+		var validationErrs config.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			for _, validationErr := range validationErrs {
+				if validationErr.Value == "" {
+					fmt.Fprintf(w, "  - %s: %s\n", validationErr.Field, validationErr.Message)
+				} else {
+					fmt.Fprintf(w, "  - %s=%q: %s\n", validationErr.Field, validationErr.Value, validationErr.Message)
+				}
+			}
+		} else {
+			fmt.Fprintf(w, "  - %v\n", err)
+		}
+		return err
+	}
+
+	database := fmt.Sprintf("%s@%s:%d/%s",
+		appConfig.Database.User, appConfig.Database.Host, appConfig.Database.Port, appConfig.Database.Database)
+	if appConfig.Database.URL != "" {
+		// The URL may embed a password, so only its presence is reported
+		database = "DATABASE_URL"
+	}
+	fmt.Fprintf(w, "Configuration OK (environment: %s, database: %s)\n", appConfig.Application.Environment, database)
+	return nil
+}
+
+// verifyIndexes logs a warning for each expected users table index that is missing
+// The check is advisory: queries still work without the indexes, only slower.
+func verifyIndexes(ctx context.Context, pool *pgxpool.Pool, logger *logging.Logger) {
+	missing, err := database.VerifyIndexes(ctx, pool, database.ExpectedIndexes)
+	if err != nil {
+		logger.Warn("Index verification failed", logging.FieldError, err)
+		return
+	}
+	for _, name := range missing {
+		logger.Warn("Expected database index is missing", "index", name, "table", "users")
+	}
+	if len(missing) == 0 {
+		logger.Database("Expected database indexes present", "indexes", database.ExpectedIndexes)
+	}
+}
+
+// databaseRetryInterval is the wait between background connection attempts under START_WITHOUT_DB
+const databaseRetryInterval = 5 * time.Second
+
+// databaseStartup is a readiness check that fails until the database has been connected and migrated
+// in the background, used when the server starts without a database (START_WITHOUT_DB)
+type databaseStartup struct {
+	ready atomic.Bool
+}
+
+// Name returns the readiness check name
+func (d *databaseStartup) Name() string {
+	return "database"
+}
+
+// CheckHealth reports healthy once run has succeeded
+func (d *databaseStartup) CheckHealth(ctx context.Context) handlers.HealthCheck {
+	if d.ready.Load() {
+		return handlers.HealthCheck{Status: "healthy"}
+	}
+	return handlers.HealthCheck{Status: "unhealthy", Error: "database not connected"}
+}
+
+// run calls connect and then migrate, retrying both every interval until they succeed or ctx is done
+func (d *databaseStartup) run(ctx context.Context, connect, migrate func(context.Context) error, interval time.Duration, logger *logging.Logger) {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := connect(attemptCtx)
+		if err == nil {
+			err = migrate(attemptCtx)
+		}
+		cancel()
+
+		if err == nil {
+			d.ready.Store(true)
+			logger.Database("Database connected and migrated after startup", "attempts", attempt)
+			return
+		}
+		logger.Warn("Database still unavailable, retrying",
+			logging.FieldError, err,
+			"attempt", attempt,
+			"retry_in_ms", interval.Milliseconds(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
 }
@@ -1,7 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+	"github.com/chybatronik/goUserAPI/internal/database"
+	internalerrors "github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/handlers"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
 )
 
 func TestMainEntryPoint(t *testing.T) {
@@ -15,3 +36,601 @@ func TestServerStartup(t *testing.T) {
 	// Implementation will be added in future stories
 	t.Skip("Server implementation will be added in Story 2.x")
 }
+
+func TestShutdownLogsInFlightRequests(t *testing.T) {
+	inFlight := middleware.NewInFlightTracker()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	server := &http.Server{
+		Handler: inFlight.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(entered)
+			<-release
+			w.WriteHeader(http.StatusOK)
+		})),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+
+	// Hold one request open
+	requestDone := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/users")
+		if err == nil {
+			resp.Body.Close()
+		}
+		requestDone <- err
+	}()
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request never reached the handler")
+	}
+
+	var logs bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&logs, "info", "goUserAPI", "test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		shutdownHTTPServer(ctx, server, inFlight, logger)
+		close(shutdownDone)
+	}()
+
+	// Shutdown must wait for the held request
+	select {
+	case <-shutdownDone:
+		t.Fatal("shutdown completed while a request was still in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	<-shutdownDone
+
+	if err := <-requestDone; err != nil {
+		t.Errorf("in-flight request failed during shutdown: %v", err)
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "Waiting for 1 in-flight requests") || !strings.Contains(output, `"in_flight":1`) {
+		t.Errorf("expected drain log with in-flight count 1, got: %s", output)
+	}
+	if inFlight.Count() != 0 {
+		t.Errorf("expected no requests in flight after shutdown, got %d", inFlight.Count())
+	}
+}
+
+// shutdownRecorder records the order in which shutdown stages reach the mocks
+type shutdownRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *shutdownRecorder) record(stage string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.order = append(r.order, stage)
+}
+
+type mockShutdownServer struct{ recorder *shutdownRecorder }
+
+func (s mockShutdownServer) Shutdown(ctx context.Context) error {
+	s.recorder.record("server")
+	return nil
+}
+
+type mockExports struct{ recorder *shutdownRecorder }
+
+func (e mockExports) Stop() { e.recorder.record("exports") }
+
+type mockPool struct {
+	recorder *shutdownRecorder
+	delay    time.Duration
+}
+
+func (p mockPool) Close() {
+	time.Sleep(p.delay)
+	p.recorder.record("pool")
+}
+
+func TestRunShutdownStagesOrderAndLogs(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	var logs bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&logs, "info", "goUserAPI", "test")
+
+	runShutdownStages(mockShutdownServer{recorder}, mockPool{recorder: recorder}, middleware.NewInFlightTracker(),
+		mockExports{recorder}, shutdownTimeouts{Server: time.Second, Pool: time.Second}, logger)
+
+	if got := strings.Join(recorder.order, ","); got != "server,exports,pool" {
+		t.Errorf("expected stages server,exports,pool, got %s", got)
+	}
+
+	var stages []string
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry["msg"] != "Shutdown stage finished" {
+			continue
+		}
+		if _, ok := entry["duration_ms"]; !ok {
+			t.Errorf("expected duration_ms on stage log, got %v", entry)
+		}
+		stages = append(stages, entry["stage"].(string))
+	}
+	if got := strings.Join(stages, ","); got != "server,exports,pool" {
+		t.Errorf("expected logged stages server,exports,pool, got %s", got)
+	}
+	if strings.Contains(logs.String(), "exceeded shutdown timeout") {
+		t.Errorf("unexpected pool timeout warning: %s", logs.String())
+	}
+}
+
+func TestRunShutdownStagesBoundsPoolClose(t *testing.T) {
+	recorder := &shutdownRecorder{}
+	var logs bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&logs, "info", "goUserAPI", "test")
+
+	start := time.Now()
+	runShutdownStages(mockShutdownServer{recorder}, mockPool{recorder: recorder, delay: time.Second}, middleware.NewInFlightTracker(),
+		mockExports{recorder}, shutdownTimeouts{Server: time.Second, Pool: 50 * time.Millisecond}, logger)
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected shutdown to give up on the pool after its budget, took %s", elapsed)
+	}
+	if !strings.Contains(logs.String(), "Database pool close exceeded shutdown timeout") || !strings.Contains(logs.String(), `"timeout_ms":50`) {
+		t.Errorf("expected pool timeout warning, got: %s", logs.String())
+	}
+}
+
+func TestDrainReadinessFailsReadyzBeforeShutdown(t *testing.T) {
+	probes := handlers.NewProbeHandler()
+	logger := logging.NewStructuredLoggerWithWriter(&bytes.Buffer{}, "info", "goUserAPI", "test")
+
+	readyzStatus := func() int {
+		w := httptest.NewRecorder()
+		probes.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w.Code
+	}
+
+	if status := readyzStatus(); status != http.StatusOK {
+		t.Fatalf("expected /readyz 200 before shutdown, got %d", status)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		drainReadiness(probes, 200*time.Millisecond, logger)
+		close(done)
+	}()
+
+	// During the delay readiness fails while the server keeps serving
+	time.Sleep(50 * time.Millisecond)
+	if status := readyzStatus(); status != http.StatusServiceUnavailable {
+		t.Errorf("expected /readyz 503 during readiness delay, got %d", status)
+	}
+	select {
+	case <-done:
+		t.Fatal("drainReadiness returned before the configured delay")
+	default:
+	}
+
+	<-done
+}
+
+func TestReloadLogLevelFromEnvironment(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "goUserAPI", "test")
+
+	t.Setenv("LOG_LEVEL", "debug")
+	reloadLogLevel(logger)
+	logger.Debug("debug visible")
+	if !strings.Contains(buf.String(), "debug visible") {
+		t.Error("Expected debug output after reloading LOG_LEVEL=debug")
+	}
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	reloadLogLevel(logger)
+	if logger.Level() != slog.LevelDebug {
+		t.Errorf("Expected invalid LOG_LEVEL to keep debug level, got %v", logger.Level())
+	}
+
+	t.Setenv("LOG_LEVEL", "warn")
+	reloadLogLevel(logger)
+	logger.Debug("debug hidden")
+	if strings.Contains(buf.String(), "debug hidden") {
+		t.Error("Expected debug output to stop after reloading LOG_LEVEL=warn")
+	}
+}
+
+func TestRootHandlerReturnsJSONNotFoundForUnmatchedRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"root", "/", http.StatusOK},
+		{"unknown path", "/unknown", http.StatusNotFound},
+		{"mistyped resource", "/user", http.StatusNotFound},
+		{"trailing slash", "/users/", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rootHandler(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if w.Body.String() != "goUserAPI is running" {
+					t.Errorf("Unexpected root body %q", w.Body.String())
+				}
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected JSON content type, got %q", ct)
+			}
+			var response internalerrors.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Expected JSON body, got %q: %v", w.Body.String(), err)
+			}
+			if response.Code != "NOT_FOUND" {
+				t.Errorf("Expected NOT_FOUND, got %q", response.Code)
+			}
+		})
+	}
+}
+
+func TestRoutesAdvertisePerRouteAllowHeader(t *testing.T) {
+	unexpected := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("handler must not be called for %s %s", r.Method, r.URL.Path)
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", usersRoute(unexpected, unexpected, unexpected))
+	mux.HandleFunc("/users/{id}", userRoute(unexpected))
+	mux.HandleFunc("/reports", reportsRoute(unexpected))
+
+	tests := []struct {
+		method string
+		path   string
+		allow  string
+	}{
+		{http.MethodDelete, "/users", "GET, HEAD, POST, PUT"},
+		{http.MethodPatch, "/users", "GET, HEAD, POST, PUT"},
+		// /users/{id} has no PUT, PATCH or DELETE handlers, so only the read methods are advertised
+		{http.MethodPost, "/users/123", "GET, HEAD"},
+		{http.MethodPut, "/users/123", "GET, HEAD"},
+		{http.MethodPatch, "/users/123", "GET, HEAD"},
+		{http.MethodDelete, "/users/123", "GET, HEAD"},
+		{http.MethodOptions, "/users/123", "GET, HEAD"},
+		{http.MethodPost, "/reports", "GET, HEAD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Fatalf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+			}
+			if allow := w.Header().Get("Allow"); allow != tt.allow {
+				t.Errorf("Expected Allow %q, got %q", tt.allow, allow)
+			}
+		})
+	}
+}
+
+func TestReportsRouteRejectsPutWithAllowHeader(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reports", reportsRoute(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("GET handler must not be called for PUT")
+	})))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/reports", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, HEAD" {
+		t.Errorf("Expected Allow GET, HEAD, got %q", allow)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", ct)
+	}
+	var response handlers.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected JSON body, got %q: %v", w.Body.String(), err)
+	}
+	if response.Code != "METHOD_NOT_ALLOWED" {
+		t.Errorf("Expected METHOD_NOT_ALLOWED, got %q", response.Code)
+	}
+	if response.Details != "Method PUT is not allowed. Supported methods: GET, HEAD" {
+		t.Errorf("Unexpected details %q", response.Details)
+	}
+}
+
+func TestHealthzAliasesHealth(t *testing.T) {
+	logger := logging.NewStructuredLogger("error", "goUserAPI", "test")
+	mux := http.NewServeMux()
+	registerHealthRoutes(mux, handlers.NewHealthHandler("goUserAPI", "test", logger))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	var health, healthz handlers.HealthCheckResponse
+	for target, response := range map[string]*handlers.HealthCheckResponse{"/health": &health, "/healthz": &healthz} {
+		w := get(target)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", target, http.StatusOK, w.Code)
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), response); err != nil {
+			t.Fatalf("%s: expected a health response, got %q: %v", target, w.Body.String(), err)
+		}
+	}
+	if health.Status != healthz.Status || health.Service != healthz.Service || health.Version != healthz.Version {
+		t.Errorf("Expected identical health responses, got %+v and %+v", health, healthz)
+	}
+
+	if ping, pingz := get("/health?ping=true").Body.String(), get("/healthz?ping=true").Body.String(); ping != pingz {
+		t.Errorf("Expected identical ping responses, got %q and %q", ping, pingz)
+	}
+}
+
+// stubMigrationStatus reports no migrations
+type stubMigrationStatus struct{}
+
+func (stubMigrationStatus) LoadMigrationFiles() ([]database.Migration, error) { return nil, nil }
+
+func (stubMigrationStatus) GetExecutedMigrations(ctx context.Context) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func TestRegisterReportRoutes(t *testing.T) {
+	logger := logging.NewStructuredLogger("error", "goUserAPI", "test")
+	passThrough := func(next http.Handler) http.Handler { return next }
+
+	newMux := func(enabled bool) *http.ServeMux {
+		appConfig := &config.Config{Application: config.ApplicationConfig{ReportsEnabled: enabled}}
+		reportHandler := handlers.NewReportHandler(logger, nil, &DatabaseAdapter{})
+		mux := http.NewServeMux()
+		registerReportRoutes(mux, appConfig, reportHandler, passThrough, logger)
+		mux.HandleFunc("/", rootHandler)
+		return mux
+	}
+
+	// An invalid limit is rejected before the database is touched, so no pool is needed
+	request := func(mux *http.ServeMux) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports?limit=abc", nil))
+		return w
+	}
+
+	if w := request(newMux(false)); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when reports are disabled, got %d", w.Code)
+	}
+	if w := request(newMux(true)); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected the reports handler to reject an invalid limit with 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRegisterAdminRoutes(t *testing.T) {
+	logger := logging.NewStructuredLogger("error", "goUserAPI", "test")
+
+	newMux := func(enabled bool) *http.ServeMux {
+		appConfig := &config.Config{Application: config.ApplicationConfig{
+			Environment:           "development",
+			AdminEndpointsEnabled: enabled,
+			AdminAPIKey:           "s3cret",
+		}}
+		mux := http.NewServeMux()
+		registerAdminRoutes(mux, appConfig, stubMigrationStatus{}, middleware.NewMaintenanceMode(false), logger)
+		mux.HandleFunc("/", rootHandler)
+		return mux
+	}
+
+	request := func(mux *http.ServeMux, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations", nil)
+		if apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := request(newMux(false), "s3cret"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when admin endpoints are disabled, got %d", w.Code)
+	}
+
+	enabled := newMux(true)
+	if w := request(enabled, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without an API key, got %d", w.Code)
+	}
+	w := request(enabled, "s3cret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with the API key, got %d: %s", w.Code, w.Body.String())
+	}
+	var response handlers.MigrationStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected migration status JSON, got %q: %v", w.Body.String(), err)
+	}
+}
+
+func TestLogCleanupThroughAdminEndpoint(t *testing.T) {
+	logger := logging.NewStructuredLogger("error", "goUserAPI", "test")
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "app_2026-01-01_00-00-00.log")
+	if err := os.WriteFile(oldFile, []byte("INFO: test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write log file: %v", err)
+	}
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	if err := os.Chtimes(oldFile, monthAgo, monthAgo); err != nil {
+		t.Fatalf("Failed to age log file: %v", err)
+	}
+
+	appConfig := &config.Config{
+		Application: config.ApplicationConfig{
+			Environment:           "development",
+			AdminEndpointsEnabled: true,
+			AdminAPIKey:           "s3cret",
+		},
+		Logging: config.LoggingConfig{Dir: dir},
+	}
+	mux := http.NewServeMux()
+	registerAdminRoutes(mux, appConfig, stubMigrationStatus{}, middleware.NewMaintenanceMode(false), logger)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/logs/cleanup?days=7", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without an API key, got %d", w.Code)
+	}
+
+	req.Header.Set(middleware.APIKeyHeader, "s3cret")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with the API key, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := os.Stat(oldFile); !os.IsNotExist(err) {
+		t.Error("Expected the old log file to be removed")
+	}
+}
+
+func TestMaintenanceModeToggledThroughAdminEndpoint(t *testing.T) {
+	logger := logging.NewStructuredLogger("error", "goUserAPI", "test")
+	appConfig := &config.Config{Application: config.ApplicationConfig{
+		Environment:           "development",
+		AdminEndpointsEnabled: true,
+		AdminAPIKey:           "s3cret",
+	}}
+	maintenance := middleware.NewMaintenanceMode(false)
+	mux := http.NewServeMux()
+	registerAdminRoutes(mux, appConfig, stubMigrationStatus{}, maintenance, logger)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("/users", usersRoute(ok, ok, ok))
+	handler := maintenance.Middleware(mux)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(middleware.APIKeyHeader, "s3cret")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := request(http.MethodPost, "/users", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected POST to succeed before maintenance, got %d", w.Code)
+	}
+	if w := request(http.MethodPut, "/admin/maintenance", `{"enabled": true}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected maintenance toggle to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := request(http.MethodPost, "/users", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected POST to return 503 in maintenance, got %d", w.Code)
+	}
+	if w := request(http.MethodGet, "/users", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected GET to keep working in maintenance, got %d", w.Code)
+	}
+	if w := request(http.MethodPut, "/admin/maintenance", `{"enabled": false}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected maintenance toggle to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := request(http.MethodPost, "/users", ""); w.Code != http.StatusOK {
+		t.Errorf("Expected POST to succeed after maintenance, got %d", w.Code)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_NAME", "postgres")
+	t.Setenv("DB_PASSWORD", "")
+
+	var buf bytes.Buffer
+	err := checkConfig(&buf)
+	if err == nil {
+		t.Fatal("Expected an error when DB_PASSWORD is missing")
+	}
+	if !strings.Contains(err.Error(), "DB_PASSWORD") {
+		t.Errorf("Expected error to list DB_PASSWORD, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "  - DB_PASSWORD: required environment variable is not set") {
+		t.Errorf("Expected report to list DB_PASSWORD, got %q", buf.String())
+	}
+
+	t.Setenv("DB_PASSWORD", "postgres")
+	buf.Reset()
+	if err := checkConfig(&buf); err != nil {
+		t.Fatalf("Expected valid configuration, got %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "Configuration OK") {
+		t.Errorf("Expected OK report, got %q", buf.String())
+	}
+}
+
+func TestDatabaseStartupReadinessTransition(t *testing.T) {
+	logger := logging.NewStructuredLoggerWithWriter(&bytes.Buffer{}, "info", "goUserAPI", "test")
+	probes := handlers.NewProbeHandler()
+	startup := &databaseStartup{}
+	probes.AddChecker(startup)
+
+	readyz := func() int {
+		w := httptest.NewRecorder()
+		probes.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w.Code
+	}
+	livez := httptest.NewRecorder()
+	probes.Livez(livez, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if livez.Code != http.StatusOK {
+		t.Errorf("Expected /livez 200 without a database, got %d", livez.Code)
+	}
+	if code := readyz(); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected /readyz 503 before the database connects, got %d", code)
+	}
+
+	// The connector fails twice before the database comes up
+	var attempts, migrations atomic.Int32
+	connect := func(ctx context.Context) error {
+		if attempts.Add(1) <= 2 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	migrate := func(ctx context.Context) error {
+		migrations.Add(1)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go startup.run(ctx, connect, migrate, 5*time.Millisecond, logger)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for readyz() != http.StatusOK {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected /readyz to become 200 after the background connect succeeded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("Expected 3 connection attempts, got %d", got)
+	}
+	if got := migrations.Load(); got != 1 {
+		t.Errorf("Expected migrations to run once after connecting, got %d", got)
+	}
+}
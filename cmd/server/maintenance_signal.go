@@ -0,0 +1,30 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+// watchMaintenanceSignals turns maintenance mode on at SIGUSR1 and off at SIGUSR2
+// Unlike /admin/maintenance this works in production, where admin endpoints are refused.
+func watchMaintenanceSignals(maintenance *middleware.MaintenanceMode, logger *logging.Logger) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	for sig := range sigChan {
+		applyMaintenanceSignal(sig, maintenance, logger)
+	}
+}
+
+// applyMaintenanceSignal enables maintenance mode for SIGUSR1 and disables it for SIGUSR2
+func applyMaintenanceSignal(sig os.Signal, maintenance *middleware.MaintenanceMode, logger *logging.Logger) {
+	enabled := sig == syscall.SIGUSR1
+	maintenance.SetEnabled(enabled)
+	logger.Warn("Maintenance mode changed", "enabled", enabled, "signal", sig.String())
+}
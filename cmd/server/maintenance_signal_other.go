@@ -0,0 +1,12 @@
+//go:build !unix
+
+package main
+
+import (
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+// watchMaintenanceSignals does nothing where SIGUSR1 and SIGUSR2 do not exist; maintenance mode is
+// then only toggled by MAINTENANCE_MODE and /admin/maintenance
+func watchMaintenanceSignals(maintenance *middleware.MaintenanceMode, logger *logging.Logger) {}
@@ -0,0 +1,51 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+func TestMaintenanceSignalsToggleMode(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "goUserAPI", "test")
+	maintenance := middleware.NewMaintenanceMode(false)
+	handler := maintenance.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(method string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, "/users", nil))
+		return w.Code
+	}
+
+	applyMaintenanceSignal(syscall.SIGUSR1, maintenance, logger)
+	if !maintenance.Enabled() {
+		t.Fatal("Expected SIGUSR1 to enable maintenance mode")
+	}
+	if code := serve(http.MethodPost); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected POST to be rejected with 503 in maintenance mode, got %d", code)
+	}
+	if code := serve(http.MethodGet); code != http.StatusOK {
+		t.Errorf("Expected GET to pass in maintenance mode, got %d", code)
+	}
+	if !strings.Contains(buf.String(), `"signal":"user defined signal 1"`) {
+		t.Errorf("Expected the change to be logged with its signal, got %s", buf.String())
+	}
+
+	applyMaintenanceSignal(syscall.SIGUSR2, maintenance, logger)
+	if maintenance.Enabled() {
+		t.Fatal("Expected SIGUSR2 to disable maintenance mode")
+	}
+	if code := serve(http.MethodPost); code != http.StatusOK {
+		t.Errorf("Expected POST to pass after SIGUSR2, got %d", code)
+	}
+}
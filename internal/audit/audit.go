@@ -0,0 +1,64 @@
+// Package audit records mutating user operations as dedicated structured log entries.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+// Audited actions on users
+const (
+	ActionUserCreate = "user.create"
+	ActionUserUpdate = "user.update"
+	ActionUserDelete = "user.delete"
+)
+
+const (
+	// APIKeyHeader identifies the calling client; only a hash of it is ever logged
+	APIKeyHeader = "X-API-Key"
+	// AnonymousActor is recorded when the request carries no API key
+	AnonymousActor = "anonymous"
+)
+
+var (
+	mu sync.RWMutex
+	// logger is info-level regardless of LOG_LEVEL so audit entries are never filtered out
+	logger = logging.NewStructuredLogger(logging.LevelInfo, "goUserAPI", "")
+)
+
+// SetLogger replaces the logger audit entries are written to
+func SetLogger(l *logging.Logger) {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = l
+}
+
+// Record emits an audit entry for action on the user identified by userID
+// actorKey is the caller's raw API key, or empty for anonymous requests
+func Record(ctx context.Context, action, userID, actorKey string) {
+	mu.RLock()
+	l := logger
+	mu.RUnlock()
+
+	l.WithCorrelationID(middleware.GetCorrelationID(ctx)).Info("Audit event",
+		"audit", true,
+		"action", action,
+		"target_user_id", userID,
+		"actor", Actor(actorKey),
+		logging.FieldRequestID, middleware.GetRequestID(ctx),
+	)
+}
+
+// Actor derives a stable, non-reversible actor identifier from an API key
+func Actor(apiKey string) string {
+	if apiKey == "" {
+		return AnonymousActor
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key:" + hex.EncodeToString(sum[:8])
+}
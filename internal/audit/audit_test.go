@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+func captureAudit(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := logger
+	SetLogger(logging.NewStructuredLoggerWithWriter(&buf, logging.LevelInfo, "goUserAPI", "test"))
+	t.Cleanup(func() { SetLogger(previous) })
+	return &buf
+}
+
+func TestRecord(t *testing.T) {
+	buf := captureAudit(t)
+	ctx := middleware.SetRequestID(context.Background(), "req-123")
+
+	Record(ctx, ActionUserCreate, "550e8400-e29b-41d4-a716-446655440000", "secret-api-key")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode audit entry %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"audit":          true,
+		"action":         ActionUserCreate,
+		"target_user_id": "550e8400-e29b-41d4-a716-446655440000",
+		"actor":          Actor("secret-api-key"),
+		"req_id":         "req-123",
+	}
+	for field, want := range expected {
+		if entry[field] != want {
+			t.Errorf("Expected %s=%v, got %v", field, want, entry[field])
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret-api-key") {
+		t.Error("Raw API key must never be logged")
+	}
+}
+
+func TestActor(t *testing.T) {
+	if got := Actor(""); got != AnonymousActor {
+		t.Errorf("Actor(\"\") = %q, want %q", got, AnonymousActor)
+	}
+
+	first := Actor("key-a")
+	if first != Actor("key-a") {
+		t.Error("Actor must be stable for the same key")
+	}
+	if first == Actor("key-b") {
+		t.Error("Actor must differ between keys")
+	}
+	if !strings.HasPrefix(first, "key:") {
+		t.Errorf("Actor(%q) = %q, want key: prefix", "key-a", first)
+	}
+}
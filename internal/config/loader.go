@@ -6,6 +6,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -46,30 +48,93 @@ var RequiredEnvironmentVariables = []string{
 
 // OptionalEnvironmentVariables defines optional environment variables with defaults
 var OptionalEnvironmentVariables = map[string]string{
-	"DB_PORT":              "5432",
-	"DB_SSL_MODE":          "disable",
-	"DB_MAX_CONNECTIONS":   "25",
-	"DB_MIN_CONNS":         "5",
-	"APP_HOST":             "0.0.0.0",
-	"APP_PORT":             "8080",
-	"LOG_LEVEL":            "info",
-	"LOG_FORMAT":           "json",
-	"ENVIRONMENT":          "development",
-	"SERVER_DEBUG":         "false",
-	"SERVER_READ_TIMEOUT":  "30",
-	"SERVER_WRITE_TIMEOUT": "30",
-	"SERVER_IDLE_TIMEOUT":  "120",
-	"SHUTDOWN_TIMEOUT":     "30",
-	"RATE_LIMIT_REQUESTS":  "100",
-	"RATE_LIMIT_WINDOW":    "1m",
-	"METRICS_ENABLED":      "false",
-	"HEALTH_CHECK_ENABLED": "true",
+	"DB_PORT":                     "5432",
+	"DB_SSL_MODE":                 "disable",
+	"DB_MAX_CONNECTIONS":          "25",
+	"DB_MIN_CONNS":                "5",
+	"APP_HOST":                    "0.0.0.0",
+	"APP_PORT":                    "8080",
+	"LOG_LEVEL":                   "info",
+	"LOG_FORMAT":                  "json",
+	"LOG_REDACT_FIELDS":           "password,email",
+	"REQUEST_ID_DEDUP_WINDOW":     "1000",
+	"SLOW_REQUEST_THRESHOLD":      "1s",
+	"LOG_ACCESS_FORMAT":           "json",
+	"LOG_DIR":                     "/app/logs",
+	"LOG_RETENTION_DAYS":          "0",
+	"LOG_MAX_SIZE_MB":             "100",
+	"LOG_MAX_BACKUPS":             "5",
+	"LOG_ASYNC":                   "false",
+	"LOG_ASYNC_QUEUE_SIZE":        "1024",
+	"LOG_ASYNC_POLICY":            "block",
+	"ENVIRONMENT":                 "development",
+	"SERVER_DEBUG":                "false",
+	"SERVER_READ_TIMEOUT":         "30",
+	"SERVER_WRITE_TIMEOUT":        "30",
+	"SERVER_IDLE_TIMEOUT":         "120",
+	"MAX_BODY_SIZE":               "1048576",
+	"TRUSTED_PROXIES":             "",
+	"GZIP_MIN_SIZE":               "1024",
+	"GZIP_CONTENT_TYPES":          "application/json,text/csv",
+	"CORS_ALLOWED_ORIGINS":        "",
+	"REQUEST_CONTENT_ENCODINGS":   "gzip",
+	"VALIDATED_HEADERS":           "",
+	"SHUTDOWN_TIMEOUT":            "30",
+	"SHUTDOWN_READINESS_DELAY":    "0",
+	"SERVER_SHUTDOWN_TIMEOUT":     "",
+	"POOL_SHUTDOWN_TIMEOUT":       "5s",
+	"RATE_LIMIT_REQUESTS":         "100",
+	"RATE_LIMIT_WINDOW":           "1m",
+	"METRICS_ENABLED":             "false",
+	"HEALTH_CHECK_ENABLED":        "true",
+	"HEALTH_CHECK_MIGRATIONS":     "false",
+	"HEALTH_CHECK_TIMEOUT":        "2s",
+	"VALIDATION_AGGREGATE_ERRORS": "false",
+	"STRICT_SCHEMA":               "false",
+	"MIN_AGE":                     "1",
+	"MAX_AGE":                     "120",
+	"DEFAULT_PAGE_SIZE":           "20",
+	"MAX_PAGE_SIZE":               "100",
+	"MAX_OFFSET":                  "10000",
+	"MAX_RESULT_ROWS":             "1000",
+	"MAX_QUERY_PARAMS":            "50",
+	"MAX_NAME_LENGTH":             "100",
+	"ALLOWED_NAME_SCRIPTS":        "",
+	"MAX_PAYLOAD_BYTES":           "1048576",
+	"REJECT_DUPLICATE_NAMES":      "false",
+	"MULTI_TENANT":                "false",
+	"ADMIN_ENDPOINTS_ENABLED":     "false",
+	"REPORTS_ENABLED":             "true",
+	"ENVELOPE_RESPONSES":          "false",
+	"ADMIN_API_KEY":               "",
+	"START_WITHOUT_DB":            "false",
+	"RESPONSE_FIELD_CASE":         "snake",
+	"NAME_NORMALIZATION":          "trim",
+	"MAINTENANCE_MODE":            "false",
+	"IMPORT_API_KEY":              "",
+	"DB_STATEMENT_TIMEOUT":        "0",
+	"DB_ACQUIRE_TIMEOUT":          "0",
+	"DB_SSL_ROOT_CERT":            "",
+	"DB_SSL_CERT":                 "",
+	"DB_SSL_KEY":                  "",
+	"DATABASE_URL":                "",
+	"VERIFY_INDEXES":              "false",
+	"EXPLAIN_SLOW_QUERIES":        "false",
+	"DB_QUERY_RETRY":              "false",
+	"USER_COUNT_CACHE_TTL":        "0",
+	"POOL_STATS_INTERVAL":         "0",
+	"OTEL_EXPORTER_OTLP_ENDPOINT": "",
 }
 
 // ValidateRequired validates required environment variables
 func ValidateRequired() ValidationErrors {
 	var errors ValidationErrors
 
+	// A DATABASE_URL carries the connection settings, leaving the DB_* variables as optional fallbacks
+	if os.Getenv("DATABASE_URL") != "" {
+		return errors
+	}
+
 	for _, envVar := range RequiredEnvironmentVariables {
 		if value := os.Getenv(envVar); value == "" {
 			errors = append(errors, ValidationError{
@@ -251,6 +316,18 @@ func Load() (*Config, error) {
 			WriteTimeout: getEnvInt("SERVER_WRITE_TIMEOUT", 30),
 			IdleTimeout:  getEnvInt("SERVER_IDLE_TIMEOUT", 120),
 			Debug:        getEnvBool("SERVER_DEBUG", false),
+			MaxBodySize:  getEnvInt64("MAX_BODY_SIZE", 1048576),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES", nil),
+
+			GzipMinSize:      getEnvInt("GZIP_MIN_SIZE", 1024),
+			GzipContentTypes: getEnvList("GZIP_CONTENT_TYPES", []string{"application/json", "text/csv"}),
+
+			CORSAllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS", nil),
+
+			RequestContentEncodings: getEnvList("REQUEST_CONTENT_ENCODINGS", []string{"gzip"}),
+
+			ValidatedHeaders: getEnvList("VALIDATED_HEADERS", nil),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -261,15 +338,56 @@ func Load() (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 			MaxConns: getEnvInt("DB_MAX_CONNECTIONS", 25),
 			MinConns: getEnvInt("DB_MIN_CONNS", 5),
+
+			StatementTimeout: getEnvDuration("DB_STATEMENT_TIMEOUT", 0),
+			AcquireTimeout:   getEnvDuration("DB_ACQUIRE_TIMEOUT", 0),
+
+			SSLRootCert: getEnv("DB_SSL_ROOT_CERT", ""),
+			SSLCert:     getEnv("DB_SSL_CERT", ""),
+			SSLKey:      getEnv("DB_SSL_KEY", ""),
+
+			URL: getEnv("DATABASE_URL", ""),
+
+			VerifyIndexes: getEnvBool("VERIFY_INDEXES", false),
+
+			ExplainSlowQueries: getEnvBool("EXPLAIN_SLOW_QUERIES", false),
+
+			QueryRetry: getEnvBool("DB_QUERY_RETRY", false),
+
+			UserCountCacheTTL: getEnvDuration("USER_COUNT_CACHE_TTL", 0),
+
+			PoolStatsInterval: getEnvDuration("POOL_STATS_INTERVAL", 0),
 		},
 		Logging: LoggingConfig{
-			Level:  getEnv("LOG_LEVEL", "info"),
-			Format: getEnv("LOG_FORMAT", "json"),
+			Level:        getEnv("LOG_LEVEL", "info"),
+			Format:       getEnv("LOG_FORMAT", "json"),
+			RedactFields: getEnvList("LOG_REDACT_FIELDS", []string{"password", "email"}),
+
+			RequestIDDedupWindow: getEnvInt("REQUEST_ID_DEDUP_WINDOW", 1000),
+
+			SlowRequestThreshold: getEnvDuration("SLOW_REQUEST_THRESHOLD", time.Second),
+
+			AccessFormat: getEnv("LOG_ACCESS_FORMAT", "json"),
+
+			Dir:           getEnv("LOG_DIR", "/app/logs"),
+			RetentionDays: getEnvInt("LOG_RETENTION_DAYS", 0),
+			MaxSizeMB:     getEnvInt("LOG_MAX_SIZE_MB", 100),
+			MaxBackups:    getEnvInt("LOG_MAX_BACKUPS", 5),
+
+			Async:          getEnvBool("LOG_ASYNC", false),
+			AsyncQueueSize: getEnvInt("LOG_ASYNC_QUEUE_SIZE", 1024),
+			AsyncPolicy:    getEnv("LOG_ASYNC_POLICY", "block"),
 		},
 		HealthCheck: HealthCheckConfig{
 			Enabled: getEnvBool("HEALTH_CHECK_ENABLED", true),
 			Port:    getEnvInt("APP_PORT", 8080),
 			Host:    getEnv("APP_HOST", "0.0.0.0"),
+
+			Migrations: getEnvBool("HEALTH_CHECK_MIGRATIONS", false),
+			Timeout:    getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		},
+		Tracing: TracingConfig{
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
 		},
 		Application: ApplicationConfig{
 			Environment:       getEnv("ENVIRONMENT", "development"),
@@ -277,6 +395,45 @@ func Load() (*Config, error) {
 			RateLimitRequests: getEnvInt("RATE_LIMIT_REQUESTS", 100),
 			RateLimitWindow:   getEnv("RATE_LIMIT_WINDOW", "1m"),
 			MetricsEnabled:    getEnvBool("METRICS_ENABLED", false),
+
+			AggregateValidationErrors: getEnvBool("VALIDATION_AGGREGATE_ERRORS", false),
+			StrictSchema:              getEnvBool("STRICT_SCHEMA", false),
+			MinAge:                    getEnvInt("MIN_AGE", 1),
+			MaxAge:                    getEnvInt("MAX_AGE", 120),
+			DefaultPageSize:           getEnvInt("DEFAULT_PAGE_SIZE", 20),
+			MaxPageSize:               getEnvInt("MAX_PAGE_SIZE", 100),
+			MaxOffset:                 getEnvInt("MAX_OFFSET", 10000),
+			MaxResultRows:             getEnvInt("MAX_RESULT_ROWS", 1000),
+			MaxQueryParams:            getEnvInt("MAX_QUERY_PARAMS", 50),
+			ShutdownReadinessDelay:    getEnvInt("SHUTDOWN_READINESS_DELAY", 0),
+
+			ServerShutdownTimeout: getEnvDuration("SERVER_SHUTDOWN_TIMEOUT", time.Duration(getEnvInt("SHUTDOWN_TIMEOUT", 30))*time.Second),
+			PoolShutdownTimeout:   getEnvDuration("POOL_SHUTDOWN_TIMEOUT", 5*time.Second),
+
+			MaxNameLength:   getEnvInt("MAX_NAME_LENGTH", 100),
+			MaxPayloadBytes: getEnvInt64("MAX_PAYLOAD_BYTES", 1048576),
+
+			AllowedNameScripts: getEnvList("ALLOWED_NAME_SCRIPTS", nil),
+
+			RejectDuplicateNames: getEnvBool("REJECT_DUPLICATE_NAMES", false),
+
+			MultiTenant: getEnvBool("MULTI_TENANT", false),
+
+			AdminEndpointsEnabled: getEnvBool("ADMIN_ENDPOINTS_ENABLED", false),
+			AdminAPIKey:           getEnv("ADMIN_API_KEY", ""),
+
+			StartWithoutDB: getEnvBool("START_WITHOUT_DB", false),
+
+			ResponseFieldCase: getEnv("RESPONSE_FIELD_CASE", "snake"),
+			EnvelopeResponses: getEnvBool("ENVELOPE_RESPONSES", false),
+
+			ReportsEnabled: getEnvBool("REPORTS_ENABLED", true),
+
+			NameNormalization: getEnv("NAME_NORMALIZATION", "trim"),
+
+			MaintenanceMode: getEnvBool("MAINTENANCE_MODE", false),
+
+			ImportAPIKey: getEnv("IMPORT_API_KEY", ""),
 		},
 	}
 
@@ -306,6 +463,43 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvInt64 gets environment variable as 64-bit integer with default value
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration gets environment variable as a Go duration (e.g. "500ms", "5s") with default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList gets environment variable as a comma-separated list with default value
+// Blank entries are dropped and surrounding whitespace is trimmed
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // getEnvBool gets environment variable as boolean with default value
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
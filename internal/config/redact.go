@@ -0,0 +1,73 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RedactedValue replaces secret configuration values in Redacted
+const RedactedValue = "***"
+
+// secretFieldMarkers identify fields holding secrets: passwords, API keys, tokens, TLS private key paths
+// and connection URLs, which may embed credentials
+var secretFieldMarkers = []string{"Password", "Secret", "Token", "APIKey", "KeyFile", "KeyPath", "SSLKey", "URL"}
+
+// Redacted returns every configuration value keyed by section and snake_case field name, with secrets
+// replaced by RedactedValue so the result is safe to log. Unset secrets stay empty so the dump still
+// shows whether they were configured. New fields are included automatically.
+func (c *Config) Redacted() map[string]any {
+	sections := make(map[string]any)
+	cfg := reflect.ValueOf(c).Elem()
+	for i := 0; i < cfg.NumField(); i++ {
+		section := cfg.Field(i)
+		if section.Kind() != reflect.Struct {
+			continue
+		}
+
+		fields := make(map[string]any, section.NumField())
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			if !field.IsExported() {
+				continue
+			}
+			fields[snakeCase(field.Name)] = redactedValue(field.Name, section.Field(j))
+		}
+		sections[snakeCase(cfg.Type().Field(i).Name)] = fields
+	}
+	return sections
+}
+
+// redactedValue masks secret fields and renders durations as strings
+func redactedValue(name string, value reflect.Value) any {
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			if value.IsZero() {
+				return value.Interface()
+			}
+			return RedactedValue
+		}
+	}
+	if d, ok := value.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return value.Interface()
+}
+
+// snakeCase converts a Go field name such as MaxBodySize or OTLPEndpoint to max_body_size or otlp_endpoint
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at a lower-to-upper change, or before the last capital of an acronym
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -1,6 +1,8 @@
 // Package config provides configuration types and structures for the goUserAPI service.
 package config
 
+import "time"
+
 // Config represents the application configuration
 type Config struct {
 	Server      ServerConfig
@@ -8,6 +10,7 @@ type Config struct {
 	Logging     LoggingConfig
 	HealthCheck HealthCheckConfig
 	Application ApplicationConfig
+	Tracing     TracingConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -18,6 +21,25 @@ type ServerConfig struct {
 	WriteTimeout int    // Write timeout in seconds
 	IdleTimeout  int    // Idle timeout in seconds
 	Debug        bool   // Enable debug mode
+	MaxBodySize  int64  // Maximum request body size in bytes
+
+	// TrustedProxies lists proxy IPs or CIDR ranges whose X-Forwarded-For is used to resolve client IPs
+	TrustedProxies []string
+
+	// GzipMinSize is the smallest response body in bytes that is gzip-compressed
+	GzipMinSize int
+	// GzipContentTypes lists the response media types eligible for compression
+	GzipContentTypes []string
+
+	// CORSAllowedOrigins lists the origins allowed to make cross-origin requests; empty disables CORS
+	CORSAllowedOrigins []string
+
+	// ValidatedHeaders lists request headers whose values are rejected when they contain control characters
+	// or fail Unicode security validation; empty validates none
+	ValidatedHeaders []string
+
+	// RequestContentEncodings lists the Content-Encodings accepted on request bodies; "identity" alone accepts only uncompressed bodies
+	RequestContentEncodings []string
 }
 
 // DatabaseConfig holds database configuration
@@ -30,12 +52,50 @@ type DatabaseConfig struct {
 	SSLMode  string // SSL mode (disable, require, etc.)
 	MaxConns int    // Maximum database connections
 	MinConns int    // Minimum database connections
+
+	StatementTimeout time.Duration // Server-side statement_timeout per connection (0 disables)
+	AcquireTimeout   time.Duration // Maximum wait for a free pool connection (0 waits for the operation timeout)
+
+	SSLRootCert string // CA certificate file used to verify the server in verify-ca and verify-full modes
+	SSLCert     string // Client certificate file for certificate authentication
+	SSLKey      string // Client private key file matching SSLCert
+
+	// URL is a full connection string (DATABASE_URL); when set it takes precedence over the individual fields
+	URL string
+
+	VerifyIndexes bool // Warn at startup about expected users table indexes that are missing
+
+	ExplainSlowQueries bool // Log EXPLAIN plans of slow GetUsers/GetReports queries (ignored in production)
+
+	QueryRetry bool // Retry user and report reads once on connection errors; writes are never retried
+
+	UserCountCacheTTL time.Duration // Serve GET /users total_count from a COUNT(*) cached this long (0 disables)
+
+	PoolStatsInterval time.Duration // Log connection pool saturation at debug level this often (0 disables)
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	Level  string // Log level (debug, info, warn, error)
-	Format string // Log format (json, text)
+	Level        string   // Log level (debug, info, warn, error)
+	Format       string   // Log format (json, text)
+	RedactFields []string // Log argument keys whose values are replaced with [REDACTED]
+
+	// RequestIDDedupWindow is how many recent X-Request-ID values are remembered to warn about repeats (0 disables)
+	RequestIDDedupWindow int
+
+	// SlowRequestThreshold is the request duration above which a slow_request warning is logged (0 disables)
+	SlowRequestThreshold time.Duration
+
+	AccessFormat string // Request completion log format (json, clf)
+
+	Dir           string // Directory for FileLogger output and retention cleanup
+	RetentionDays int    // Log files older than this many days are removed daily (0 disables)
+	MaxSizeMB     int    // FileLogger starts a new file once the active one reaches this size (0 disables rotation)
+	MaxBackups    int    // Rotated files kept per log kind; older ones are removed on rotation (0 keeps all)
+
+	Async          bool   // Write log files from a background goroutine instead of the caller
+	AsyncQueueSize int    // Messages buffered per log file in async mode
+	AsyncPolicy    string // What a full async queue does: block the caller or drop the message (block, drop)
 }
 
 // HealthCheckConfig holds health check configuration
@@ -43,6 +103,16 @@ type HealthCheckConfig struct {
 	Enabled bool   // Enable health check endpoint
 	Port    int    // Health check port (deprecated, uses APP_PORT)
 	Host    string // Health check host (deprecated, uses APP_HOST)
+
+	Migrations bool // Fail /readyz while migration files are pending
+
+	// Timeout is the budget shared by all checkers of one /health or /readyz request (0 disables)
+	Timeout time.Duration
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	OTLPEndpoint string // OTLP/HTTP collector endpoint; tracing is disabled when empty
 }
 
 // ApplicationConfig holds application-specific configuration
@@ -52,4 +122,43 @@ type ApplicationConfig struct {
 	RateLimitRequests int    // Rate limit requests per window
 	RateLimitWindow   string // Rate limit time window
 	MetricsEnabled    bool   // Enable metrics collection
+
+	AggregateValidationErrors bool // Report all failing fields in one VALIDATION_FAILED response
+	StrictSchema              bool // Check request bodies against the embedded JSON Schema first (SCHEMA_VALIDATION_FAILED)
+	MinAge                    int  // Minimum accepted user age (inclusive)
+	MaxAge                    int  // Maximum accepted user age (inclusive)
+	DefaultPageSize           int  // Page size used when limit is omitted
+	MaxPageSize               int  // Largest accepted limit
+	MaxOffset                 int  // Largest accepted offset (0 disables)
+	MaxResultRows             int  // Hard cap on rows a single database read returns, at least MaxPageSize
+	MaxQueryParams            int  // Most query parameter values accepted by GET /reports (0 disables)
+	ShutdownReadinessDelay    int  // Seconds /readyz reports 503 before the server stops
+
+	ServerShutdownTimeout time.Duration // Budget for draining the HTTP server on shutdown (defaults to ShutdownTimeout)
+	PoolShutdownTimeout   time.Duration // Budget for closing the database pool on shutdown
+
+	MaxNameLength   int   // Maximum length in bytes of first_name and last_name
+	MaxPayloadBytes int64 // Maximum size of a POST /users request body
+
+	AllowedNameScripts []string // Unicode scripts (e.g. Latin, Cyrillic) names may use; empty allows all
+
+	RejectDuplicateNames bool // Reject a user whose first and last name match an existing user, ignoring case (best-effort)
+
+	MultiTenant bool // Require X-Tenant-ID on user and report routes and scope every user query to that tenant
+
+	AdminEndpointsEnabled bool   // Register /admin/* routes; refused in production
+	AdminAPIKey           string // X-API-Key value required by /admin/* routes
+
+	StartWithoutDB bool // Serve probes and retry the database in the background when it is down at startup
+
+	ResponseFieldCase string // Key case of user objects in JSON responses (snake, camel)
+	EnvelopeResponses bool   // Wrap GET /users and GET /reports bodies as {data, meta}
+
+	ReportsEnabled bool // Register /reports and the report export routes
+
+	NameNormalization string // Rewrite applied to submitted names (none, trim, trim_title)
+
+	MaintenanceMode bool // Start with POST/PUT/PATCH/DELETE answered by 503 MAINTENANCE
+
+	ImportAPIKey string // X-API-Key value that lets POST /users set recording_date; empty disables imports
 }
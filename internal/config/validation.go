@@ -3,7 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"mime"
+	"net/netip"
+	"net/url"
+	"os"
 	"strings"
+	"unicode"
 )
 
 // Validate validates the configuration and returns any errors
@@ -30,6 +35,17 @@ func Validate(config *Config) error {
 		validationErrors = append(validationErrors, err.Error())
 	}
 
+	// MaxBodySize bounds every request before the POST /users payload limit is checked, so a larger
+	// payload limit would never be reached and its 413 message would report the wrong size
+	if config.Server.MaxBodySize > 0 && config.Application.MaxPayloadBytes > config.Server.MaxBodySize {
+		validationErrors = append(validationErrors, "max payload bytes cannot exceed max body size")
+	}
+
+	// Validate health check configuration
+	if config.HealthCheck.Timeout < 0 {
+		validationErrors = append(validationErrors, "health check timeout cannot be negative")
+	}
+
 	if len(validationErrors) > 0 {
 		return fmt.Errorf("configuration validation failed: %s", strings.Join(validationErrors, "; "))
 	}
@@ -37,8 +53,38 @@ func Validate(config *Config) error {
 	return nil
 }
 
+// validateDatabaseURL checks that DATABASE_URL is a postgres:// or postgresql:// URL naming a host
+// Parse errors are reported without the URL itself so an embedded password never reaches the logs.
+func validateDatabaseURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("invalid DATABASE_URL: %v", err)
+	}
+
+	if parsed.Scheme != "postgres" && parsed.Scheme != "postgresql" {
+		return fmt.Errorf("invalid DATABASE_URL: scheme must be postgres or postgresql, got %q", parsed.Scheme)
+	}
+
+	// pgx also accepts the host as a query parameter, e.g. a Unix socket directory
+	if parsed.Host == "" && parsed.Query().Get("host") == "" {
+		return errors.New("invalid DATABASE_URL: host is required")
+	}
+
+	return nil
+}
+
 // validateDatabaseConfig validates database configuration
 func validateDatabaseConfig(db *DatabaseConfig) error {
+	if db.URL != "" {
+		if err := validateDatabaseURL(db.URL); err != nil {
+			return err
+		}
+	}
+
 	if db.Host == "" {
 		return errors.New("database host is required")
 	}
@@ -72,6 +118,23 @@ func validateDatabaseConfig(db *DatabaseConfig) error {
 		return fmt.Errorf("invalid SSL mode: %s, must be one of: %s", db.SSLMode, strings.Join(validSSLModes, ", "))
 	}
 
+	if (db.SSLMode == "verify-ca" || db.SSLMode == "verify-full") && db.SSLRootCert == "" {
+		return fmt.Errorf("database SSL root certificate is required when SSL mode is %s", db.SSLMode)
+	}
+
+	if (db.SSLCert == "") != (db.SSLKey == "") {
+		return errors.New("database SSL client certificate and key must be set together")
+	}
+
+	for _, path := range []string{db.SSLRootCert, db.SSLCert, db.SSLKey} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("database SSL file is not readable: %w", err)
+		}
+	}
+
 	if db.MaxConns <= 0 {
 		return errors.New("database max connections must be positive")
 	}
@@ -80,6 +143,22 @@ func validateDatabaseConfig(db *DatabaseConfig) error {
 		return errors.New("database min connections must be between 0 and max connections")
 	}
 
+	if db.StatementTimeout < 0 {
+		return errors.New("database statement timeout cannot be negative")
+	}
+
+	if db.UserCountCacheTTL < 0 {
+		return errors.New("user count cache TTL cannot be negative")
+	}
+
+	if db.AcquireTimeout < 0 {
+		return errors.New("database acquire timeout cannot be negative")
+	}
+
+	if db.PoolStatsInterval < 0 {
+		return errors.New("pool stats interval cannot be negative")
+	}
+
 	return nil
 }
 
@@ -101,6 +180,51 @@ func validateServerConfig(server *ServerConfig) error {
 		return errors.New("server idle timeout must be positive")
 	}
 
+	if server.MaxBodySize <= 0 {
+		return errors.New("server max body size must be positive")
+	}
+
+	for _, proxy := range server.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			return fmt.Errorf("trusted proxy %q must be an IP address or CIDR range", proxy)
+		}
+	}
+
+	if server.GzipMinSize < 0 {
+		return errors.New("gzip min size cannot be negative")
+	}
+
+	for _, contentType := range server.GzipContentTypes {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !strings.Contains(mediaType, "/") {
+			return fmt.Errorf("gzip content type %q is not a valid media type", contentType)
+		}
+	}
+
+	for _, origin := range server.CORSAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.Path != "" {
+			return fmt.Errorf("CORS allowed origin %q must be \"*\" or a scheme://host[:port] origin", origin)
+		}
+	}
+
+	for _, encoding := range server.RequestContentEncodings {
+		if !strings.EqualFold(encoding, "gzip") && !strings.EqualFold(encoding, "identity") {
+			return fmt.Errorf("request content encoding %q is not supported (use gzip or identity)", encoding)
+		}
+	}
+
+	for _, name := range server.ValidatedHeaders {
+		if !isHeaderName(name) {
+			return fmt.Errorf("validated header %q is not a valid header name", name)
+		}
+	}
+
 	return nil
 }
 
@@ -130,6 +254,38 @@ func validateLoggingConfig(logging *LoggingConfig) error {
 		return fmt.Errorf("invalid log format: %s, must be one of: %s", logging.Format, strings.Join(validFormats, ", "))
 	}
 
+	if logging.RequestIDDedupWindow < 0 {
+		return errors.New("request ID dedup window cannot be negative")
+	}
+
+	if logging.SlowRequestThreshold < 0 {
+		return errors.New("slow request threshold cannot be negative")
+	}
+
+	if logging.AccessFormat != "json" && logging.AccessFormat != "clf" {
+		return fmt.Errorf("invalid access log format: %s, must be one of: json, clf", logging.AccessFormat)
+	}
+
+	if logging.RetentionDays < 0 {
+		return errors.New("log retention days cannot be negative")
+	}
+
+	if logging.MaxSizeMB < 0 {
+		return errors.New("log max size cannot be negative")
+	}
+
+	if logging.MaxBackups < 0 {
+		return errors.New("log max backups cannot be negative")
+	}
+
+	if logging.AsyncQueueSize < 1 {
+		return errors.New("log async queue size must be positive")
+	}
+
+	if logging.AsyncPolicy != "block" && logging.AsyncPolicy != "drop" {
+		return fmt.Errorf("invalid log async policy: %s, must be one of: block, drop", logging.AsyncPolicy)
+	}
+
 	return nil
 }
 
@@ -151,6 +307,18 @@ func validateApplicationConfig(app *ApplicationConfig) error {
 		return errors.New("shutdown timeout must be positive")
 	}
 
+	if app.ShutdownReadinessDelay < 0 {
+		return errors.New("shutdown readiness delay cannot be negative")
+	}
+
+	if app.ServerShutdownTimeout <= 0 {
+		return errors.New("server shutdown timeout must be positive")
+	}
+
+	if app.PoolShutdownTimeout <= 0 {
+		return errors.New("pool shutdown timeout must be positive")
+	}
+
 	if app.RateLimitRequests <= 0 {
 		return errors.New("rate limit requests must be positive")
 	}
@@ -159,5 +327,92 @@ func validateApplicationConfig(app *ApplicationConfig) error {
 		return errors.New("rate limit window is required")
 	}
 
+	if app.MinAge < 1 {
+		return errors.New("minimum age must be at least 1")
+	}
+
+	if app.MaxAge < app.MinAge {
+		return errors.New("maximum age cannot be less than minimum age")
+	}
+
+	if app.DefaultPageSize < 1 {
+		return errors.New("default page size must be at least 1")
+	}
+
+	if app.DefaultPageSize > app.MaxPageSize {
+		return errors.New("default page size cannot exceed maximum page size")
+	}
+
+	if app.MaxOffset < 0 {
+		return errors.New("max offset cannot be negative")
+	}
+
+	// A cap below the page size would reject pages the handlers accept
+	if app.MaxResultRows < app.MaxPageSize {
+		return errors.New("max result rows cannot be less than max page size")
+	}
+
+	if app.MaxQueryParams < 0 {
+		return errors.New("max query params cannot be negative")
+	}
+
+	// Names are stored in VARCHAR(100) columns
+	if app.MaxNameLength < 1 || app.MaxNameLength > 100 {
+		return errors.New("max name length must be between 1 and 100")
+	}
+
+	if app.MaxPayloadBytes <= 0 {
+		return errors.New("max payload bytes must be positive")
+	}
+
+	for _, script := range app.AllowedNameScripts {
+		if !isUnicodeScript(script) {
+			return fmt.Errorf("allowed name script %q is not a Unicode script name such as Latin or Cyrillic", script)
+		}
+	}
+
+	// Admin endpoints expose operational details and are for non-production environments only
+	if app.AdminEndpointsEnabled {
+		if app.Environment == "production" {
+			return errors.New("admin endpoints cannot be enabled in production")
+		}
+		if app.AdminAPIKey == "" {
+			return errors.New("admin API key is required when admin endpoints are enabled")
+		}
+	}
+
+	if app.ResponseFieldCase != "snake" && app.ResponseFieldCase != "camel" {
+		return fmt.Errorf("invalid response field case: %s, must be one of: snake, camel", app.ResponseFieldCase)
+	}
+
+	switch app.NameNormalization {
+	case "none", "trim", "trim_title":
+	default:
+		return fmt.Errorf("invalid name normalization: %s, must be one of: none, trim, trim_title", app.NameNormalization)
+	}
+
 	return nil
 }
+
+// isUnicodeScript reports whether name is a key of unicode.Scripts, ignoring case
+func isUnicodeScript(name string) bool {
+	for script := range unicode.Scripts {
+		if strings.EqualFold(script, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHeaderName reports whether name is a non-empty HTTP header name made of letters, digits and hyphens
+func isHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
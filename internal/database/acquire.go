@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// acquireTimeout bounds how long an operation waits for a free pool connection (0 waits for the operation timeout)
+var acquireTimeout time.Duration
+
+// SetAcquireTimeout configures how long operations wait for a pool connection before failing with POOL_EXHAUSTED
+func SetAcquireTimeout(timeout time.Duration) {
+	acquireTimeout = timeout
+}
+
+// connAcquirer hands out pooled connections; *pgxpool.Pool satisfies it
+type connAcquirer interface {
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
+}
+
+// acquireConn takes a connection from the pool, waiting at most acquireTimeout
+// Only the wait is bounded; the returned connection is not tied to the acquire deadline. When that
+// deadline expires while ctx is still live the error wraps pkgerrors.ErrPoolExhausted.
+func acquireConn(ctx context.Context, pool connAcquirer) (*pgxpool.Conn, error) {
+	acquireCtx := ctx
+	if acquireTimeout > 0 {
+		var cancel context.CancelFunc
+		acquireCtx, cancel = context.WithTimeout(ctx, acquireTimeout)
+		defer cancel()
+	}
+
+	conn, err := pool.Acquire(acquireCtx)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(acquireCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: no connection within %s: %v", pkgerrors.ErrPoolExhausted, acquireTimeout, err)
+		}
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	internalerrors "github.com/chybatronik/goUserAPI/internal/errors"
+	usererrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// saturatedPool never hands out a connection, like a pool whose every connection is checked out
+type saturatedPool struct{}
+
+func (saturatedPool) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// withAcquireTimeout sets acquireTimeout for the duration of a test
+func withAcquireTimeout(t *testing.T, timeout time.Duration) {
+	t.Helper()
+	previous := acquireTimeout
+	SetAcquireTimeout(timeout)
+	t.Cleanup(func() { SetAcquireTimeout(previous) })
+}
+
+func TestAcquireConnMapsTimeoutToPoolExhausted(t *testing.T) {
+	withAcquireTimeout(t, 20*time.Millisecond)
+
+	_, err := acquireConn(context.Background(), saturatedPool{})
+	if !errors.Is(err, usererrors.ErrPoolExhausted) {
+		t.Fatalf("Expected ErrPoolExhausted, got %v", err)
+	}
+
+	for name, mapped := range map[string]error{
+		"database":        MapDatabaseErrorSecure(err),
+		"internal/errors": internalerrors.MapDatabaseErrorSecure(err),
+	} {
+		var userErr *usererrors.UserError
+		if !errors.As(mapped, &userErr) {
+			t.Fatalf("%s: expected a UserError, got %v", name, mapped)
+		}
+		if userErr.Code != "POOL_EXHAUSTED" || userErr.GetHTTPStatus() != 503 {
+			t.Errorf("%s: expected POOL_EXHAUSTED 503, got %s %d", name, userErr.Code, userErr.GetHTTPStatus())
+		}
+	}
+}
+
+func TestAcquireConnCanceledContextIsNotPoolExhausted(t *testing.T) {
+	withAcquireTimeout(t, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := acquireConn(ctx, saturatedPool{})
+	if err == nil {
+		t.Fatal("Expected an error from a saturated pool")
+	}
+	if errors.Is(err, usererrors.ErrPoolExhausted) {
+		t.Errorf("Expected the operation deadline to win over the acquire timeout, got %v", err)
+	}
+}
+
+func TestAcquireConnSaturatedLivePool(t *testing.T) {
+	pool := setupBulkTestPool(t)
+	withAcquireTimeout(t, 50*time.Millisecond)
+
+	// Check out every connection so the next acquire has to wait
+	var held []*pgxpool.Conn
+	for i := int32(0); i < pool.Config().MaxConns; i++ {
+		conn, err := pool.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to acquire connection %d: %v", i, err)
+		}
+		held = append(held, conn)
+	}
+	defer func() {
+		for _, conn := range held {
+			conn.Release()
+		}
+	}()
+
+	_, err := GetUserByID(context.Background(), pool, "00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, usererrors.ErrPoolExhausted) {
+		t.Fatalf("Expected ErrPoolExhausted from a saturated pool, got %v", err)
+	}
+}
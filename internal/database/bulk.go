@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// bulkInsertAttempts is how many times BulkInsertUsers runs its COPY when PostgreSQL aborts it with a
+// serialization failure or deadlock
+const bulkInsertAttempts = 3
+
+// bulkInsertColumns are the users columns sent by BulkInsertUsers; id and recording_date use their defaults
+var bulkInsertColumns = []string{"first_name", "last_name", "age", "email"}
+
+// BulkInsertUsers inserts users with a single COPY and returns the number of rows inserted
+// It is the high-throughput import path: every user is validated before any row is sent, and COPY is
+// atomic, so either all users are inserted or none are; a COPY aborted by a deadlock or serialization
+// failure is retried with RetryableTx. Unlike CreateUser it does not return generated IDs and skips
+// the REJECT_DUPLICATE_NAMES check. Large imports can exceed DefaultOperationTimeout, so
+// the caller's context bounds the operation.
+func BulkInsertUsers(ctx context.Context, pool *pgxpool.Pool, users []models.User) (int64, error) {
+	ctx, span := startSpan(ctx, "BulkInsertUsers")
+	count, err := bulkInsertUsers(ctx, pool, users)
+	endSpan(span, int(count), err)
+	return count, err
+}
+
+// bulkInsertUsers implements BulkInsertUsers inside its tracing span
+func bulkInsertUsers(ctx context.Context, pool *pgxpool.Pool, users []models.User) (int64, error) {
+	for i := range users {
+		if err := validateUser(&users[i]); err != nil {
+			return 0, fmt.Errorf("validation failed for user %d: %w", i, err)
+		}
+	}
+	if len(users) == 0 {
+		return 0, nil
+	}
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// In multi-tenant mode every imported user belongs to the request's tenant
+	columns := bulkInsertColumns
+	if scope.scoped() {
+		columns = append(columns[:len(columns):len(columns)], "tenant_id")
+	}
+
+	start := time.Now()
+
+	var count int64
+	err = RetryableTx(ctx, pool, bulkInsertAttempts, func(tx pgx.Tx) error {
+		// The source is consumed by CopyFrom, so each attempt needs its own
+		source := pgx.CopyFromSlice(len(users), func(i int) ([]any, error) {
+			return bulkInsertRow(scope, &users[i]), nil
+		})
+		var err error
+		count, err = tx.CopyFrom(ctx, pgx.Identifier{"users"}, columns, source)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk insert users: %w", err)
+	}
+
+	logPerformanceMetrics("BulkInsertUsers", time.Since(start))
+
+	return count, nil
+}
+
+// bulkInsertRow returns the COPY values of user matching bulkInsertColumns
+// An empty Email is sent as NULL, as CreateUser stores it, so users without one never conflict on
+// users_email_key.
+func bulkInsertRow(scope tenantScope, user *models.User) []any {
+	var email any
+	if user.Email != "" {
+		email = user.Email
+	}
+	return scope.args(user.FirstName, user.LastName, user.Age, email)
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTestLastName marks rows written by the bulk insert tests so they can be removed afterwards
+const bulkTestLastName = "BulkInsertTest"
+
+// UNIT TEST: an invalid user rejects the whole batch before the database is contacted
+func TestBulkInsertUsers_ValidatesBeforeCopy(t *testing.T) {
+	users := []models.User{
+		{FirstName: "Valid", LastName: bulkTestLastName, Age: 30},
+		{FirstName: "", LastName: bulkTestLastName, Age: 30},
+	}
+
+	// A nil pool would panic if COPY were attempted
+	count, err := BulkInsertUsers(context.Background(), nil, users)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed for user 1")
+	assert.Zero(t, count)
+}
+
+func TestBulkInsertUsers_Empty(t *testing.T) {
+	count, err := BulkInsertUsers(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestBulkInsertRowSendsEmail(t *testing.T) {
+	user := models.User{FirstName: "Ada", LastName: bulkTestLastName, Age: 36, Email: "ada@example.com"}
+
+	row := bulkInsertRow(tenantScope{}, &user)
+	require.Len(t, row, len(bulkInsertColumns))
+	assert.Equal(t, "email", bulkInsertColumns[3])
+	assert.Equal(t, "ada@example.com", row[3])
+
+	user.Email = ""
+	row = bulkInsertRow(tenantScope{}, &user)
+	assert.Nil(t, row[3], "an empty email is sent as NULL")
+}
+
+// setupBulkTestPool connects to the test database and removes rows left by bulk insert tests
+func setupBulkTestPool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	appConfig := &config.Config{
+		Database: config.DatabaseConfig{
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "postgres",
+			Database: "postgres_test",
+			SSLMode:  "disable",
+			MaxConns: 2,
+			MinConns: 0,
+		},
+	}
+
+	pool, err := NewConnectionPool(appConfig)
+	if err != nil {
+		tb.Skipf("INTEGRATION TEST: Requires live test database: %v", err)
+	}
+
+	cleanup := func() error {
+		_, err := pool.Exec(context.Background(), `DELETE FROM users WHERE last_name = $1`, bulkTestLastName)
+		return err
+	}
+	if err := cleanup(); err != nil {
+		pool.Close()
+		tb.Skipf("INTEGRATION TEST: Requires migrated users table: %v", err)
+	}
+	tb.Cleanup(func() {
+		_ = cleanup()
+		pool.Close()
+	})
+	return pool
+}
+
+// bulkTestUsers builds n valid users marked with bulkTestLastName
+func bulkTestUsers(n int) []models.User {
+	users := make([]models.User, n)
+	for i := range users {
+		users[i] = models.User{FirstName: fmt.Sprintf("User%d", i), LastName: bulkTestLastName, Age: 18 + i%60}
+	}
+	return users
+}
+
+// INTEGRATION TEST: every row is inserted with generated id and recording_date
+func TestBulkInsertUsers_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupBulkTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := BulkInsertUsers(ctx, pool, bulkTestUsers(500))
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), count)
+
+	var stored int64
+	var missingDefaults int64
+	err = pool.QueryRow(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE id IS NULL OR recording_date IS NULL) FROM users WHERE last_name = $1`,
+		bulkTestLastName).Scan(&stored, &missingDefaults)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), stored)
+	assert.Zero(t, missingDefaults)
+}
+
+// PERFORMANCE BENCHMARK: COPY compared with one INSERT per row for the same batch
+func BenchmarkBulkInsertUsers(b *testing.B) {
+	if testing.Short() {
+		b.Skip("Skipping performance benchmark in short mode")
+	}
+
+	pool := setupBulkTestPool(b)
+	ctx := context.Background()
+	users := bulkTestUsers(1000)
+
+	b.Run("copy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := BulkInsertUsers(ctx, pool, users); err != nil {
+				b.Fatalf("BulkInsertUsers failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("per_row_insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range users {
+				if _, err := insertUser(ctx, pool, &users[j]); err != nil {
+					b.Fatalf("insertUser failed: %v", err)
+				}
+			}
+		}
+	})
+}
+
+// INTEGRATION TEST: emails are stored, and a duplicate email fails the whole COPY on users_email_key
+func TestBulkInsertUsers_Email_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupBulkTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	users := bulkTestUsers(3)
+	users[0].Email = "bulk.first@example.com"
+	users[1].Email = "bulk.second@example.com"
+	count, err := BulkInsertUsers(ctx, pool, users)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	var withEmail, withoutEmail int64
+	err = pool.QueryRow(ctx,
+		`SELECT COUNT(email), COUNT(*) FILTER (WHERE email IS NULL) FROM users WHERE last_name = $1`,
+		bulkTestLastName).Scan(&withEmail, &withoutEmail)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), withEmail)
+	assert.Equal(t, int64(1), withoutEmail)
+
+	duplicate := bulkTestUsers(2)
+	duplicate[1].Email = "bulk.first@example.com"
+	count, err = BulkInsertUsers(ctx, pool, duplicate)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "users_email_key")
+	assert.Zero(t, count)
+
+	var stored int64
+	err = pool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE last_name = $1`, bulkTestLastName).Scan(&stored)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stored, "the failed COPY inserts nothing")
+}
@@ -6,28 +6,50 @@ import (
 	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/config"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// NewConnectionPool creates a new PostgreSQL connection pool
+// NewConnectionPool creates a new PostgreSQL connection pool and verifies the database is reachable
 // Source: Architecture.md#Database-Driver - pgx v5+ with connection pooling
 func NewConnectionPool(appConfig *config.Config) (*pgxpool.Pool, error) {
-	ctx := context.Background()
+	pool, err := NewLazyConnectionPool(appConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	// Build connection string from config
-	connString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		appConfig.Database.Host,
-		appConfig.Database.Port,
-		appConfig.Database.User,
-		appConfig.Database.Password,
-		appConfig.Database.Database,
-		appConfig.Database.SSLMode)
+	// Test connection
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
 
-	poolConfig, err := pgxpool.ParseConfig(connString)
+	return pool, nil
+}
+
+// NewLazyConnectionPool creates a PostgreSQL connection pool without contacting the database
+// Connections are opened on first use, so it succeeds while the database is down; only invalid
+// configuration is reported.
+func NewLazyConnectionPool(appConfig *config.Config) (*pgxpool.Pool, error) {
+	ctx := context.Background()
+
+	poolConfig, err := pgxpool.ParseConfig(connectionString(appConfig.Database))
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse database config: %w", err)
 	}
 
+	// Verification modes use our own TLS config so the CA and client certificate come from config
+	if sslModeVerifies(appConfig.Database.SSLMode) {
+		tlsConfig, err := newTLSConfig(appConfig.Database)
+		if err != nil {
+			return nil, err
+		}
+		poolConfig.ConnConfig.TLSConfig = tlsConfig
+		for _, fallback := range poolConfig.ConnConfig.Fallbacks {
+			fallback.TLSConfig = tlsConfig
+		}
+	}
+
 	// Configure connection pool using config values with performance optimization
 	poolConfig.MaxConns = int32(appConfig.Database.MaxConns)
 	poolConfig.MinConns = int32(appConfig.Database.MinConns)
@@ -41,18 +63,37 @@ func NewConnectionPool(appConfig *config.Config) (*pgxpool.Pool, error) {
 	// Connection acquisition timeout for responsive error handling
 	// Note: AcquireTimeout was removed in pgx v5.5+, using MaxConnIdleTime instead
 
+	// Enforce a server-side statement timeout so runaway queries stop even after the Go context is gone
+	if timeout := appConfig.Database.StatementTimeout; timeout > 0 {
+		poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			return setStatementTimeout(ctx, conn, timeout)
+		}
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
 
-	// Test connection
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	return pool, nil
+}
+
+// connectionString returns DATABASE_URL when configured, otherwise a DSN built from the individual DB_* settings
+func connectionString(db config.DatabaseConfig) string {
+	if db.URL != "" {
+		return db.URL
 	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		db.Host, db.Port, db.User, db.Password, db.Database, db.SSLMode)
+}
 
-	return pool, nil
+// setStatementTimeout applies statement_timeout to a single connection
+func setStatementTimeout(ctx context.Context, conn *pgx.Conn, timeout time.Duration) error {
+	// SET does not accept bind parameters; the value is an integer we format ourselves
+	if _, err := conn.Exec(ctx, fmt.Sprintf("SET statement_timeout = %d", timeout.Milliseconds())); err != nil {
+		return fmt.Errorf("unable to set statement timeout: %w", err)
+	}
+	return nil
 }
 
 // ValidateConnection checks if database connection is working
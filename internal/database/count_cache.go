@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// userCountCache serves GetUsers totals when USER_COUNT_CACHE_TTL is set; nil counts on every request
+var userCountCache *CachedUserCount
+
+// SetUserCountCacheTTL makes GetUsers take total_count from a cached COUNT(*) refreshed at most once per ttl
+// A zero ttl disables the cache. It must be called during startup before any requests are served
+func SetUserCountCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		userCountCache = nil
+		return
+	}
+	userCountCache = NewCachedUserCount(ttl)
+}
+
+// CachedUserCount is an in-memory TTL cache of the number of rows in users
+// The value is approximate: rows created or deleted since the last refresh are not reflected until it expires.
+type CachedUserCount struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	value     int64
+	fetchedAt time.Time
+}
+
+// NewCachedUserCount creates an empty cache whose value is reloaded once it is older than ttl
+func NewCachedUserCount(ttl time.Duration) *CachedUserCount {
+	return &CachedUserCount{ttl: ttl, now: time.Now}
+}
+
+// Get returns the cached count, running COUNT(*) through q when the cache is empty or expired
+// If the refresh fails after a value was cached, the stale value is served instead of failing the request.
+func (c *CachedUserCount) Get(ctx context.Context, q rowQuerier) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetchedAt.IsZero() && c.now().Sub(c.fetchedAt) < c.ttl {
+		return c.value, nil
+	}
+
+	var count int64
+	if err := q.QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&count); err != nil {
+		if !c.fetchedAt.IsZero() {
+			return c.value, nil
+		}
+		return 0, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	c.value = count
+	c.fetchedAt = c.now()
+	return count, nil
+}
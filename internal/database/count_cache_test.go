@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countRow scans a count into the first destination
+type countRow struct {
+	count int64
+	err   error
+}
+
+func (r countRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int64) = r.count
+	return nil
+}
+
+// fakeCounter answers SELECT COUNT(*) with count, or err when set, and records how often it was asked
+type fakeCounter struct {
+	count int64
+	err   error
+	calls int
+}
+
+func (f *fakeCounter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.calls++
+	return countRow{count: f.count, err: f.err}
+}
+
+// newTestCountCache returns a cache whose clock is advanced by the returned function
+func newTestCountCache(ttl time.Duration) (*CachedUserCount, func(time.Duration)) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	cache := NewCachedUserCount(ttl)
+	cache.now = func() time.Time { return now }
+	return cache, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCachedUserCountServesStoredValueWithinTTL(t *testing.T) {
+	cache, advance := newTestCountCache(30 * time.Second)
+	counter := &fakeCounter{count: 150}
+
+	count, err := cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), count)
+
+	counter.count = 151
+	advance(29 * time.Second)
+	count, err = cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), count, "a count younger than the TTL must come from the cache")
+	assert.Equal(t, 1, counter.calls)
+}
+
+func TestCachedUserCountRefreshesAfterExpiry(t *testing.T) {
+	cache, advance := newTestCountCache(30 * time.Second)
+	counter := &fakeCounter{count: 150}
+
+	_, err := cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+
+	counter.count = 175
+	advance(30 * time.Second)
+	count, err := cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(175), count)
+	assert.Equal(t, 2, counter.calls)
+}
+
+func TestCachedUserCountServesStaleValueWhenRefreshFails(t *testing.T) {
+	cache, advance := newTestCountCache(30 * time.Second)
+	counter := &fakeCounter{count: 150}
+
+	_, err := cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+
+	counter.err = errors.New("statement timeout")
+	advance(time.Minute)
+	count, err := cache.Get(context.Background(), counter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), count)
+}
+
+func TestCachedUserCountFailsWithoutValue(t *testing.T) {
+	cache, _ := newTestCountCache(30 * time.Second)
+
+	_, err := cache.Get(context.Background(), &fakeCounter{err: errors.New("connection refused")})
+	assert.Error(t, err)
+}
+
+func TestClampCachedCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		cached  int64
+		offset  int
+		pageLen int
+		hasMore bool
+		want    int64
+	}{
+		{"stale count below the page keeps has_more", 10, 20, 20, true, 41},
+		{"count above the page is kept", 500, 20, 20, true, 500},
+		{"last page is exact", 500, 20, 5, false, 25},
+		{"past the end is capped at offset", 500, 100, 0, false, 100},
+		{"past the end keeps a smaller count", 40, 100, 0, false, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clampCachedCount(tt.cached, tt.offset, tt.pageLen, tt.hasMore))
+		})
+	}
+}
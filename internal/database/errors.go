@@ -3,8 +3,10 @@ package database
 import (
 	"context"
 	"database/sql/driver"
+	stderrors "errors"
 	"log"
 
+	internalerrors "github.com/chybatronik/goUserAPI/internal/errors"
 	"github.com/chybatronik/goUserAPI/pkg/errors"
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -16,8 +18,20 @@ func MapDatabaseErrorSecure(err error) error {
 		return nil
 	}
 
+	// The duplicate name conflict is raised by CreateUser itself and carries no internal details
+	if stderrors.Is(err, errors.ErrDuplicateName) {
+		return errors.ErrDuplicateName
+	}
+
+	// Waiting for a free pool connection timed out; the pool is saturated rather than the database down
+	if stderrors.Is(err, errors.ErrPoolExhausted) {
+		return errors.ErrPoolExhausted
+	}
+
 	// PostgreSQL specific errors - NEVER expose internal details to users
-	if pgErr, ok := err.(*pgconn.PgError); ok {
+	// Database functions wrap driver errors, so unwrap before inspecting
+	var pgErr *pgconn.PgError
+	if stderrors.As(err, &pgErr) {
 		// Log detailed error internally for debugging
 		log.Printf("Database constraint violation - Code: %s, Table: %s, Column: %s, Constraint: %s, Message: %s",
 			pgErr.Code, pgErr.TableName, pgErr.ColumnName, pgErr.ConstraintName, pgErr.Message)
@@ -26,7 +40,17 @@ func MapDatabaseErrorSecure(err error) error {
 		switch pgErr.Code {
 		// Constraint violations - return as validation errors
 		case "23505", "23503", "23502", "23514": // unique, foreign key, not null, check constraints
+			// Known constraints get a specific code; unknown ones stay generic
+			if userErr, ok := internalerrors.MapConstraintError(pgErr); ok {
+				return userErr
+			}
 			return errors.NewUserValidationError(errors.ErrCodeValidationFailed, "Request failed validation")
+		case "57014": // query_canceled, raised when statement_timeout stops a runaway query
+			return &errors.UserError{
+				Code:       "QUERY_TIMEOUT",
+				Message:    "Request took too long to process",
+				HTTPStatus: 503,
+			}
 		default:
 			return errors.NewUserDatabaseError("Database operation failed")
 		}
@@ -34,8 +34,8 @@ func TestMapDatabaseErrorSecure(t *testing.T) {
 				ConstraintName: "users_email_key",
 				TableName:      "users",
 			},
-			expectedCode:   usererrors.ErrCodeValidationFailed,
-			expectedStatus: 400,
+			expectedCode:   usererrors.ErrCodeDuplicateEmail,
+			expectedStatus: 409,
 			expectLog:      true,
 		},
 		{
@@ -67,10 +67,42 @@ func TestMapDatabaseErrorSecure(t *testing.T) {
 				ConstraintName: "users_age_check",
 				TableName:      "users",
 			},
+			expectedCode:   "INVALID_AGE_RANGE",
+			expectedStatus: 400,
+			expectLog:      true,
+		},
+		{
+			name: "wrapped check constraint violation",
+			inputErr: fmt.Errorf("failed to create user: %w", &pgconn.PgError{
+				Code:           "23514",
+				ConstraintName: "users_age_check",
+				TableName:      "users",
+			}),
+			expectedCode:   "INVALID_AGE_RANGE",
+			expectedStatus: 400,
+			expectLog:      true,
+		},
+		{
+			name: "unknown check constraint violation",
+			inputErr: &pgconn.PgError{
+				Code:           "23514",
+				ConstraintName: "users_other_check",
+				TableName:      "users",
+			},
 			expectedCode:   usererrors.ErrCodeValidationFailed,
 			expectedStatus: 400,
 			expectLog:      true,
 		},
+		{
+			name: "statement timeout",
+			inputErr: fmt.Errorf("failed to query users: %w", &pgconn.PgError{
+				Code:    "57014",
+				Message: "canceling statement due to statement timeout",
+			}),
+			expectedCode:   "QUERY_TIMEOUT",
+			expectedStatus: 503,
+			expectLog:      true,
+		},
 		{
 			name:           "connection error",
 			inputErr:       driver.ErrBadConn,
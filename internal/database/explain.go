@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+)
+
+// explainLogger receives the plans of slow read queries; nil disables EXPLAIN_SLOW_QUERIES
+var explainLogger *logging.Logger
+
+// SetExplainSlowQueries makes GetUsers and GetReports log the EXPLAIN (FORMAT JSON) plan of any query
+// slower than PerformanceCriticalThreshold to logger; a nil logger turns it off
+// EXPLAIN is a second round trip per slow query, so it is meant for non-production environments only.
+// It must be called during startup before any requests are served
+func SetExplainSlowQueries(logger *logging.Logger) {
+	explainLogger = logger
+}
+
+// explainSlowQuery logs the plan of query at warn level when duration exceeds PerformanceCriticalThreshold
+// The plan is requested without ANALYZE, so the query itself is not executed a second time.
+// Failing to get a plan is logged and otherwise ignored; it never fails the operation being explained.
+func explainSlowQuery(ctx context.Context, q rowQuerier, operation, query string, duration time.Duration, args ...any) {
+	if explainLogger == nil || duration <= PerformanceCriticalThreshold {
+		return
+	}
+
+	var plan []byte
+	if err := q.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		explainLogger.Warn("Failed to explain slow query",
+			"operation", operation,
+			logging.FieldError, err,
+			"component", "database",
+		)
+		return
+	}
+
+	explainLogger.Warn("Slow query plan",
+		"operation", operation,
+		"duration_ms", duration.Milliseconds(),
+		"threshold_ms", PerformanceCriticalThreshold.Milliseconds(),
+		"plan", string(plan),
+		"component", "database",
+	)
+}
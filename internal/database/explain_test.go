@@ -0,0 +1,95 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePlanRow scans a fixed plan into the first destination
+type fakePlanRow struct {
+	plan string
+	err  error
+}
+
+func (r fakePlanRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*[]byte) = []byte(r.plan)
+	return nil
+}
+
+// fakePlanQuerier records the SQL and arguments it is asked to run
+type fakePlanQuerier struct {
+	row   fakePlanRow
+	calls int
+	sql   string
+	args  []any
+}
+
+func (q *fakePlanQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.calls++
+	q.sql = sql
+	q.args = args
+	return q.row
+}
+
+// captureExplainLogs enables EXPLAIN_SLOW_QUERIES for the test and returns its log output
+func captureExplainLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetExplainSlowQueries(logging.NewStructuredLoggerWithWriter(&buf, logging.LevelInfo, "goUserAPI", "test"))
+	t.Cleanup(func() { SetExplainSlowQueries(nil) })
+	return &buf
+}
+
+func TestExplainSlowQueryLogsPlan(t *testing.T) {
+	logs := captureExplainLogs(t)
+	querier := &fakePlanQuerier{row: fakePlanRow{plan: `[{"Plan": {"Node Type": "Seq Scan"}}]`}}
+
+	explainSlowQuery(context.Background(), querier, "GetUsers", "SELECT 1 LIMIT $1", PerformanceCriticalThreshold+time.Millisecond, 20)
+
+	require.Equal(t, 1, querier.calls)
+	assert.Equal(t, "EXPLAIN (FORMAT JSON) SELECT 1 LIMIT $1", querier.sql)
+	assert.Equal(t, []any{20}, querier.args)
+	assert.Contains(t, logs.String(), `"level":"WARN"`)
+	assert.Contains(t, logs.String(), "Slow query plan")
+	assert.Contains(t, logs.String(), `Seq Scan`)
+	assert.Contains(t, logs.String(), `"operation":"GetUsers"`)
+}
+
+func TestExplainSlowQuerySkipsFastQueries(t *testing.T) {
+	logs := captureExplainLogs(t)
+	querier := &fakePlanQuerier{}
+
+	explainSlowQuery(context.Background(), querier, "GetUsers", "SELECT 1", PerformanceCriticalThreshold, 20)
+
+	assert.Zero(t, querier.calls)
+	assert.Empty(t, logs.String())
+}
+
+func TestExplainSlowQueryDisabledByDefault(t *testing.T) {
+	querier := &fakePlanQuerier{}
+
+	explainSlowQuery(context.Background(), querier, "GetReports", "SELECT 1", time.Second)
+
+	assert.Zero(t, querier.calls)
+}
+
+func TestExplainSlowQueryLogsPlanFailure(t *testing.T) {
+	logs := captureExplainLogs(t)
+	querier := &fakePlanQuerier{row: fakePlanRow{err: errors.New("conn busy")}}
+
+	explainSlowQuery(context.Background(), querier, "GetReports", "SELECT 1", time.Second)
+
+	assert.Contains(t, logs.String(), "Failed to explain slow query")
+	assert.NotContains(t, logs.String(), "Slow query plan")
+}
@@ -6,18 +6,58 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/chybatronik/goUserAPI/internal/handlers"
+	"github.com/chybatronik/goUserAPI/internal/types"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// pinger is the subset of pgxpool.Pool needed to test connectivity
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PoolStats is a snapshot of connection pool saturation reported in health check details
+type PoolStats struct {
+	AcquiredConns int32
+	IdleConns     int32
+	TotalConns    int32
+	MaxConns      int32
+}
+
+// details renders the stats as health check detail values
+func (s PoolStats) details() map[string]any {
+	return map[string]any{
+		"acquired_conns": s.AcquiredConns,
+		"idle_conns":     s.IdleConns,
+		"total_conns":    s.TotalConns,
+		"max_conns":      s.MaxConns,
+	}
+}
+
 // HealthChecker implements database health checking with timing
 type HealthChecker struct {
-	db *pgxpool.Pool
+	db    pinger
+	stats func() PoolStats
 }
 
 // NewHealthChecker creates a new database health checker
 func NewHealthChecker(db *pgxpool.Pool) *HealthChecker {
-	return &HealthChecker{db: db}
+	return &HealthChecker{
+		db:    db,
+		stats: poolStatsSource(db),
+	}
+}
+
+// poolStatsSource returns a function reading the current saturation of pool
+func poolStatsSource(pool *pgxpool.Pool) func() PoolStats {
+	return func() PoolStats {
+		stat := pool.Stat()
+		return PoolStats{
+			AcquiredConns: stat.AcquiredConns(),
+			IdleConns:     stat.IdleConns(),
+			TotalConns:    stat.TotalConns(),
+			MaxConns:      stat.MaxConns(),
+		}
+	}
 }
 
 // Name implements the handlers.HealthChecker interface
@@ -26,7 +66,7 @@ func (h *HealthChecker) Name() string {
 }
 
 // CheckHealth checks database connectivity with timing
-func (h *HealthChecker) CheckHealth(ctx context.Context) handlers.HealthCheck {
+func (h *HealthChecker) CheckHealth(ctx context.Context) types.ComponentHealth {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -34,9 +74,10 @@ func (h *HealthChecker) CheckHealth(ctx context.Context) handlers.HealthCheck {
 	err := h.db.Ping(ctx)
 	responseTime := time.Since(start).Milliseconds()
 
-	healthCheck := handlers.HealthCheck{
+	healthCheck := types.ComponentHealth{
 		Status:         "healthy",
 		ResponseTimeMs: responseTime,
+		Details:        h.stats().details(),
 	}
 
 	if err != nil {
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPinger reports a fixed connectivity result
+type mockPinger struct {
+	err error
+}
+
+func (m *mockPinger) Ping(ctx context.Context) error {
+	return m.err
+}
+
+func TestHealthCheckerIncludesPoolStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus string
+	}{
+		{"healthy", nil, "healthy"},
+		{"unhealthy still reports saturation", errors.New("connection refused"), "unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &HealthChecker{
+				db: &mockPinger{err: tt.pingErr},
+				stats: func() PoolStats {
+					return PoolStats{AcquiredConns: 3, IdleConns: 2, TotalConns: 5, MaxConns: 25}
+				},
+			}
+
+			health := checker.CheckHealth(context.Background())
+			assert.Equal(t, tt.wantStatus, health.Status)
+
+			body, err := json.Marshal(health)
+			require.NoError(t, err)
+
+			var decoded struct {
+				Details map[string]int `json:"details"`
+			}
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			assert.Equal(t, map[string]int{
+				"acquired_conns": 3,
+				"idle_conns":     2,
+				"total_conns":    5,
+				"max_conns":      25,
+			}, decoded.Details)
+		})
+	}
+}
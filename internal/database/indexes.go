@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExpectedIndexes are the users table indexes created by migrations/003_create_indexes.sql
+var ExpectedIndexes = []string{"idx_users_recording_date_desc", "idx_users_age_created"}
+
+// VerifyIndexes returns the expected index names that do not exist on the users table, in expected order
+// Only the current schema is inspected, matching where migrations create the table.
+func VerifyIndexes(ctx context.Context, pool *pgxpool.Pool, expected []string) ([]string, error) {
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT indexname FROM pg_indexes WHERE schemaname = current_schema() AND tablename = 'users'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users table indexes: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan index name: %w", err)
+		}
+		existing[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list users table indexes: %w", err)
+	}
+
+	var missing []string
+	for _, name := range expected {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
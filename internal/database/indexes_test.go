@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// INTEGRATION TEST: only the indexes missing from the users table are reported
+func TestVerifyIndexesReportsMissing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// A scratch schema holds a users table with only one of the expected indexes
+	const schema = "verify_indexes_test"
+	poolConfig, err := pgxpool.ParseConfig("host=localhost port=5432 user=postgres password=postgres dbname=postgres_test sslmode=disable")
+	if err != nil {
+		t.Fatalf("Failed to parse pool config: %v", err)
+	}
+	poolConfig.MaxConns = 2
+	poolConfig.ConnConfig.RuntimeParams["search_path"] = schema
+
+	ctx := context.Background()
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		t.Skipf("INTEGRATION TEST: Requires live test database: %v", err)
+	}
+	defer pool.Close()
+	if err := pool.Ping(ctx); err != nil {
+		t.Skipf("INTEGRATION TEST: Requires live test database: %v", err)
+	}
+
+	setup := []string{
+		`DROP SCHEMA IF EXISTS ` + schema + ` CASCADE`,
+		`CREATE SCHEMA ` + schema,
+		`CREATE TABLE ` + schema + `.users (id UUID PRIMARY KEY, age INTEGER, recording_date BIGINT)`,
+		`CREATE INDEX idx_users_age_created ON ` + schema + `.users(age, recording_date)`,
+	}
+	for _, statement := range setup {
+		if _, err := pool.Exec(ctx, statement); err != nil {
+			t.Fatalf("Setup %q failed: %v", statement, err)
+		}
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DROP SCHEMA IF EXISTS `+schema+` CASCADE`)
+	})
+
+	missing, err := VerifyIndexes(ctx, pool, ExpectedIndexes)
+	if err != nil {
+		t.Fatalf("VerifyIndexes failed: %v", err)
+	}
+	if !slices.Equal(missing, []string{"idx_users_recording_date_desc"}) {
+		t.Errorf("Expected only idx_users_recording_date_desc missing, got %v", missing)
+	}
+
+	if _, err := pool.Exec(ctx, `CREATE INDEX idx_users_recording_date_desc ON `+schema+`.users(recording_date DESC)`); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	missing, err = VerifyIndexes(ctx, pool, ExpectedIndexes)
+	if err != nil {
+		t.Fatalf("VerifyIndexes failed: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("Expected no missing indexes, got %v", missing)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build leakdemo
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// leakRecorder captures the failures and cleanups of AssertNoLeakedConns instead of failing the real test
+type leakRecorder struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (r *leakRecorder) Cleanup(fn func()) { r.cleanups = append(r.cleanups, fn) }
+
+func (r *leakRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Run with: go test -tags leakdemo -run TestAssertNoLeakedConnsDetectsLeak ./internal/database
+func TestAssertNoLeakedConnsDetectsLeak(t *testing.T) {
+	pool := setupBulkTestPool(t)
+	recorder := &leakRecorder{TB: t}
+	AssertNoLeakedConns(recorder, pool)
+
+	// Deliberately leave the rows open, as a refactor that drops rows.Close() would
+	rows, err := pool.Query(context.Background(), `SELECT id FROM users LIMIT 1`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	for _, cleanup := range recorder.cleanups {
+		cleanup()
+	}
+	if len(recorder.errors) == 0 {
+		t.Fatal("Expected AssertNoLeakedConns to report the unclosed rows")
+	}
+	t.Logf("Detector fired: %s", recorder.errors[0])
+}
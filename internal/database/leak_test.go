@@ -0,0 +1,20 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AssertNoLeakedConns fails tb when pool holds more acquired connections at the end of the test than when called
+// Call it right after creating the pool; a query whose rows are never closed keeps its connection acquired.
+func AssertNoLeakedConns(tb testing.TB, pool *pgxpool.Pool) {
+	tb.Helper()
+
+	before := pool.Stat().AcquiredConns()
+	tb.Cleanup(func() {
+		if after := pool.Stat().AcquiredConns(); after > before {
+			tb.Errorf("connection leak: %d connections acquired at start, %d at end (missing rows.Close or conn.Release?)", before, after)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultMetricsWindow is how many recent samples per operation feed the latency percentiles
+const DefaultMetricsWindow = 1024
+
+// LatencySummary describes the recent latency of one database operation in milliseconds
+// Count is the total number of recorded operations; the percentiles and max cover the last window samples
+type LatencySummary struct {
+	Count int64   `json:"count"`
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+// latencyRing keeps the most recent durations of one operation
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+	count   int64
+}
+
+// MetricsCollector keeps per-operation latency samples in fixed-size ring buffers
+// It is safe for concurrent use
+type MetricsCollector struct {
+	mu     sync.Mutex
+	window int
+	ops    map[string]*latencyRing
+}
+
+// Metrics collects the latency of every database operation logged by logPerformanceMetrics
+var Metrics = NewMetricsCollector(DefaultMetricsWindow)
+
+// NewMetricsCollector creates a collector keeping the last window samples per operation
+func NewMetricsCollector(window int) *MetricsCollector {
+	if window <= 0 {
+		window = DefaultMetricsWindow
+	}
+	return &MetricsCollector{
+		window: window,
+		ops:    make(map[string]*latencyRing),
+	}
+}
+
+// Window returns how many recent samples per operation are kept
+func (c *MetricsCollector) Window() int {
+	return c.window
+}
+
+// Record adds one operation duration, overwriting the oldest sample once the window is full
+func (c *MetricsCollector) Record(operation string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ring, ok := c.ops[operation]
+	if !ok {
+		ring = &latencyRing{samples: make([]time.Duration, 0, c.window)}
+		c.ops[operation] = ring
+	}
+
+	if len(ring.samples) < c.window {
+		ring.samples = append(ring.samples, duration)
+	} else {
+		ring.samples[ring.next] = duration
+	}
+	ring.next = (ring.next + 1) % c.window
+	ring.count++
+}
+
+// Snapshot returns the latency summary of every recorded operation keyed by operation name
+func (c *MetricsCollector) Snapshot() map[string]LatencySummary {
+	c.mu.Lock()
+	samples := make(map[string][]time.Duration, len(c.ops))
+	counts := make(map[string]int64, len(c.ops))
+	for operation, ring := range c.ops {
+		samples[operation] = slices.Clone(ring.samples)
+		counts[operation] = ring.count
+	}
+	c.mu.Unlock()
+
+	// Sort outside the lock so recording is never blocked by a snapshot
+	summaries := make(map[string]LatencySummary, len(samples))
+	for operation, durations := range samples {
+		slices.Sort(durations)
+		summaries[operation] = LatencySummary{
+			Count: counts[operation],
+			P50Ms: milliseconds(percentile(durations, 50)),
+			P95Ms: milliseconds(percentile(durations, 95)),
+			P99Ms: milliseconds(percentile(durations, 99)),
+			MaxMs: milliseconds(durations[len(durations)-1]),
+		}
+	}
+	return summaries
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted, which must not be empty
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// milliseconds converts d to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
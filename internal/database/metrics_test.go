@@ -0,0 +1,66 @@
+package database
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollectorPercentiles(t *testing.T) {
+	collector := NewMetricsCollector(100)
+	// Record 1ms..100ms out of order so the snapshot has to sort
+	for i := 100; i >= 1; i-- {
+		collector.Record("GetUsers", time.Duration(i)*time.Millisecond)
+	}
+
+	summary, ok := collector.Snapshot()["GetUsers"]
+	require.True(t, ok)
+	assert.Equal(t, int64(100), summary.Count)
+	assert.Equal(t, 50.0, summary.P50Ms)
+	assert.Equal(t, 95.0, summary.P95Ms)
+	assert.Equal(t, 99.0, summary.P99Ms)
+	assert.Equal(t, 100.0, summary.MaxMs)
+}
+
+func TestMetricsCollectorSmallSample(t *testing.T) {
+	collector := NewMetricsCollector(10)
+	collector.Record("CreateUser", 1500*time.Microsecond)
+
+	summary := collector.Snapshot()["CreateUser"]
+	assert.Equal(t, LatencySummary{Count: 1, P50Ms: 1.5, P95Ms: 1.5, P99Ms: 1.5, MaxMs: 1.5}, summary)
+}
+
+func TestMetricsCollectorWindowDropsOldestSamples(t *testing.T) {
+	collector := NewMetricsCollector(4)
+	collector.Record("GetReports", time.Second)
+	for i := 1; i <= 4; i++ {
+		collector.Record("GetReports", time.Duration(i)*time.Millisecond)
+	}
+
+	summary := collector.Snapshot()["GetReports"]
+	assert.Equal(t, int64(5), summary.Count, "count covers every recorded operation")
+	assert.Equal(t, 4.0, summary.MaxMs, "the 1s sample fell out of the window")
+	assert.Equal(t, 2.0, summary.P50Ms)
+}
+
+func TestMetricsCollectorConcurrentRecord(t *testing.T) {
+	collector := NewMetricsCollector(DefaultMetricsWindow)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				collector.Record("GetUserByID", time.Millisecond)
+				collector.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(800), collector.Snapshot()["GetUserByID"].Count)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/types"
+)
+
+// migrationSource is the subset of MigrationRunner needed to compare migration files with executed versions
+type migrationSource interface {
+	LoadMigrationFiles() ([]Migration, error)
+	GetExecutedMigrations(ctx context.Context) (map[string]bool, error)
+}
+
+// MigrationHealthChecker reports unhealthy while migration files exist that have not been executed
+// A reachable database with a stale schema fails every request, so this belongs in readiness checks.
+type MigrationHealthChecker struct {
+	runner migrationSource
+}
+
+// NewMigrationHealthChecker creates a migration status checker for the runner's migrations directory
+func NewMigrationHealthChecker(runner *MigrationRunner) *MigrationHealthChecker {
+	return &MigrationHealthChecker{runner: runner}
+}
+
+// Name implements the handlers.HealthChecker interface
+func (h *MigrationHealthChecker) Name() string {
+	return "migrations"
+}
+
+// CheckHealth counts migration files whose version is missing from schema_migrations
+func (h *MigrationHealthChecker) CheckHealth(ctx context.Context) types.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	healthCheck := types.ComponentHealth{Status: "healthy"}
+
+	migrations, err := h.runner.LoadMigrationFiles()
+	if err != nil {
+		healthCheck.Status = "unhealthy"
+		healthCheck.Error = fmt.Sprintf("failed to load migration files: %v", err)
+		healthCheck.ResponseTimeMs = time.Since(start).Milliseconds()
+		return healthCheck
+	}
+
+	executed, err := h.runner.GetExecutedMigrations(ctx)
+	if err != nil {
+		healthCheck.Status = "unhealthy"
+		healthCheck.Error = fmt.Sprintf("failed to read executed migrations: %v", err)
+		healthCheck.ResponseTimeMs = time.Since(start).Milliseconds()
+		return healthCheck
+	}
+
+	pending := 0
+	for _, migration := range migrations {
+		if !executed[migration.Version] {
+			pending++
+		}
+	}
+
+	healthCheck.ResponseTimeMs = time.Since(start).Milliseconds()
+	healthCheck.Details = map[string]any{
+		"pending_migrations":  pending,
+		"executed_migrations": len(executed),
+	}
+	if pending > 0 {
+		healthCheck.Status = "unhealthy"
+		healthCheck.Error = fmt.Sprintf("%d pending migrations", pending)
+	}
+	return healthCheck
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMigrationSource serves fixed migration files and executed versions
+type fakeMigrationSource struct {
+	files    []Migration
+	executed map[string]bool
+	err      error
+}
+
+func (f *fakeMigrationSource) LoadMigrationFiles() ([]Migration, error) {
+	return f.files, nil
+}
+
+func (f *fakeMigrationSource) GetExecutedMigrations(ctx context.Context) (map[string]bool, error) {
+	return f.executed, f.err
+}
+
+func TestMigrationHealthChecker(t *testing.T) {
+	files := []Migration{{Version: "001_create_users"}, {Version: "002_add_indexes"}, {Version: "003_add_email"}}
+
+	tests := []struct {
+		name        string
+		executed    map[string]bool
+		err         error
+		wantStatus  string
+		wantPending any
+	}{
+		{"none pending", map[string]bool{"001_create_users": true, "002_add_indexes": true, "003_add_email": true}, nil, "healthy", 0},
+		{"pending migrations", map[string]bool{"001_create_users": true}, nil, "unhealthy", 2},
+		{"schema_migrations unreadable", nil, errors.New(`relation "schema_migrations" does not exist`), "unhealthy", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &MigrationHealthChecker{runner: &fakeMigrationSource{files: files, executed: tt.executed, err: tt.err}}
+
+			health := checker.CheckHealth(context.Background())
+
+			assert.Equal(t, "migrations", checker.Name())
+			assert.Equal(t, tt.wantStatus, health.Status)
+			if tt.wantPending == nil {
+				assert.Contains(t, health.Error, "failed to read executed migrations")
+				return
+			}
+			assert.Equal(t, tt.wantPending, health.Details["pending_migrations"])
+			if tt.wantStatus == "unhealthy" {
+				assert.Equal(t, "2 pending migrations", health.Error)
+			} else {
+				assert.Empty(t, health.Error)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -230,25 +231,20 @@ func (m *MigrationRunner) isMigrationExecuted(version string, executed map[strin
 
 // executeMigration executes a single migration
 func (m *MigrationRunner) executeMigration(ctx context.Context, migration Migration) error {
-	tx, err := m.db.Begin(ctx)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	// Execute migration SQL
-	if _, err := tx.Exec(ctx, migration.SQLContent); err != nil {
-		return fmt.Errorf("failed to execute migration SQL: %w", err)
-	}
-
-	// Record migration as executed with checksum
-	if _, err := tx.Exec(ctx,
-		"INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)",
-		migration.Version, migration.Checksum); err != nil {
-		return fmt.Errorf("failed to record migration: %w", err)
-	}
+	return WithTx(ctx, m.db, func(tx pgx.Tx) error {
+		// Execute migration SQL
+		if _, err := tx.Exec(ctx, migration.SQLContent); err != nil {
+			return fmt.Errorf("failed to execute migration SQL: %w", err)
+		}
 
-	return tx.Commit(ctx)
+		// Record migration as executed with checksum
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)",
+			migration.Version, migration.Checksum); err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
 }
 
 // verifyMigrationIntegrity verifies that executed migrations match their checksums
@@ -345,6 +341,8 @@ func (m *MigrationRunner) RollbackLastMigration(ctx context.Context) error {
 		downFilename = "002_down_create_users_table.sql"
 	case "003_create_indexes":
 		downFilename = "003_down_create_indexes.sql"
+	case "004_add_tenant_id":
+		downFilename = "004_down_add_tenant_id.sql"
 	default:
 		// For other migrations, try to find the corresponding down file
 		files, err := os.ReadDir(m.dir)
@@ -369,27 +367,23 @@ func (m *MigrationRunner) RollbackLastMigration(ctx context.Context) error {
 	}
 
 	// Execute rollback in transaction
-	tx, err := m.db.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
-
-	// Execute rollback SQL
-	if _, err := tx.Exec(ctx, string(content)); err != nil {
-		log.Printf("[MIGRATION] ERROR: Failed to execute rollback SQL: %v", err)
-		return fmt.Errorf("failed to execute rollback SQL: %w", err)
-	}
-
-	// Remove migration from tracking
-	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", lastMigration); err != nil {
-		log.Printf("[MIGRATION] ERROR: Failed to remove migration from tracking: %v", err)
-		return fmt.Errorf("failed to remove migration from tracking: %w", err)
-	}
+	err = WithTx(ctx, m.db, func(tx pgx.Tx) error {
+		// Execute rollback SQL
+		if _, err := tx.Exec(ctx, string(content)); err != nil {
+			log.Printf("[MIGRATION] ERROR: Failed to execute rollback SQL: %v", err)
+			return fmt.Errorf("failed to execute rollback SQL: %w", err)
+		}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Printf("[MIGRATION] ERROR: Failed to commit rollback transaction: %v", err)
-		return fmt.Errorf("failed to commit rollback transaction: %w", err)
+		// Remove migration from tracking
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", lastMigration); err != nil {
+			log.Printf("[MIGRATION] ERROR: Failed to remove migration from tracking: %v", err)
+			return fmt.Errorf("failed to remove migration from tracking: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[MIGRATION] ERROR: Rollback transaction failed: %v", err)
+		return err
 	}
 
 	log.Printf("[MIGRATION] SUCCESS: Migration %s rolled back successfully", lastMigration)
@@ -435,6 +429,8 @@ func (m *MigrationRunner) RunDownMigrations(ctx context.Context, targetVersion s
 					downFilename = "002_down_create_users_table.sql"
 				case "003_create_indexes":
 					downFilename = "003_down_create_indexes.sql"
+				case "004_add_tenant_id":
+					downFilename = "004_down_add_tenant_id.sql"
 				default:
 					// Try to find the corresponding down file
 					files, err := os.ReadDir(m.dir)
@@ -458,26 +454,22 @@ func (m *MigrationRunner) RunDownMigrations(ctx context.Context, targetVersion s
 					return fmt.Errorf("failed to read rollback file %s: %w", downFilename, err)
 				}
 
-				// Execute rollback
-				tx, err := m.db.Begin(ctx)
-				if err != nil {
-					return fmt.Errorf("failed to begin transaction: %w", err)
-				}
-				defer tx.Rollback(ctx)
-
-				if _, err := tx.Exec(ctx, string(content)); err != nil {
-					log.Printf("[MIGRATION] ERROR: Failed to execute rollback SQL for %s: %v", migration.Version, err)
-					return fmt.Errorf("failed to execute rollback SQL: %w", err)
-				}
-
-				if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
-					log.Printf("[MIGRATION] ERROR: Failed to remove migration %s from tracking: %v", migration.Version, err)
-					return fmt.Errorf("failed to remove migration from tracking: %w", err)
-				}
+				// Execute rollback; each migration is rolled back in its own transaction
+				err = WithTx(ctx, m.db, func(tx pgx.Tx) error {
+					if _, err := tx.Exec(ctx, string(content)); err != nil {
+						log.Printf("[MIGRATION] ERROR: Failed to execute rollback SQL for %s: %v", migration.Version, err)
+						return fmt.Errorf("failed to execute rollback SQL: %w", err)
+					}
 
-				if err := tx.Commit(ctx); err != nil {
-					log.Printf("[MIGRATION] ERROR: Failed to commit rollback transaction for %s: %v", migration.Version, err)
-					return fmt.Errorf("failed to commit rollback transaction: %w", err)
+					if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+						log.Printf("[MIGRATION] ERROR: Failed to remove migration %s from tracking: %v", migration.Version, err)
+						return fmt.Errorf("failed to remove migration from tracking: %w", err)
+					}
+					return nil
+				})
+				if err != nil {
+					log.Printf("[MIGRATION] ERROR: Rollback transaction for %s failed: %v", migration.Version, err)
+					return err
 				}
 
 				rollbackCount++
@@ -16,13 +16,15 @@ func TestLoadMigrationFiles(t *testing.T) {
 
 	migrations, err := migrationRunner.loadMigrationFiles()
 	assert.NoError(t, err, "Should load migration files without error")
-	assert.Len(t, migrations, 3, "Should load exactly 3 migration files (up migrations only)")
+	assert.Len(t, migrations, 5, "Should load exactly 5 migration files (up migrations only)")
 
 	// All loaded migrations are up migrations (down migrations are filtered out in loadMigrationFiles)
-	assert.Len(t, migrations, 3, "Should have 3 up migrations")
+	assert.Len(t, migrations, 5, "Should have 5 up migrations")
 	assert.Equal(t, "001_create_schema_migrations_table", migrations[0].Version, "First migration should create schema_migrations table")
 	assert.Equal(t, "002_create_users_table", migrations[1].Version, "Second migration should be 002_create_users_table")
 	assert.Equal(t, "003_create_indexes", migrations[2].Version, "Third migration should be 003_create_indexes")
+	assert.Equal(t, "004_add_tenant_id", migrations[3].Version, "Fourth migration should be 004_add_tenant_id")
+	assert.Equal(t, "005_add_email", migrations[4].Version, "Fifth migration should be 005_add_email")
 
 	// Verify schema_migrations table creation
 	assert.Contains(t, migrations[0].SQLContent, "CREATE TABLE", "First migration should create schema_migrations table")
@@ -37,6 +39,10 @@ func TestLoadMigrationFiles(t *testing.T) {
 	assert.Contains(t, migrations[2].SQLContent, "CREATE INDEX idx_users_age_created", "Third migration should create age index")
 	assert.Contains(t, migrations[2].SQLContent, "CREATE INDEX idx_users_recording_date_desc", "Third migration should create recording_date index")
 
+	// Verify the email natural key is unique per tenant under the name mapped to USER_DUPLICATE_EMAIL
+	assert.Contains(t, migrations[4].SQLContent, "ADD COLUMN email", "Fifth migration should add the email column")
+	assert.Contains(t, migrations[4].SQLContent, "CREATE UNIQUE INDEX users_email_key", "Fifth migration should create the unique email index")
+
 	// Note: Down migrations are not loaded by loadMigrationFiles() - they are only used for rollbacks
 	// This is intentional design to keep migration execution simple and safe
 }
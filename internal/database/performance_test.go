@@ -27,7 +27,7 @@ func TestMigrationPerformance(t *testing.T) {
 
 	require.NoError(t, err, "Should load migrations without error")
 	assert.Less(t, duration, 100*time.Millisecond, "Migration loading should complete quickly")
-	assert.Len(t, migrations, 3, "Should load exactly 3 migration files (up migrations only)")
+	assert.Len(t, migrations, 5, "Should load exactly 5 migration files (up migrations only)")
 }
 
 func TestQueryPerformanceTargets(t *testing.T) {
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LogPoolStats logs the saturation of pool at debug level once per interval until ctx is done
+func LogPoolStats(ctx context.Context, pool *pgxpool.Pool, interval time.Duration, logger *logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logPoolStats(ctx, poolStatsSource(pool), ticker.C, logger)
+}
+
+// logPoolStats writes one "Connection pool stats" line per tick until ctx is done
+func logPoolStats(ctx context.Context, stats func() PoolStats, ticks <-chan time.Time, logger *logging.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+			s := stats()
+			logger.Debug("Connection pool stats",
+				"acquired_conns", s.AcquiredConns,
+				"idle_conns", s.IdleConns,
+				"total_conns", s.TotalConns,
+				"max_conns", s.MaxConns,
+			)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogPoolStatsLogsOncePerTick(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, logging.LevelDebug, "goUserAPI", "test")
+
+	calls := 0
+	stats := func() PoolStats {
+		calls++
+		return PoolStats{AcquiredConns: int32(calls), IdleConns: 2, TotalConns: int32(calls) + 2, MaxConns: 10}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		logPoolStats(ctx, stats, ticks, logger)
+		close(done)
+	}()
+
+	for range 3 {
+		ticks <- time.Now()
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("logPoolStats did not stop after its context was cancelled")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3, "one log line per tick")
+	for i, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "DEBUG", entry["level"])
+		assert.Equal(t, "Connection pool stats", entry["msg"])
+		assert.EqualValues(t, i+1, entry["acquired_conns"])
+		assert.EqualValues(t, 2, entry["idle_conns"])
+		assert.EqualValues(t, i+3, entry["total_conns"])
+		assert.EqualValues(t, 10, entry["max_conns"])
+	}
+}
+
+func TestLogPoolStatsSilentAboveDebug(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, logging.LevelInfo, "goUserAPI", "test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		logPoolStats(ctx, func() PoolStats { return PoolStats{MaxConns: 10} }, ticks, logger)
+		close(done)
+	}()
+
+	ticks <- time.Now()
+	cancel()
+	<-done
+
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// WithQueryRetry runs the read-only query fn and, when it fails with a connection-level error, runs it
+// once more. The read functions acquire their connection from the pool on every call, so the second
+// attempt runs on a freshly acquired connection while the broken one is discarded on release.
+// Only wrap functions without side effects such as GetUserByID, GetUsers or GetReports: writes are
+// never retried, since a write whose connection broke may already have been applied. Nothing is
+// retried once ctx is done.
+func WithQueryRetry(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if !isRetryableQueryError(ctx, err) {
+		return err
+	}
+
+	log.Printf("Retrying read after connection error: %v", err)
+	return fn(ctx)
+}
+
+// isRetryableQueryError reports whether err, or any error it wraps, is a connection error worth one retry
+// Context errors count as connection errors to isConnectionError, so a done ctx is checked first.
+func isRetryableQueryError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if isConnectionError(err) {
+			return true
+		}
+	}
+	return false
+}
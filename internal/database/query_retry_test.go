@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyReader fails its first call with err and then returns user
+type flakyReader struct {
+	err   error
+	user  models.User
+	calls int
+}
+
+func (r *flakyReader) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	r.calls++
+	if r.calls == 1 {
+		return nil, fmt.Errorf("failed to get user by ID %s: %w", id, r.err)
+	}
+	user := r.user
+	return &user, nil
+}
+
+func TestWithQueryRetryRetriesConnectionErrorOnce(t *testing.T) {
+	reader := &flakyReader{err: &pgconn.PgError{Code: "08006"}, user: models.User{ID: "42", FirstName: "Ada"}}
+
+	var user *models.User
+	err := WithQueryRetry(context.Background(), func(ctx context.Context) error {
+		var err error
+		user, err = reader.GetUserByID(ctx, "42")
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, reader.calls)
+	assert.Equal(t, "Ada", user.FirstName)
+}
+
+func TestWithQueryRetryGivesUpAfterSecondFailure(t *testing.T) {
+	calls := 0
+	connErr := &pgconn.PgError{Code: "08003"}
+	err := WithQueryRetry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("failed to query users: %w", connErr)
+	})
+
+	assert.ErrorIs(t, err, connErr)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWithQueryRetryDoesNotRetryOtherErrors(t *testing.T) {
+	for _, queryErr := range []error{
+		pgx.ErrNoRows,
+		&pgconn.PgError{Code: "23505"},
+		errors.New("parameter validation failed"),
+	} {
+		calls := 0
+		err := WithQueryRetry(context.Background(), func(ctx context.Context) error {
+			calls++
+			return queryErr
+		})
+
+		assert.ErrorIs(t, err, queryErr)
+		assert.Equal(t, 1, calls, "%v must not be retried", queryErr)
+	}
+}
+
+func TestWithQueryRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := WithQueryRetry(ctx, func(ctx context.Context) error {
+		calls++
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
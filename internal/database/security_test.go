@@ -1,11 +1,13 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
 )
 
 func TestGetUsersSQLInjectionProtection(t *testing.T) {
@@ -330,3 +332,46 @@ func TestGetUsersValidationIntegration(t *testing.T) {
 		t.Errorf("Expected no validation error for valid params, got: %v", err)
 	}
 }
+
+func TestValidateParamsMaxOffset(t *testing.T) {
+	params := types.GetUsersParams{SortBy: "recording_date", SortOrder: "desc", Limit: 10, Offset: validation.DefaultPageSize.MaxOffset}
+	if err := validateGetUsersParams(params); err != nil {
+		t.Errorf("Expected offset equal to the maximum to be accepted, got: %v", err)
+	}
+
+	params.Offset++
+	if err := validateGetUsersParams(params); err == nil {
+		t.Error("Expected validation error for an offset above the maximum")
+	}
+	if err := validateGetReportsParams(10, params.Offset, 0, 1, 1, 120); err == nil {
+		t.Error("Expected report validation error for an offset above the maximum")
+	}
+}
+
+func TestValidateParamsMaxResultRows(t *testing.T) {
+	// A page size misconfigured above the cap must not let a read through
+	SetPageSize(validation.PageSize{Default: 20, Max: 5000})
+	SetMaxResultRows(1000)
+	defer func() {
+		SetPageSize(validation.DefaultPageSize)
+		SetMaxResultRows(DefaultMaxResultRows)
+	}()
+
+	params := types.GetUsersParams{SortBy: "recording_date", SortOrder: "desc", Limit: 1000}
+	if err := validateGetUsersParams(params); err != nil {
+		t.Errorf("Expected a limit equal to the cap to be accepted, got: %v", err)
+	}
+
+	params.Limit = 1001
+	if err := validateGetUsersParams(params); err == nil || !strings.Contains(err.Error(), "maximum result size") {
+		t.Errorf("Expected result size error for a limit above the cap, got: %v", err)
+	}
+	if err := validateGetReportsParams(1001, 0, 0, 1, 1, 120); err == nil || !strings.Contains(err.Error(), "maximum result size") {
+		t.Errorf("Expected report result size error for a limit above the cap, got: %v", err)
+	}
+
+	// The cap is checked before any query is issued, so no pool is needed
+	if _, err := GetAllUsers(context.Background(), nil, 1001, 0); err == nil {
+		t.Error("Expected GetAllUsers to reject a limit above the cap")
+	}
+}
@@ -0,0 +1,50 @@
+package database
+
+import "sort"
+
+// DefaultSortField is the sort field used when a request does not specify one
+const DefaultSortField = "recording_date"
+
+// AllowedSortFields maps each sortable API field name to the physical column used in ORDER BY
+// It is the single whitelist consulted by request validation and by clause building,
+// so making a new column sortable only requires adding it here. Values are inlined
+// into SQL and must therefore be trusted column names, never user input.
+var AllowedSortFields = map[string]string{
+	"recording_date": "recording_date",
+	"age":            "age",
+	"first_name":     "first_name",
+	"last_name":      "last_name",
+}
+
+// DefaultSortOrders maps sort fields to the direction used when a request omits sort_order
+// Dates read newest first while names read alphabetically; fields not listed fall back to desc.
+var DefaultSortOrders = map[string]string{
+	"recording_date": "desc",
+	"first_name":     "asc",
+	"last_name":      "asc",
+}
+
+// DefaultSortOrder returns the sort direction for field when sort_order is omitted
+func DefaultSortOrder(field string) string {
+	if order, ok := DefaultSortOrders[field]; ok {
+		return order
+	}
+	return "desc"
+}
+
+// SortFieldNames returns the allowed sort fields for error messages and documentation
+// The default field comes first, followed by the rest in alphabetical order
+func SortFieldNames() []string {
+	names := make([]string, 0, len(AllowedSortFields))
+	for name := range AllowedSortFields {
+		if name != DefaultSortField {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if _, ok := AllowedSortFields[DefaultSortField]; ok {
+		names = append([]string{DefaultSortField}, names...)
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/types"
+)
+
+func TestSortFieldNames(t *testing.T) {
+	expected := []string{"recording_date", "age", "first_name", "last_name"}
+	if got := SortFieldNames(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortFieldNames() = %v, want %v", got, expected)
+	}
+}
+
+func TestDefaultSortOrder(t *testing.T) {
+	expected := map[string]string{
+		"recording_date": "desc",
+		"first_name":     "asc",
+		"last_name":      "asc",
+		"age":            "desc",
+	}
+	for field, order := range expected {
+		if got := DefaultSortOrder(field); got != order {
+			t.Errorf("DefaultSortOrder(%q) = %q, want %q", field, got, order)
+		}
+	}
+
+	filter := resolveReportFilter(types.GetReportsParams{SortBy: "last_name"})
+	if filter.sortOrder != "asc" {
+		t.Errorf("resolveReportFilter() sortOrder = %q, want asc for last_name", filter.sortOrder)
+	}
+}
+
+func TestAllowedSortFieldsExtension(t *testing.T) {
+	// A logical field may map to a differently named physical column
+	AllowedSortFields["email"] = "email_address"
+	t.Cleanup(func() { delete(AllowedSortFields, "email") })
+
+	if err := validateSortParams("email", "asc"); err != nil {
+		t.Errorf("validateSortParams() rejected newly allowed field: %v", err)
+	}
+	if got := buildOrderClause("email", "asc"); got != "email_address ASC" {
+		t.Errorf("buildOrderClause() = %q, want %q", got, "email_address ASC")
+	}
+
+	// The physical column name itself is not a valid API field
+	if err := validateSortParams("email_address", "asc"); err == nil {
+		t.Error("validateSortParams() accepted a physical column name")
+	}
+	if got := buildOrderClause("email_address", "asc"); got != "recording_date ASC" {
+		t.Errorf("buildOrderClause() = %q, want default column", got)
+	}
+}
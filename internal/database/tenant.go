@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+)
+
+// ErrTenantRequired is returned by user queries in multi-tenant mode when the context carries no tenant
+var ErrTenantRequired = errors.New("tenant ID required in multi-tenant mode")
+
+// multiTenant scopes every user query to the tenant carried by the request context
+var multiTenant bool
+
+// SetMultiTenant enables tenant scoping: users are created with the context's tenant_id and every read,
+// update and delete adds a tenant_id = $n predicate. It requires migration 004_add_tenant_id.
+// It must be called during startup before any requests are served
+func SetMultiTenant(enabled bool) {
+	multiTenant = enabled
+}
+
+// MultiTenant reports whether user queries are scoped to a tenant
+func MultiTenant() bool {
+	return multiTenant
+}
+
+// tenantScope restricts a users query to one tenant; the zero value restricts nothing
+type tenantScope struct {
+	id string // empty when multi-tenancy is disabled
+}
+
+// scopeToTenant returns the scope for the tenant in ctx
+// It fails with ErrTenantRequired when multi-tenancy is enabled and ctx carries no tenant, so a
+// missing tenant can never widen a query to every tenant's rows.
+func scopeToTenant(ctx context.Context) (tenantScope, error) {
+	if !multiTenant {
+		return tenantScope{}, nil
+	}
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenantScope{}, ErrTenantRequired
+	}
+	return tenantScope{id: id}, nil
+}
+
+// scoped reports whether queries are restricted to a tenant
+func (s tenantScope) scoped() bool {
+	return s.id != ""
+}
+
+// where returns " WHERE tenant_id = $n" for queries without other conditions, or "" when unscoped
+func (s tenantScope) where(n int) string {
+	if !s.scoped() {
+		return ""
+	}
+	return fmt.Sprintf(" WHERE tenant_id = $%d", n)
+}
+
+// and returns " AND tenant_id = $n" to extend an existing WHERE clause, or "" when unscoped
+func (s tenantScope) and(n int) string {
+	if !s.scoped() {
+		return ""
+	}
+	return fmt.Sprintf(" AND tenant_id = $%d", n)
+}
+
+// args appends the tenant ID bound by where or and to args
+func (s tenantScope) args(args ...any) []any {
+	if !s.scoped() {
+		return args
+	}
+	return append(args, s.id)
+}
@@ -0,0 +1,265 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+	"github.com/chybatronik/goUserAPI/internal/types"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withMultiTenant enables tenant scoping for the duration of a test
+func withMultiTenant(t *testing.T) {
+	t.Helper()
+	SetMultiTenant(true)
+	t.Cleanup(func() { SetMultiTenant(false) })
+}
+
+// insertRow scans a generated ID and recording date
+type insertRow struct{}
+
+func (insertRow) Scan(dest ...any) error {
+	*dest[0].(*string) = "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	*dest[1].(*int64) = 1700000000
+	return nil
+}
+
+// recordingQuerier records the SQL and arguments of the last QueryRow call
+type recordingQuerier struct {
+	sql  string
+	args []any
+}
+
+func (q *recordingQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.sql, q.args = sql, args
+	return insertRow{}
+}
+
+func TestScopeToTenantDisabledAddsNoPredicate(t *testing.T) {
+	scope, err := scopeToTenant(tenant.WithID(context.Background(), "acme"))
+	require.NoError(t, err)
+
+	assert.False(t, scope.scoped())
+	assert.Empty(t, scope.where(1))
+	assert.Empty(t, scope.and(2))
+	assert.Equal(t, []any{"a"}, scope.args("a"))
+}
+
+func TestScopeToTenantRequiresTenantWhenEnabled(t *testing.T) {
+	withMultiTenant(t)
+
+	_, err := scopeToTenant(context.Background())
+	assert.ErrorIs(t, err, ErrTenantRequired)
+}
+
+func TestScopeToTenantBindsTenantID(t *testing.T) {
+	withMultiTenant(t)
+
+	scope, err := scopeToTenant(tenant.WithID(context.Background(), "acme"))
+	require.NoError(t, err)
+
+	assert.Equal(t, " WHERE tenant_id = $1", scope.where(1))
+	assert.Equal(t, " AND tenant_id = $3", scope.and(3))
+	assert.Equal(t, []any{10, 0, "acme"}, scope.args(10, 0))
+}
+
+func TestUsersPageQueryScopedToTenant(t *testing.T) {
+	assert.NotContains(t, usersPageWithCountQuery("recording_date DESC", tenantScope{}), "tenant_id")
+	assert.Contains(t, usersPageWithCountQuery("recording_date DESC", tenantScope{id: "acme"}), "FROM users WHERE tenant_id = $3")
+}
+
+func TestInsertUserStoresTenant(t *testing.T) {
+	user := &models.User{FirstName: "Ada", LastName: "Lovelace", Age: 36}
+
+	q := &recordingQuerier{}
+	_, err := insertUser(context.Background(), q, user)
+	require.NoError(t, err)
+	assert.NotContains(t, q.sql, "tenant_id", "single-tenant inserts leave tenant_id alone")
+
+	withMultiTenant(t)
+
+	_, err = insertUser(context.Background(), q, user)
+	assert.ErrorIs(t, err, ErrTenantRequired)
+
+	created, err := insertUser(tenant.WithID(context.Background(), "acme"), q, user)
+	require.NoError(t, err)
+	assert.Equal(t, "3f2504e0-4f89-11d3-9a0c-0305e82c3301", created.ID)
+	assert.Contains(t, q.sql, "tenant_id")
+	require.Len(t, q.args, 6)
+	assert.Equal(t, "acme", q.args[5])
+}
+
+// setupTenantTestPool returns a test database pool with multi-tenancy enabled and two tenant IDs
+// unique to this run, so rows of earlier runs and other tests never fall into either tenant
+func setupTenantTestPool(t *testing.T) (pool *pgxpool.Pool, tenantA, tenantB string) {
+	t.Helper()
+	pool = setupBulkTestPool(t)
+	withMultiTenant(t)
+	run := time.Now().UnixNano()
+	return pool, fmt.Sprintf("isolation-a-%d", run), fmt.Sprintf("isolation-b-%d", run)
+}
+
+// createTenantUsers creates n users in tenant and returns their IDs
+func createTenantUsers(t *testing.T, ctx context.Context, pool *pgxpool.Pool, tenantID string, n int) []string {
+	t.Helper()
+	ids := make([]string, n)
+	for i := range ids {
+		user, err := CreateUser(tenant.WithID(ctx, tenantID),
+			pool, &models.User{FirstName: fmt.Sprintf("Tenant%d", i), LastName: bulkTestLastName, Age: 30 + i})
+		require.NoError(t, err)
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// userIDs returns the IDs of users
+func userIDs(users []models.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// INTEGRATION TEST: GetUsers, GetUserByID and GetReports only read the rows of the context's tenant
+func TestTenantIsolationReads_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool, tenantA, tenantB := setupTenantTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	idsA := createTenantUsers(t, ctx, pool, tenantA, 3)
+	idsB := createTenantUsers(t, ctx, pool, tenantB, 2)
+	ctxA, ctxB := tenant.WithID(ctx, tenantA), tenant.WithID(ctx, tenantB)
+
+	users, total, err := GetUsers(ctxA, pool, types.GetUsersParams{Limit: 50})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, idsA, userIDs(users))
+	assert.Equal(t, int64(3), total)
+
+	users, total, err = GetUsers(ctxB, pool, types.GetUsersParams{Limit: 50})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, idsB, userIDs(users))
+	assert.Equal(t, int64(2), total)
+
+	user, err := GetUserByID(ctxA, pool, idsA[0])
+	require.NoError(t, err)
+	assert.Equal(t, idsA[0], user.ID)
+	_, err = GetUserByID(ctxB, pool, idsA[0])
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "another tenant's user is not found")
+
+	users, err = GetUsersByIDs(ctxB, pool, append(idsA, idsB...))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, idsB, userIDs(users))
+
+	users, total, err = GetReports(ctxA, pool, types.GetReportsParams{Limit: 50})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, idsA, userIDs(users))
+	assert.Equal(t, int64(3), total)
+
+	users, total, err = GetReports(ctxB, pool, types.GetReportsParams{Limit: 50})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, idsB, userIDs(users))
+	assert.Equal(t, int64(2), total)
+}
+
+// INTEGRATION TEST: with USER_COUNT_CACHE_TTL set, tenant-scoped GetUsers still counts each tenant exactly
+// The cached count covers the whole table, so serving it would leak other tenants' row counts
+func TestTenantIsolationBypassesCountCache_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool, tenantA, tenantB := setupTenantTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	SetUserCountCacheTTL(time.Minute)
+	t.Cleanup(func() { SetUserCountCacheTTL(0) })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	createTenantUsers(t, ctx, pool, tenantA, 3)
+	createTenantUsers(t, ctx, pool, tenantB, 1)
+
+	for tenantID, want := range map[string]int64{tenantA: 3, tenantB: 1} {
+		users, total, err := GetUsers(tenant.WithID(ctx, tenantID), pool, types.GetUsersParams{Limit: 50})
+		require.NoError(t, err)
+		assert.Len(t, users, int(want), tenantID)
+		assert.Equal(t, want, total, tenantID)
+	}
+
+	// Rows added after the first count are counted at once, which a cached total would miss
+	createTenantUsers(t, ctx, pool, tenantB, 1)
+	_, total, err := GetUsers(tenant.WithID(ctx, tenantB), pool, types.GetUsersParams{Limit: 50})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
+
+// INTEGRATION TEST: the same email upserts an independent user in each tenant
+// The upsert conflicts on the tenant and email, so updating one tenant's user leaves the other's alone.
+func TestTenantIsolationUpsert_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool, tenantA, tenantB := setupTenantTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctxA, ctxB := tenant.WithID(ctx, tenantA), tenant.WithID(ctx, tenantB)
+	email := fmt.Sprintf("shared.%d@example.com", time.Now().UnixNano())
+
+	userA, inserted, err := UpsertUser(ctxA, pool, &models.User{FirstName: "Ada", LastName: bulkTestLastName, Age: 36, Email: email})
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	userB, inserted, err := UpsertUser(ctxB, pool, &models.User{FirstName: "Bea", LastName: bulkTestLastName, Age: 40, Email: email})
+	require.NoError(t, err)
+	assert.True(t, inserted, "an email known to another tenant creates a new user")
+	assert.NotEqual(t, userA.ID, userB.ID)
+
+	updated, inserted, err := UpsertUser(ctxA, pool, &models.User{FirstName: "Adele", LastName: bulkTestLastName, Age: 37, Email: email})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+	assert.Equal(t, userA.ID, updated.ID)
+
+	stored, err := GetUserByID(ctxB, pool, userB.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Bea", stored.FirstName, "the update stays within tenant A")
+	assert.Equal(t, 40, stored.Age)
+}
+
+// INTEGRATION TEST: with REJECT_DUPLICATE_NAMES, a name only conflicts with users of the same tenant
+func TestTenantIsolationUniqueName_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool, tenantA, tenantB := setupTenantTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	SetRejectDuplicateNames(true)
+	t.Cleanup(func() { SetRejectDuplicateNames(false) })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	firstName := fmt.Sprintf("Unique%d", time.Now().UnixNano())
+
+	_, err := CreateUser(tenant.WithID(ctx, tenantA), pool, &models.User{FirstName: firstName, LastName: bulkTestLastName, Age: 30})
+	require.NoError(t, err)
+
+	_, err = CreateUser(tenant.WithID(ctx, tenantB), pool, &models.User{FirstName: firstName, LastName: bulkTestLastName, Age: 30})
+	require.NoError(t, err, "the same name is free in another tenant")
+
+	_, err = CreateUser(tenant.WithID(ctx, tenantA), pool, &models.User{FirstName: firstName, LastName: bulkTestLastName, Age: 30})
+	assert.ErrorIs(t, err, pkgerrors.ErrDuplicateName)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+)
+
+// sslModeVerifies reports whether the SSL mode verifies the server certificate
+func sslModeVerifies(mode string) bool {
+	return mode == "verify-ca" || mode == "verify-full"
+}
+
+// newTLSConfig builds the TLS configuration for verify-ca and verify-full modes
+// verify-full checks the certificate chain and the host name; verify-ca checks only the chain.
+func newTLSConfig(db config.DatabaseConfig) (*tls.Config, error) {
+	caPEM, err := os.ReadFile(db.SSLRootCert)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read SSL root certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in SSL root certificate %s", db.SSLRootCert)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    roots,
+		ServerName: db.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if db.SSLMode == "verify-ca" {
+		// Go cannot skip only the host name check, so verify the chain ourselves
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyChain(rawCerts, roots)
+		}
+	}
+
+	if db.SSLCert != "" {
+		cert, err := tls.LoadX509KeyPair(db.SSLCert, db.SSLKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load SSL client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyChain verifies the server certificate chain against roots without checking the host name
+func verifyChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server presented no certificate")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("unable to parse server certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	return err
+}
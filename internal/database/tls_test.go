@@ -0,0 +1,75 @@
+package database
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCA writes a self-signed CA certificate to a temporary file and returns its path and DER bytes
+func writeTestCA(t *testing.T) (string, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		DNSNames:              []string{"db.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "root.crt")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return path, der
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	rootCert, der := writeTestCA(t)
+
+	t.Run("verify-full checks the host name", func(t *testing.T) {
+		tlsConfig, err := newTLSConfig(config.DatabaseConfig{Host: "db.internal", SSLMode: "verify-full", SSLRootCert: rootCert})
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", tlsConfig.ServerName)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("verify-ca checks only the chain", func(t *testing.T) {
+		tlsConfig, err := newTLSConfig(config.DatabaseConfig{Host: "10.0.0.5", SSLMode: "verify-ca", SSLRootCert: rootCert})
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig.VerifyPeerCertificate)
+		assert.NoError(t, tlsConfig.VerifyPeerCertificate([][]byte{der}, nil))
+
+		_, otherDER := writeTestCA(t)
+		assert.Error(t, tlsConfig.VerifyPeerCertificate([][]byte{otherDER}, nil), "a certificate from another CA is rejected")
+	})
+
+	t.Run("invalid root certificate", func(t *testing.T) {
+		_, err := newTLSConfig(config.DatabaseConfig{SSLMode: "verify-full", SSLRootCert: filepath.Join(t.TempDir(), "missing.crt")})
+		assert.ErrorContains(t, err, "unable to read SSL root certificate")
+
+		empty := filepath.Join(t.TempDir(), "empty.crt")
+		require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+		_, err = newTLSConfig(config.DatabaseConfig{SSLMode: "verify-full", SSLRootCert: empty})
+		assert.ErrorContains(t, err, "no certificates found")
+	})
+}
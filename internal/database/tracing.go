@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer resolves through the global provider, so spans are no-ops until tracing.Setup installs an exporter
+var tracer = otel.Tracer("github.com/chybatronik/goUserAPI/internal/database")
+
+// startSpan starts a child span for a database operation
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+		),
+	)
+}
+
+// endSpan records the row count or error of a database operation and ends its span
+// The span's start and end timestamps carry the operation duration
+func endSpan(span trace.Span, rows int, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "database operation failed")
+	} else {
+		span.SetAttributes(attribute.Int("db.rows_returned", rows))
+	}
+	span.End()
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// txBeginner starts transactions; *pgxpool.Pool and *pgxpool.Conn satisfy it
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// WithTx runs fn inside a transaction, committing when fn returns nil and rolling back otherwise
+// The rollback is deferred, so a panic in fn also leaves no partial writes behind.
+// Errors from fn are returned unchanged so callers can still inspect them with errors.As.
+func WithTx(ctx context.Context, db txBeginner, fn func(tx pgx.Tx) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback after a successful commit is a no-op returning pgx.ErrTxClosed
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// retryableTxCodes are the SQLSTATEs after which rerunning the whole transaction can succeed
+var retryableTxCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// retryTxBaseDelay is the wait before the second attempt; it doubles on each further attempt
+var retryTxBaseDelay = 20 * time.Millisecond
+
+// isRetryableTxError reports whether err carries a serialization failure or deadlock SQLSTATE
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && retryableTxCodes[pgErr.Code]
+}
+
+// RetryableTx runs fn in a transaction like WithTx, rerunning it up to attempts times in total when
+// PostgreSQL aborts it with a serialization failure (40001) or deadlock (40P01). fn must be safe to
+// repeat: each attempt gets a fresh transaction and earlier attempts have been rolled back. Attempts
+// are spaced by exponential backoff with jitter and stop early when ctx is done. Any other error, and
+// the last retryable one, is returned unchanged.
+func RetryableTx(ctx context.Context, db txBeginner, attempts int, fn func(tx pgx.Tx) error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := retryTxBaseDelay << (attempt - 1)
+			delay += time.Duration(rand.Int64N(int64(delay)/2 + 1))
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+
+		err = WithTx(ctx, db, fn)
+		if err == nil || !isRetryableTxError(err) {
+			return err
+		}
+		log.Printf("Retrying transaction after retryable error (attempt %d of %d): %v", attempt+1, attempts, err)
+	}
+	return err
+}
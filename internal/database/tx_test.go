@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records whether the transaction was committed or rolled back
+// Only Commit and Rollback are implemented; any other call panics on the nil embedded Tx
+type fakeTx struct {
+	pgx.Tx
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeTx) Commit(ctx context.Context) error {
+	if f.commitErr != nil {
+		return f.commitErr
+	}
+	f.committed = true
+	return nil
+}
+
+func (f *fakeTx) Rollback(ctx context.Context) error {
+	if f.committed {
+		return pgx.ErrTxClosed
+	}
+	f.rolledBack = true
+	return nil
+}
+
+// fakeBeginner hands out a single fakeTx
+type fakeBeginner struct {
+	tx  *fakeTx
+	err error
+}
+
+func (f *fakeBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.tx, nil
+}
+
+func TestWithTxCommitsOnSuccess(t *testing.T) {
+	db := &fakeBeginner{tx: &fakeTx{}}
+
+	err := WithTx(context.Background(), db, func(tx pgx.Tx) error { return nil })
+
+	require.NoError(t, err)
+	assert.True(t, db.tx.committed)
+	assert.False(t, db.tx.rolledBack)
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	db := &fakeBeginner{tx: &fakeTx{}}
+	errInsert := errors.New("insert failed")
+
+	err := WithTx(context.Background(), db, func(tx pgx.Tx) error { return errInsert })
+
+	assert.ErrorIs(t, err, errInsert)
+	assert.Equal(t, errInsert, err, "errors from fn are returned unwrapped")
+	assert.False(t, db.tx.committed)
+	assert.True(t, db.tx.rolledBack)
+}
+
+func TestWithTxRollsBackOnPanic(t *testing.T) {
+	db := &fakeBeginner{tx: &fakeTx{}}
+
+	assert.Panics(t, func() {
+		_ = WithTx(context.Background(), db, func(tx pgx.Tx) error { panic("boom") })
+	})
+	assert.True(t, db.tx.rolledBack)
+}
+
+func TestWithTxReportsBeginAndCommitErrors(t *testing.T) {
+	errConn := errors.New("connection refused")
+	err := WithTx(context.Background(), &fakeBeginner{err: errConn}, func(tx pgx.Tx) error {
+		t.Fatal("fn must not run when the transaction cannot begin")
+		return nil
+	})
+	assert.ErrorIs(t, err, errConn)
+	assert.Contains(t, err.Error(), "failed to begin transaction")
+
+	errCommit := errors.New("serialization failure")
+	db := &fakeBeginner{tx: &fakeTx{commitErr: errCommit}}
+	err = WithTx(context.Background(), db, func(tx pgx.Tx) error { return nil })
+	assert.ErrorIs(t, err, errCommit)
+	assert.Contains(t, err.Error(), "failed to commit transaction")
+}
+
+// sequenceBeginner hands out a fresh fakeTx for every Begin and keeps them in order
+type sequenceBeginner struct {
+	txs []*fakeTx
+}
+
+func (s *sequenceBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	s.txs = append(s.txs, tx)
+	return tx, nil
+}
+
+// withoutRetryDelay removes the RetryableTx backoff for the duration of a test
+func withoutRetryDelay(t *testing.T) {
+	t.Helper()
+	previous := retryTxBaseDelay
+	retryTxBaseDelay = 0
+	t.Cleanup(func() { retryTxBaseDelay = previous })
+}
+
+func TestRetryableTxRetriesSerializationFailure(t *testing.T) {
+	withoutRetryDelay(t)
+	db := &sequenceBeginner{}
+
+	calls := 0
+	err := RetryableTx(context.Background(), db, 3, func(tx pgx.Tx) error {
+		calls++
+		if calls <= 2 {
+			return fmt.Errorf("insert failed: %w", &pgconn.PgError{Code: "40001"})
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	require.Len(t, db.txs, 3)
+	assert.True(t, db.txs[0].rolledBack)
+	assert.True(t, db.txs[1].rolledBack)
+	assert.True(t, db.txs[2].committed)
+}
+
+func TestRetryableTxStopsAfterAttempts(t *testing.T) {
+	withoutRetryDelay(t)
+	db := &sequenceBeginner{}
+	deadlock := &pgconn.PgError{Code: "40P01"}
+
+	err := RetryableTx(context.Background(), db, 2, func(tx pgx.Tx) error { return deadlock })
+
+	assert.ErrorIs(t, err, deadlock)
+	assert.Len(t, db.txs, 2)
+}
+
+func TestRetryableTxDoesNotRetryOtherErrors(t *testing.T) {
+	withoutRetryDelay(t)
+	db := &sequenceBeginner{}
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+
+	err := RetryableTx(context.Background(), db, 3, func(tx pgx.Tx) error { return uniqueViolation })
+
+	assert.ErrorIs(t, err, uniqueViolation)
+	assert.Len(t, db.txs, 1)
+}
+
+func TestRetryableTxStopsWhenContextDone(t *testing.T) {
+	db := &sequenceBeginner{}
+	ctx, cancel := context.WithCancel(context.Background())
+	serialization := &pgconn.PgError{Code: "40001"}
+
+	err := RetryableTx(ctx, db, 5, func(tx pgx.Tx) error {
+		cancel()
+		return serialization
+	})
+
+	assert.ErrorIs(t, err, serialization)
+	assert.Len(t, db.txs, 1, "no attempt starts after the context is cancelled")
+}
+
+// INTEGRATION TEST: an error mid-transaction leaves no rows behind
+func TestWithTx_RollbackIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	appConfig := &config.Config{
+		Database: config.DatabaseConfig{
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "postgres",
+			Database: "postgres_test",
+			SSLMode:  "disable",
+			MaxConns: 2,
+			MinConns: 0,
+		},
+	}
+
+	pool, err := NewConnectionPool(appConfig)
+	if err != nil {
+		t.Skipf("INTEGRATION TEST: Requires live test database: %v", err)
+	}
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS with_tx_test (id SERIAL PRIMARY KEY, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+	defer pool.Exec(context.Background(), `DROP TABLE IF EXISTS with_tx_test`)
+
+	errMidway := errors.New("second step failed")
+	err = WithTx(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `INSERT INTO with_tx_test (name) VALUES ('first')`); err != nil {
+			return err
+		}
+		return errMidway
+	})
+	require.ErrorIs(t, err, errMidway)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM with_tx_test`).Scan(&count))
+	assert.Zero(t, count, "rolled back insert must not be persisted")
+
+	err = WithTx(ctx, pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO with_tx_test (name) VALUES ('committed')`)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM with_tx_test`).Scan(&count))
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrEmailRequired is returned by UpsertUser for a user without an email, the upsert's natural key
+var ErrEmailRequired = errors.New("email is required to upsert a user")
+
+// upsertUserQuery inserts a user or, when the tenant already has a user with the email, updates its
+// name and age. recording_date is only set on insert. xmax is 0 only for a freshly inserted row,
+// which is how inserts and updates are told apart without a second query.
+// The conflict target matches the users_email_key expression index from migration 005_add_email.
+const upsertUserQuery = `INSERT INTO users (first_name, last_name, age, recording_date, email, tenant_id)
+		VALUES ($1, $2, $3, COALESCE($4::bigint, EXTRACT(EPOCH FROM NOW())::BIGINT), $5, $6)
+		ON CONFLICT ((COALESCE(tenant_id, '')), email) DO UPDATE
+		SET first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name, age = EXCLUDED.age
+		RETURNING id, recording_date, (xmax = 0) AS inserted`
+
+// UpsertUser creates the user with user.Email, or updates the first name, last name and age of the
+// existing one, reporting whether a new row was inserted
+// In multi-tenant mode the email is looked up within the request's tenant only.
+func UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	ctx, span := startSpan(ctx, "UpsertUser")
+	upserted, inserted, err := upsertUserWithPool(ctx, pool, user)
+	endSpan(span, 1, err)
+	return upserted, inserted, err
+}
+
+// upsertUserWithPool implements UpsertUser inside its tracing span
+func upsertUserWithPool(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultOperationTimeout)
+	defer cancel()
+
+	if user.Email == "" {
+		return nil, false, ErrEmailRequired
+	}
+	if err := validateUser(user); err != nil {
+		return nil, false, fmt.Errorf("validation failed: %w", err)
+	}
+
+	start := time.Now()
+
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Release()
+
+	upserted, inserted, err := upsertUser(ctx, conn, user)
+	if err != nil {
+		return nil, false, err
+	}
+
+	logPerformanceMetrics("UpsertUser", time.Since(start))
+	return upserted, inserted, nil
+}
+
+// upsertUser runs upsertUserQuery for user on q
+func upsertUser(ctx context.Context, q rowQuerier, user *models.User) (*models.User, bool, error) {
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	// Single-tenant rows keep a NULL tenant_id
+	var tenantID *string
+	if scope.scoped() {
+		tenantID = &scope.id
+	}
+
+	var recordingDate *int64
+	if user.RecordingDate != 0 {
+		recordingDate = &user.RecordingDate
+	}
+
+	upserted := models.User{FirstName: user.FirstName, LastName: user.LastName, Age: user.Age, Email: user.Email}
+	var inserted bool
+	err = q.QueryRow(ctx, upsertUserQuery, user.FirstName, user.LastName, user.Age, recordingDate, user.Email, tenantID).
+		Scan(&upserted.ID, &upserted.RecordingDate, &inserted)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to upsert user: %w", err)
+	}
+	return &upserted, inserted, nil
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upsertRow scans a fixed ID, recording date and (xmax = 0) result
+type upsertRow struct {
+	inserted bool
+}
+
+func (r upsertRow) Scan(dest ...any) error {
+	*dest[0].(*string) = "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	*dest[1].(*int64) = 1700000000
+	*dest[2].(*bool) = r.inserted
+	return nil
+}
+
+// upsertQuerier records the SQL and arguments of the last QueryRow call
+type upsertQuerier struct {
+	row  upsertRow
+	sql  string
+	args []any
+}
+
+func (q *upsertQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.sql, q.args = sql, args
+	return q.row
+}
+
+func TestUpsertUserQueryConflictsOnEmail(t *testing.T) {
+	assert.Contains(t, upsertUserQuery, "ON CONFLICT ((COALESCE(tenant_id, '')), email) DO UPDATE")
+	assert.Contains(t, upsertUserQuery, "RETURNING id, recording_date, (xmax = 0) AS inserted")
+	assert.NotContains(t, upsertUserQuery, "recording_date = EXCLUDED", "updates keep the original recording_date")
+}
+
+func TestUpsertUserReportsInsertOrUpdate(t *testing.T) {
+	user := &models.User{FirstName: "Ada", LastName: "Lovelace", Age: 36, Email: "ada@example.com"}
+
+	q := &upsertQuerier{row: upsertRow{inserted: true}}
+	created, inserted, err := upsertUser(context.Background(), q, user)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	assert.Equal(t, "3f2504e0-4f89-11d3-9a0c-0305e82c3301", created.ID)
+	assert.Equal(t, "ada@example.com", created.Email)
+	require.Len(t, q.args, 6)
+	assert.Equal(t, "ada@example.com", q.args[4])
+	assert.Nil(t, q.args[5], "single-tenant rows keep a NULL tenant_id")
+
+	q.row.inserted = false
+	_, inserted, err = upsertUser(context.Background(), q, user)
+	require.NoError(t, err)
+	assert.False(t, inserted)
+}
+
+func TestUpsertUserScopedToTenant(t *testing.T) {
+	withMultiTenant(t)
+	user := &models.User{FirstName: "Ada", LastName: "Lovelace", Age: 36, Email: "ada@example.com"}
+
+	q := &upsertQuerier{}
+	_, _, err := upsertUser(context.Background(), q, user)
+	assert.ErrorIs(t, err, ErrTenantRequired)
+
+	_, _, err = upsertUser(tenant.WithID(context.Background(), "acme"), q, user)
+	require.NoError(t, err)
+	require.Len(t, q.args, 6)
+	tenantID, ok := q.args[5].(*string)
+	require.True(t, ok)
+	assert.Equal(t, "acme", *tenantID)
+}
+
+func TestUpsertUserRequiresEmail(t *testing.T) {
+	_, _, err := UpsertUser(context.Background(), nil, &models.User{FirstName: "Ada", LastName: "Lovelace", Age: 36})
+	assert.ErrorIs(t, err, ErrEmailRequired)
+}
@@ -3,11 +3,16 @@ package database
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/chybatronik/goUserAPI/internal/logging"
 	"github.com/chybatronik/goUserAPI/internal/models"
 	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,11 +26,78 @@ const (
 	PerformanceCriticalThreshold = 180 * time.Millisecond
 )
 
+// ageBounds is the accepted age range enforced before queries reach the database
+// The users.age CHECK constraint remains the final guard for the default 1-120 range
+var ageBounds = validation.DefaultAgeBounds
+
+// SetAgeBounds configures the age range used by database-level validation
+// It must be called during startup before any requests are served
+func SetAgeBounds(bounds validation.AgeBounds) {
+	ageBounds = bounds
+}
+
+// pageSize is the accepted page size range enforced before queries reach the database
+var pageSize = validation.DefaultPageSize
+
+// SetPageSize configures the maximum limit used by database-level validation
+// It must be called during startup before any requests are served
+func SetPageSize(size validation.PageSize) {
+	pageSize = size
+}
+
+// DefaultMaxResultRows is the most rows a single read may return when MAX_RESULT_ROWS is not configured
+const DefaultMaxResultRows = 1000
+
+// maxResultRows is a hard cap on the limit of every multi-row read
+// It is defense in depth below the handlers' page size: internal callers get no bigger results than clients do
+var maxResultRows = DefaultMaxResultRows
+
+// SetMaxResultRows configures the largest limit GetUsers, GetReports and GetAllUsers accept
+// It must be called during startup before any requests are served
+func SetMaxResultRows(rows int) {
+	maxResultRows = rows
+}
+
+// validateResultRows rejects a limit above maxResultRows
+func validateResultRows(limit int) error {
+	if limit > maxResultRows {
+		return fmt.Errorf("invalid limit: %d (exceeds the maximum result size of %d rows)", limit, maxResultRows)
+	}
+	return nil
+}
+
+// rejectDuplicateNames makes CreateUser refuse a first/last name pair that already exists
+var rejectDuplicateNames bool
+
+// SetRejectDuplicateNames enables the case-insensitive first_name/last_name uniqueness check in CreateUser
+// It must be called during startup before any requests are served
+func SetRejectDuplicateNames(enabled bool) {
+	rejectDuplicateNames = enabled
+}
+
+// RejectsDuplicateNames reports whether CreateUser rejects duplicate first/last name pairs
+func RejectsDuplicateNames() bool {
+	return rejectDuplicateNames
+}
+
+// rowQuerier is satisfied by both *pgxpool.Pool and pgx.Tx
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 // CreateUser inserts a new user into the database (AC: #2, #3)
 // Uses parameterized queries for security (NFR-S1)
 // Returns user with generated ID and recording_date
 // Includes performance monitoring for NFR-P1 compliance (AC #5)
 func CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
+	ctx, span := startSpan(ctx, "CreateUser")
+	created, err := createUser(ctx, pool, user)
+	endSpan(span, 1, err)
+	return created, err
+}
+
+// createUser implements CreateUser inside its tracing span
+func createUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
 	// Add operation timeout for performance guarantees (AC #5)
 	ctx, cancel := context.WithTimeout(ctx, DefaultOperationTimeout)
 	defer cancel()
@@ -38,28 +110,112 @@ func CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*mo
 	// Performance monitoring start
 	start := time.Now()
 
-	// PostgreSQL gen_random_uuid() for ID generation (AC: #3)
-	// Uses existing indexes: idx_users_recording_date_desc for optimal insertion
-	query := `INSERT INTO users (first_name, last_name, age) VALUES ($1, $2, $3) RETURNING id, recording_date`
-
-	var newUser models.User
-	err := pool.QueryRow(ctx, query, user.FirstName, user.LastName, user.Age).Scan(&newUser.ID, &newUser.RecordingDate)
+	conn, err := acquireConn(ctx, pool)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, err
 	}
+	defer conn.Release()
 
-	// Copy user data to returned struct
-	newUser.FirstName = user.FirstName
-	newUser.LastName = user.LastName
-	newUser.Age = user.Age
+	var newUser *models.User
+	if rejectDuplicateNames {
+		newUser, err = insertUserUniqueName(ctx, conn, user)
+	} else {
+		newUser, err = insertUser(ctx, conn, user)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Performance monitoring end
 	duration := time.Since(start)
 	logPerformanceMetrics("CreateUser", duration)
 
+	return newUser, nil
+}
+
+// insertUser inserts user and returns it with the generated ID and recording_date
+func insertUser(ctx context.Context, q rowQuerier, user *models.User) (*models.User, error) {
+	// PostgreSQL gen_random_uuid() for ID generation (AC: #3)
+	// Uses existing indexes: idx_users_recording_date_desc for optimal insertion
+	// A zero RecordingDate keeps the column default (now); imports pass the original timestamp
+	// An empty Email is stored as NULL so users without one never conflict on users_email_key
+	query := `INSERT INTO users (first_name, last_name, age, recording_date, email)
+		VALUES ($1, $2, $3, COALESCE($4::bigint, EXTRACT(EPOCH FROM NOW())::BIGINT), NULLIF($5, ''))
+		RETURNING id, recording_date`
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// In multi-tenant mode the user belongs to the request's tenant
+	if scope.scoped() {
+		query = `INSERT INTO users (first_name, last_name, age, recording_date, email, tenant_id)
+		VALUES ($1, $2, $3, COALESCE($4::bigint, EXTRACT(EPOCH FROM NOW())::BIGINT), NULLIF($5, ''), $6)
+		RETURNING id, recording_date`
+	}
+
+	var recordingDate *int64
+	if user.RecordingDate != 0 {
+		recordingDate = &user.RecordingDate
+	}
+
+	newUser := models.User{FirstName: user.FirstName, LastName: user.LastName, Age: user.Age, Email: user.Email}
+	args := scope.args(user.FirstName, user.LastName, user.Age, recordingDate, user.Email)
+	err = q.QueryRow(ctx, query, args...).Scan(&newUser.ID, &newUser.RecordingDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
 	return &newUser, nil
 }
 
+// insertUserUniqueName inserts user unless a user with the same first and last name exists, ignoring case,
+// in which case it returns pkgerrors.ErrDuplicateName
+// This is best-effort: there is no unique index, so rows inserted while the check was disabled are not
+// deduplicated. Concurrent creates of the same name through this path are serialized by a transaction-scoped
+// advisory lock on the name, so they cannot both pass the existence check.
+// In multi-tenant mode names only need to be unique within the tenant.
+func insertUserUniqueName(ctx context.Context, db txBeginner, user *models.User) (*models.User, error) {
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var newUser *models.User
+	err = WithTx(ctx, db, func(tx pgx.Tx) error {
+		// Hash collisions only serialize unrelated names; they never cause a false conflict
+		lockQuery := `SELECT pg_advisory_xact_lock(hashtext(lower($1) || '|' || lower($2)))`
+		if _, err := tx.Exec(ctx, lockQuery, user.FirstName, user.LastName); err != nil {
+			return fmt.Errorf("failed to lock user name: %w", err)
+		}
+
+		var exists bool
+		existsQuery := `SELECT EXISTS (SELECT 1 FROM users WHERE lower(first_name) = lower($1) AND lower(last_name) = lower($2)` +
+			scope.and(3) + `)`
+		if err := tx.QueryRow(ctx, existsQuery, scope.args(user.FirstName, user.LastName)...).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check for duplicate user name: %w", err)
+		}
+		if exists {
+			return pkgerrors.ErrDuplicateName
+		}
+
+		var err error
+		newUser, err = insertUser(ctx, tx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUser, nil
+}
+
+// userColumns is the select list of every user read; a NULL email is read as empty
+const userColumns = `id, first_name, last_name, age, recording_date, COALESCE(email, '') AS email`
+
+// userFields returns the scan destinations matching userColumns
+func userFields(user *models.User) []any {
+	return []any{&user.ID, &user.FirstName, &user.LastName, &user.Age, &user.RecordingDate, &user.Email}
+}
+
 // GetUserByID retrieves a user by ID using parameterized query
 // Includes performance monitoring for NFR-P1 compliance (AC #5)
 func GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error) {
@@ -69,11 +225,22 @@ func GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.Us
 
 	start := time.Now()
 
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Uses primary key index for optimal performance
-	query := `SELECT id, first_name, last_name, age, recording_date FROM users WHERE id = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1` + scope.and(2)
+
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
 
 	var user models.User
-	err := pool.QueryRow(ctx, query, id).Scan(&user.ID, &user.FirstName, &user.LastName, &user.Age, &user.RecordingDate)
+	err = conn.QueryRow(ctx, query, scope.args(id)...).Scan(userFields(&user)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by ID %s: %w", id, err)
 	}
@@ -85,11 +252,88 @@ func GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.Us
 	return &user, nil
 }
 
+// MaxBatchGetIDs caps how many IDs a single batch lookup may request
+const MaxBatchGetIDs = 100
+
+// GetUsersByIDs retrieves the users matching ids in one query
+// Missing IDs are simply absent from the result; callers compare against ids to report them
+func GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error) {
+	ctx, span := startSpan(ctx, "GetUsersByIDs")
+	users, err := getUsersByIDs(ctx, pool, ids)
+	endSpan(span, len(users), err)
+	return users, err
+}
+
+// getUsersByIDs implements GetUsersByIDs inside its tracing span
+func getUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error) {
+	if len(ids) > MaxBatchGetIDs {
+		return nil, fmt.Errorf("too many IDs: %d (max %d)", len(ids), MaxBatchGetIDs)
+	}
+	if len(ids) == 0 {
+		return []models.User{}, nil
+	}
+
+	// Add operation timeout for performance guarantees (AC #5)
+	ctx, cancel := context.WithTimeout(ctx, DefaultOperationTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// IDs are bound as a single uuid[] parameter; the primary key index serves the lookup
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ANY($1::uuid[])` + scope.and(2)
+
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, query, scope.args(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0, len(ids))
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(userFields(&user)...); err != nil {
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user rows: %w", err)
+	}
+
+	// Performance monitoring
+	duration := time.Since(start)
+	logPerformanceMetrics("GetUsersByIDs", duration)
+
+	return users, nil
+}
+
 // GetAllUsers retrieves all users with pagination support
 func GetAllUsers(ctx context.Context, pool *pgxpool.Pool, limit, offset int) ([]*models.User, error) {
-	query := `SELECT id, first_name, last_name, age, recording_date FROM users ORDER BY recording_date DESC LIMIT $1 OFFSET $2`
+	if err := validateResultRows(limit); err != nil {
+		return nil, fmt.Errorf("parameter validation failed: %w", err)
+	}
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + scope.where(3) +
+		` ORDER BY recording_date DESC LIMIT $1 OFFSET $2`
 
-	rows, err := pool.Query(ctx, query, limit, offset)
+	rows, err := pool.Query(ctx, query, scope.args(limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
@@ -98,7 +342,7 @@ func GetAllUsers(ctx context.Context, pool *pgxpool.Pool, limit, offset int) ([]
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Age, &user.RecordingDate)
+		err := rows.Scan(userFields(&user)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user row: %w", err)
 		}
@@ -119,10 +363,16 @@ func UpdateUser(ctx context.Context, pool *pgxpool.Pool, id string, user *models
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	query := `UPDATE users SET first_name = $1, last_name = $2, age = $3 WHERE id = $4 RETURNING id, recording_date`
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE users SET first_name = $1, last_name = $2, age = $3 WHERE id = $4` + scope.and(5) +
+		` RETURNING id, recording_date`
 
 	var updatedUser models.User
-	err := pool.QueryRow(ctx, query, user.FirstName, user.LastName, user.Age, id).Scan(&updatedUser.ID, &updatedUser.RecordingDate)
+	err = pool.QueryRow(ctx, query, scope.args(user.FirstName, user.LastName, user.Age, id)...).Scan(&updatedUser.ID, &updatedUser.RecordingDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user %s: %w", id, err)
 	}
@@ -137,9 +387,14 @@ func UpdateUser(ctx context.Context, pool *pgxpool.Pool, id string, user *models
 
 // DeleteUser deletes a user by ID
 func DeleteUser(ctx context.Context, pool *pgxpool.Pool, id string) error {
-	query := `DELETE FROM users WHERE id = $1`
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM users WHERE id = $1` + scope.and(2)
 
-	cmdTag, err := pool.Exec(ctx, query, id)
+	cmdTag, err := pool.Exec(ctx, query, scope.args(id)...)
 	if err != nil {
 		return fmt.Errorf("failed to delete user %s: %w", id, err)
 	}
@@ -156,52 +411,62 @@ func validateUser(user *models.User) error {
 	if user.FirstName == "" {
 		return fmt.Errorf("first_name cannot be empty")
 	}
-	if len(user.FirstName) > 100 {
-		return fmt.Errorf("first_name cannot exceed 100 characters")
+	// VARCHAR(100) counts characters, not bytes; any name within the handler's byte limit fits
+	if utf8.RuneCountInString(user.FirstName) > validation.MaxNameColumnLength {
+		return fmt.Errorf("first_name cannot exceed %d characters", validation.MaxNameColumnLength)
 	}
 	if user.LastName == "" {
 		return fmt.Errorf("last_name cannot be empty")
 	}
-	if len(user.LastName) > 100 {
-		return fmt.Errorf("last_name cannot exceed 100 characters")
+	if utf8.RuneCountInString(user.LastName) > validation.MaxNameColumnLength {
+		return fmt.Errorf("last_name cannot exceed %d characters", validation.MaxNameColumnLength)
 	}
-	if user.Age < 1 || user.Age > 120 {
-		return fmt.Errorf("age must be between 1 and 120 years")
+	if err := validation.ValidateAge(user.Age, ageBounds.Min, ageBounds.Max); err != nil {
+		return err
+	}
+	if user.Email != "" {
+		if err := validation.ValidateEmail(user.Email); err != nil {
+			return err
+		}
+	}
+	if user.RecordingDate != 0 {
+		if err := validation.ValidateRecordingDate(user.RecordingDate, time.Now()); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // TransactionExample demonstrates transaction support (AC: #2)
+// Either every user is inserted or, on the first failure, none are
 func TransactionExample(ctx context.Context, pool *pgxpool.Pool, users []*models.User) error {
-	tx, err := pool.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
-
-	for _, user := range users {
-		if err := validateUser(user); err != nil {
-			return fmt.Errorf("validation failed for user %s %s: %w", user.FirstName, user.LastName, err)
+	return WithTx(ctx, pool, func(tx pgx.Tx) error {
+		for _, user := range users {
+			if err := validateUser(user); err != nil {
+				return fmt.Errorf("validation failed for user %s %s: %w", user.FirstName, user.LastName, err)
+			}
+
+			query := `INSERT INTO users (first_name, last_name, age) VALUES ($1, $2, $3)`
+			if _, err := tx.Exec(ctx, query, user.FirstName, user.LastName, user.Age); err != nil {
+				return fmt.Errorf("failed to insert user %s %s: %w", user.FirstName, user.LastName, err)
+			}
 		}
-
-		query := `INSERT INTO users (first_name, last_name, age) VALUES ($1, $2, $3)`
-		_, err := tx.Exec(ctx, query, user.FirstName, user.LastName, user.Age)
-		if err != nil {
-			return fmt.Errorf("failed to insert user %s %s: %w", user.FirstName, user.LastName, err)
-		}
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // GetUsers retrieves users with pagination and sorting (Story 2.3)
 // Uses parameterized queries for security (NFR-S1 compliance)
 // Returns users array, total count, and error
 func GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
+	ctx, span := startSpan(ctx, "GetUsers")
+	users, totalCount, err := getUsers(ctx, pool, params)
+	endSpan(span, len(users), err)
+	return users, totalCount, err
+}
+
+// getUsers implements GetUsers inside its tracing span
+func getUsers(ctx context.Context, pool connAcquirer, params types.GetUsersParams) ([]models.User, int64, error) {
 	// Add operation timeout for performance guarantees (AC #5)
 	ctx, cancel := context.WithTimeout(ctx, DefaultOperationTimeout)
 	defer cancel()
@@ -216,31 +481,80 @@ func GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersPara
 	// Build ORDER BY clause with whitelist validation
 	orderClause := buildOrderClause(params.SortBy, params.SortOrder)
 
-	// First, get total count
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Release()
+
+	var users []models.User
 	var totalCount int64
-	countQuery := `SELECT COUNT(*) FROM users`
-	err := pool.QueryRow(ctx, countQuery).Scan(&totalCount)
+	var query string
+	var args []any
+	// The cached count covers the whole table, so tenant-scoped pages always count exactly
+	if userCountCache != nil && !scope.scoped() {
+		query, args = usersPageQuery(orderClause), []any{params.Limit + 1, params.Offset}
+		users, totalCount, err = getUsersPageCachedCount(ctx, conn, query, params)
+	} else {
+		query, args = usersPageWithCountQuery(orderClause, scope), scope.args(params.Limit, params.Offset)
+		users, totalCount, err = getUsersPageWithCount(ctx, conn, query, params, scope)
+	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
+		return nil, 0, err
 	}
 
-	// Main query with parameterized LIMIT and OFFSET
-	query := fmt.Sprintf(`
-		SELECT id, first_name, last_name, age, recording_date
+	// Performance monitoring
+	duration := time.Since(start)
+	logPerformanceMetrics("GetUsers", duration)
+	explainSlowQuery(ctx, conn, "GetUsers", query, duration, args...)
+
+	return users, totalCount, nil
+}
+
+// pageQuerier is satisfied by *pgxpool.Conn, *pgxpool.Pool and pgx.Tx
+type pageQuerier interface {
+	rowQuerier
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// usersPageWithCountQuery selects a page of users together with the total count
+// Page and total count come from one query, like GetReports: the window is evaluated before LIMIT/OFFSET,
+// so every row carries the full count and both come from the same snapshot
+func usersPageWithCountQuery(orderClause string, scope tenantScope) string {
+	return fmt.Sprintf(`
+		SELECT %s, COUNT(*) OVER() AS total_count
+		FROM users%s
+		ORDER BY %s
+		LIMIT $1 OFFSET $2`, userColumns, scope.where(3), orderClause)
+}
+
+// usersPageQuery selects a page of users without counting the table
+func usersPageQuery(orderClause string) string {
+	return fmt.Sprintf(`
+		SELECT %s
 		FROM users
 		ORDER BY %s
-		LIMIT $1 OFFSET $2`, orderClause)
+		LIMIT $1 OFFSET $2`, userColumns, orderClause)
+}
 
-	rows, err := pool.Query(ctx, query, params.Limit, params.Offset)
+// getUsersPageWithCount runs usersPageWithCountQuery and returns the page with its exact total count
+func getUsersPageWithCount(ctx context.Context, q pageQuerier, query string, params types.GetUsersParams, scope tenantScope) ([]models.User, int64, error) {
+	rows, err := q.Query(ctx, query, scope.args(params.Limit, params.Offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query users: %w", err)
 	}
 	defer rows.Close()
 
 	var users []models.User
+	var totalCount int64
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Age, &user.RecordingDate)
+		err := rows.Scan(append(userFields(&user), &totalCount)...)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan user row: %w", err)
 		}
@@ -251,35 +565,95 @@ func GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersPara
 		return nil, 0, fmt.Errorf("error iterating user rows: %w", err)
 	}
 
-	// Performance monitoring
-	duration := time.Since(start)
-	logPerformanceMetrics("GetUsers", duration)
+	// A page past the end has no rows to carry the count, so count separately
+	if len(users) == 0 {
+		if err := q.QueryRow(ctx, `SELECT COUNT(*) FROM users`+scope.where(1), scope.args()...).Scan(&totalCount); err != nil {
+			return nil, 0, fmt.Errorf("failed to get total count: %w", err)
+		}
+	}
 
 	return users, totalCount, nil
 }
 
+// getUsersPageCachedCount runs usersPageQuery for one row more than the page and takes the total from userCountCache
+// The extra row tells whether another page exists, and the cached count is clamped to agree with it,
+// so has_more stays exact even when total_count is stale. On the last page the count is exact.
+func getUsersPageCachedCount(ctx context.Context, q pageQuerier, query string, params types.GetUsersParams) ([]models.User, int64, error) {
+	rows, err := q.Query(ctx, query, params.Limit+1, params.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(userFields(&user)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating user rows: %w", err)
+	}
+
+	hasMore := len(users) > params.Limit
+	if hasMore {
+		users = users[:params.Limit]
+	}
+
+	cached, err := userCountCache.Get(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, clampCachedCount(cached, params.Offset, len(users), hasMore), nil
+}
+
+// clampCachedCount makes a possibly stale total agree with what the page query observed
+func clampCachedCount(cached int64, offset, pageLen int, hasMore bool) int64 {
+	seen := int64(offset + pageLen)
+	switch {
+	case hasMore:
+		// At least one row follows this page
+		return max(cached, seen+1)
+	case pageLen > 0:
+		// This is the last page, so the total is known exactly
+		return seen
+	default:
+		// Past the end: the table has at most offset rows
+		return min(cached, int64(offset))
+	}
+}
+
 // validateGetUsersParams validates query parameters for GetUsers
 func validateGetUsersParams(params types.GetUsersParams) error {
-	// Validate limit (1-100)
-	if params.Limit < 1 || params.Limit > 100 {
-		return fmt.Errorf("invalid limit: %d (must be between 1 and 100)", params.Limit)
+	if err := validateResultRows(params.Limit); err != nil {
+		return err
 	}
 
-	// Validate offset (>= 0)
+	// Validate limit against the configured page size
+	if !pageSize.Contains(params.Limit) {
+		return fmt.Errorf("invalid limit: %d (must be %s)", params.Limit, pageSize)
+	}
+
+	// Validate offset (>= 0 and within the configured maximum)
 	if params.Offset < 0 {
 		return fmt.Errorf("invalid offset: %d (must be >= 0)", params.Offset)
 	}
-
-	// Validate sort_by against whitelist
-	validSortFields := map[string]bool{
-		"recording_date": true,
-		"age":            true,
-		"first_name":     true,
-		"last_name":      true,
+	if !pageSize.AllowsOffset(params.Offset) {
+		return fmt.Errorf("invalid offset: %d (must not exceed %d)", params.Offset, pageSize.MaxOffset)
 	}
 
-	if !validSortFields[params.SortBy] {
-		return fmt.Errorf("invalid sort_by: %s (must be one of: recording_date, age, first_name, last_name)", params.SortBy)
+	return validateSortParams(params.SortBy, params.SortOrder)
+}
+
+// validateSortParams validates sort_by and sort_order against the shared whitelist
+func validateSortParams(sortBy, sortOrder string) error {
+	// Validate sort_by against whitelist
+	if _, ok := AllowedSortFields[sortBy]; !ok {
+		return fmt.Errorf("invalid sort_by: %s (must be one of: %s)", sortBy, strings.Join(SortFieldNames(), ", "))
 	}
 
 	// Validate sort_order
@@ -288,8 +662,8 @@ func validateGetUsersParams(params types.GetUsersParams) error {
 		"desc": true,
 	}
 
-	if !validSortOrders[params.SortOrder] {
-		return fmt.Errorf("invalid sort_order: %s (must be 'asc' or 'desc')", params.SortOrder)
+	if !validSortOrders[sortOrder] {
+		return fmt.Errorf("invalid sort_order: %s (must be 'asc' or 'desc')", sortOrder)
 	}
 
 	return nil
@@ -299,22 +673,15 @@ func validateGetUsersParams(params types.GetUsersParams) error {
 // This eliminates any possibility of SQL injection through defense in depth
 func buildOrderClause(sortBy, sortOrder string) string {
 	// Map whitelisted values to exact SQL fragments
-	validSortColumns := map[string]string{
-		"recording_date": "recording_date",
-		"age":            "age",
-		"first_name":     "first_name",
-		"last_name":      "last_name",
-	}
-
 	validSortOrders := map[string]string{
 		"asc":  "ASC",
 		"desc": "DESC",
 	}
 
 	// Get safe column name or default to recording_date
-	column, exists := validSortColumns[sortBy]
+	column, exists := AllowedSortFields[sortBy]
 	if !exists {
-		column = "recording_date"
+		column = AllowedSortFields[DefaultSortField]
 	}
 
 	// Get safe order or default to ASC
@@ -330,51 +697,61 @@ func buildOrderClause(sortBy, sortOrder string) string {
 // Uses parameterized queries for security (NFR-S1 compliance)
 // Returns users array, total count, and error
 func GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
+	ctx, span := startSpan(ctx, "GetReports")
+	users, totalCount, err := getReports(ctx, pool, params)
+	endSpan(span, len(users), err)
+	return users, totalCount, err
+}
+
+// getReports implements GetReports inside its tracing span
+func getReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
 	// Add operation timeout for performance guarantees (AC #5)
 	ctx, cancel := context.WithTimeout(ctx, DefaultOperationTimeout)
 	defer cancel()
 
 	start := time.Now()
 
-	// Set Epic 3 defaults if parameters are nil
-	var startDate int64 = 0
-	var endDate int64 = time.Now().Unix()
-	var minAge int = 1
-	var maxAge int = 120
+	filter := resolveReportFilter(params)
 
-	if params.StartDate != nil {
-		startDate = *params.StartDate
-	}
-	if params.EndDate != nil {
-		endDate = *params.EndDate
-	}
-	if params.MinAge != nil {
-		minAge = *params.MinAge
+	// Validate parameters
+	if err := validateGetReportsParams(params.Limit, params.Offset, filter.startDate, filter.endDate, filter.minAge, filter.maxAge); err != nil {
+		return nil, 0, fmt.Errorf("parameter validation failed: %w", err)
 	}
-	if params.MaxAge != nil {
-		maxAge = *params.MaxAge
+	if err := validateSortParams(filter.sortBy, filter.sortOrder); err != nil {
+		return nil, 0, fmt.Errorf("parameter validation failed: %w", err)
 	}
 
-	// Validate parameters
-	if err := validateGetReportsParams(params.Limit, params.Offset, startDate, endDate, minAge, maxAge); err != nil {
-		return nil, 0, fmt.Errorf("parameter validation failed: %w", err)
+	// Build ORDER BY clause with whitelist validation (shared with GetUsers)
+	orderClause := buildOrderClause(filter.sortBy, filter.sortOrder)
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Get filtered users with pagination in single query to avoid race conditions
 	// Use window function to get accurate count and results in atomic operation
-	query := `
+	query := fmt.Sprintf(`
 		WITH filtered_users AS (
-			SELECT id, first_name, last_name, age, recording_date,
+			SELECT %s,
 				   COUNT(*) OVER() as total_count
 			FROM users
-			WHERE recording_date >= $1 AND recording_date <= $2 AND age >= $3 AND age <= $4
-			ORDER BY recording_date DESC
+			WHERE recording_date >= $1 AND recording_date <= $2 AND age >= $3 AND age <= $4%s
+			ORDER BY %s
 			LIMIT $5 OFFSET $6
 		)
-		SELECT id, first_name, last_name, age, recording_date, total_count
-		FROM filtered_users`
+		SELECT id, first_name, last_name, age, recording_date, email, total_count
+		FROM filtered_users
+		ORDER BY %s`, userColumns, scope.and(7), orderClause, orderClause)
+	args := scope.args(filter.startDate, filter.endDate, filter.minAge, filter.maxAge, params.Limit, params.Offset)
 
-	rows, err := pool.Query(ctx, query, startDate, endDate, minAge, maxAge, params.Limit, params.Offset)
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query users for report: %w", err)
 	}
@@ -387,7 +764,7 @@ func GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReports
 	for rows.Next() {
 		hasRows = true
 		var user models.User
-		err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Age, &user.RecordingDate, &totalCount)
+		err := rows.Scan(append(userFields(&user), &totalCount)...)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan user row: %w", err)
 		}
@@ -398,8 +775,9 @@ func GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReports
 	if !hasRows {
 		// If no users found, still need to get count separately
 		countQuery := `SELECT COUNT(*) FROM users
-					   WHERE recording_date >= $1 AND recording_date <= $2 AND age >= $3 AND age <= $4`
-		err := pool.QueryRow(ctx, countQuery, startDate, endDate, minAge, maxAge).Scan(&totalCount)
+					   WHERE recording_date >= $1 AND recording_date <= $2 AND age >= $3 AND age <= $4` + scope.and(5)
+		countArgs := scope.args(filter.startDate, filter.endDate, filter.minAge, filter.maxAge)
+		err := conn.QueryRow(ctx, countQuery, countArgs...).Scan(&totalCount)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get total count: %w", err)
 		}
@@ -412,33 +790,170 @@ func GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReports
 	// Performance monitoring
 	duration := time.Since(start)
 	logPerformanceMetrics("GetReports", duration)
+	explainSlowQuery(ctx, conn, "GetReports", query, duration, args...)
 
 	return users, totalCount, nil
 }
 
+// reportFilter holds report parameters after Epic 3 defaults are applied
+type reportFilter struct {
+	startDate int64
+	endDate   int64
+	minAge    int
+	maxAge    int
+	sortBy    string
+	sortOrder string
+}
+
+// resolveReportFilter applies Epic 3 defaults to nil or empty report parameters
+func resolveReportFilter(params types.GetReportsParams) reportFilter {
+	filter := reportFilter{
+		startDate: 0,
+		endDate:   time.Now().Unix(),
+		minAge:    ageBounds.Min,
+		maxAge:    ageBounds.Max,
+		sortBy:    DefaultSortField,
+	}
+
+	if params.StartDate != nil {
+		filter.startDate = *params.StartDate
+	}
+	if params.EndDate != nil {
+		filter.endDate = *params.EndDate
+	}
+	if params.MinAge != nil {
+		filter.minAge = *params.MinAge
+	}
+	if params.MaxAge != nil {
+		filter.maxAge = *params.MaxAge
+	}
+	if params.SortBy != "" {
+		filter.sortBy = params.SortBy
+	}
+	filter.sortOrder = params.SortOrder
+	if filter.sortOrder == "" {
+		filter.sortOrder = DefaultSortOrder(filter.sortBy)
+	}
+
+	return filter
+}
+
+// StreamReports reads the same filtered report as GetReports but hands each row to fn as it is scanned
+// Rows are never accumulated, so exports are not bounded by memory. A zero Limit streams every matching row.
+// Streaming stops at the first error returned by fn, which is passed back to the caller.
+func StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	ctx, span := startSpan(ctx, "StreamReports")
+	rows, err := streamReports(ctx, pool, params, fn)
+	endSpan(span, rows, err)
+	return err
+}
+
+// streamReports implements StreamReports inside its tracing span and returns the number of rows streamed
+func streamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) (int, error) {
+	start := time.Now()
+
+	filter := resolveReportFilter(params)
+
+	// Validate parameters; unlike GetReports the page size is not capped
+	if params.Limit < 0 {
+		return 0, fmt.Errorf("parameter validation failed: invalid limit: %d (must be >= 0)", params.Limit)
+	}
+	if params.Offset < 0 {
+		return 0, fmt.Errorf("parameter validation failed: invalid offset: %d (must be >= 0)", params.Offset)
+	}
+	if err := validateReportFilters(filter.startDate, filter.endDate, filter.minAge, filter.maxAge); err != nil {
+		return 0, fmt.Errorf("parameter validation failed: %w", err)
+	}
+	if err := validateSortParams(filter.sortBy, filter.sortOrder); err != nil {
+		return 0, fmt.Errorf("parameter validation failed: %w", err)
+	}
+
+	orderClause := buildOrderClause(filter.sortBy, filter.sortOrder)
+
+	scope, err := scopeToTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// LIMIT NULL means no limit in PostgreSQL
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM users
+		WHERE recording_date >= $1 AND recording_date <= $2 AND age >= $3 AND age <= $4%s
+		ORDER BY %s
+		LIMIT NULLIF($5::int, 0) OFFSET $6`, userColumns, scope.and(7), orderClause)
+
+	conn, err := acquireConn(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	args := scope.args(filter.startDate, filter.endDate, filter.minAge, filter.maxAge, params.Limit, params.Offset)
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query users for report stream: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(userFields(&user)...); err != nil {
+			return count, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		if err := fn(user); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("error iterating user rows: %w", err)
+	}
+
+	// Performance monitoring
+	duration := time.Since(start)
+	logPerformanceMetrics("StreamReports", duration)
+
+	return count, nil
+}
+
 // validateGetReportsParams validates query parameters for GetReports
 func validateGetReportsParams(limit, offset int, startDate, endDate int64, minAge, maxAge int) error {
-	// Validate limit (1-100)
-	if limit < 1 || limit > 100 {
-		return fmt.Errorf("invalid limit: %d (must be between 1 and 100)", limit)
+	if err := validateResultRows(limit); err != nil {
+		return err
+	}
+
+	// Validate limit against the configured page size
+	if !pageSize.Contains(limit) {
+		return fmt.Errorf("invalid limit: %d (must be %s)", limit, pageSize)
 	}
 
-	// Validate offset (>= 0)
+	// Validate offset (>= 0 and within the configured maximum)
 	if offset < 0 {
 		return fmt.Errorf("invalid offset: %d (must be >= 0)", offset)
 	}
+	if !pageSize.AllowsOffset(offset) {
+		return fmt.Errorf("invalid offset: %d (must not exceed %d)", offset, pageSize.MaxOffset)
+	}
+
+	return validateReportFilters(startDate, endDate, minAge, maxAge)
+}
 
+// validateReportFilters validates the date and age filters shared by GetReports and StreamReports
+func validateReportFilters(startDate, endDate int64, minAge, maxAge int) error {
 	// Validate date range
 	if startDate > endDate {
 		return fmt.Errorf("invalid date range: start_date (%d) cannot be greater than end_date (%d)", startDate, endDate)
 	}
 
 	// Validate age range
-	if minAge < 1 || minAge > 120 {
-		return fmt.Errorf("invalid min_age: %d (must be between 1 and 120)", minAge)
+	if err := validation.ValidateAge(minAge, ageBounds.Min, ageBounds.Max); err != nil {
+		return fmt.Errorf("invalid min_age: %d (%w)", minAge, err)
 	}
-	if maxAge < 1 || maxAge > 120 {
-		return fmt.Errorf("invalid max_age: %d (must be between 1 and 120)", maxAge)
+	if err := validation.ValidateAge(maxAge, ageBounds.Min, ageBounds.Max); err != nil {
+		return fmt.Errorf("invalid max_age: %d (%w)", maxAge, err)
 	}
 	if minAge > maxAge {
 		return fmt.Errorf("invalid age range: min_age (%d) cannot be greater than max_age (%d)", minAge, maxAge)
@@ -448,8 +963,11 @@ func validateGetReportsParams(limit, offset int, startDate, endDate int64, minAg
 }
 
 // logPerformanceMetrics logs database operation performance for monitoring (AC #5)
+// and records the duration in Metrics for the GET /metrics/db latency summary
 // Helps ensure NFR-P1 compliance (<200ms response time) and provides observability
 func logPerformanceMetrics(operation string, duration time.Duration) {
+	Metrics.Record(operation, duration)
+
 	// Create a structured logger for database operations
 	logger := logging.NewStructuredLogger("info", "goUserAPI", "database")
 
@@ -1,11 +1,16 @@
 package database
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // INTEGRATION TEST: Test CreateUser functionality with actual database (AC: #2, #3)
@@ -78,6 +83,25 @@ func TestValidateUser(t *testing.T) {
 			wantErr: true,
 			errMsg:  "first_name cannot exceed 100 characters",
 		},
+		{
+			name: "multi-byte name within 100 characters",
+			user: &models.User{
+				FirstName: strings.Repeat("Ж", 100), // 200 bytes
+				LastName:  "Doe",
+				Age:       30,
+			},
+			wantErr: false,
+		},
+		{
+			name: "multi-byte last name too long",
+			user: &models.User{
+				FirstName: "John",
+				LastName:  strings.Repeat("Ж", 101),
+				Age:       30,
+			},
+			wantErr: true,
+			errMsg:  "last_name cannot exceed 100 characters",
+		},
 		{
 			name: "empty last name",
 			user: &models.User{
@@ -108,6 +132,27 @@ func TestValidateUser(t *testing.T) {
 			wantErr: true,
 			errMsg:  "age must be between 1 and 120 years",
 		},
+		{
+			name: "imported recording date",
+			user: &models.User{
+				FirstName:     "John",
+				LastName:      "Doe",
+				Age:           30,
+				RecordingDate: 1638360000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "recording date before 2000",
+			user: &models.User{
+				FirstName:     "John",
+				LastName:      "Doe",
+				Age:           30,
+				RecordingDate: 946684799,
+			},
+			wantErr: true,
+			errMsg:  "recording_date 946684799 is before 2000-01-01",
+		},
 	}
 
 	for _, tt := range tests {
@@ -347,3 +392,155 @@ func TestPerformance_ConcurrentOperations(t *testing.T) {
 			"Average duration should be well under NFR-P1 target for optimal performance")
 	*/
 }
+
+func TestGetUsersByIDs_RejectsTooManyIDs(t *testing.T) {
+	ids := make([]string, MaxBatchGetIDs+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("550e8400-e29b-41d4-a716-%012d", i)
+	}
+
+	// The cap is enforced before any query is issued, so no pool is needed
+	users, err := GetUsersByIDs(context.Background(), nil, ids)
+	assert.Error(t, err)
+	assert.Nil(t, users)
+
+	users, err = GetUsersByIDs(context.Background(), nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+// INTEGRATION TEST: the window count reports every row in the table on each page, including past the end
+func TestGetUsers_TotalCountMatchesAcrossPages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupBulkTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := BulkInsertUsers(ctx, pool, bulkTestUsers(25))
+	require.NoError(t, err)
+
+	var expected int64
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&expected))
+
+	pages := []struct {
+		name   string
+		offset int
+		rows   int
+	}{
+		{"first page", 0, 10},
+		{"later page", 10, 10},
+		{"past the end", int(expected) + 10, 0},
+	}
+
+	for _, page := range pages {
+		t.Run(page.name, func(t *testing.T) {
+			users, totalCount, err := GetUsers(ctx, pool, types.GetUsersParams{Limit: 10, Offset: page.offset})
+			require.NoError(t, err)
+			assert.Len(t, users, page.rows)
+			assert.Equal(t, expected, totalCount)
+		})
+	}
+}
+
+// INTEGRATION TEST: every read path returns its connection to the pool, including a stream stopped early
+func TestReadPaths_NoLeakedConns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupBulkTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	inserted, err := BulkInsertUsers(ctx, pool, bulkTestUsers(5))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), inserted)
+
+	users, _, err := GetUsers(ctx, pool, types.GetUsersParams{Limit: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, users)
+
+	_, err = GetUserByID(ctx, pool, users[0].ID)
+	require.NoError(t, err)
+
+	_, err = GetUsersByIDs(ctx, pool, []string{users[0].ID})
+	require.NoError(t, err)
+
+	_, _, err = GetReports(ctx, pool, types.GetReportsParams{Limit: 10})
+	require.NoError(t, err)
+
+	stop := fmt.Errorf("stop after first row")
+	err = StreamReports(ctx, pool, types.GetReportsParams{}, func(models.User) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestResolveReportFilter_ExactAge(t *testing.T) {
+	age := 30
+	filter := resolveReportFilter(types.GetReportsParams{MinAge: &age, MaxAge: &age})
+
+	assert.Equal(t, 30, filter.minAge)
+	assert.Equal(t, 30, filter.maxAge)
+	assert.NoError(t, validateReportFilters(filter.startDate, filter.endDate, filter.minAge, filter.maxAge),
+		"equal bounds are a valid single-age range")
+}
+
+func TestUserColumnsMatchScanFields(t *testing.T) {
+	assert.Equal(t, "id, first_name, last_name, age, recording_date, COALESCE(email, '') AS email", userColumns,
+		"a NULL email scans as empty")
+	assert.Len(t, userFields(&models.User{}), 6)
+}
+
+// INTEGRATION TEST: a stored email is returned by every user and report read
+func TestUserReadsReturnEmail_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pool := setupBulkTestPool(t)
+	AssertNoLeakedConns(t, pool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	withEmail, err := CreateUser(ctx, pool, &models.User{FirstName: "Email", LastName: bulkTestLastName, Age: 97, Email: "read.back@example.com"})
+	require.NoError(t, err)
+	withoutEmail, err := CreateUser(ctx, pool, &models.User{FirstName: "NoEmail", LastName: bulkTestLastName, Age: 97})
+	require.NoError(t, err)
+	want := map[string]string{withEmail.ID: "read.back@example.com", withoutEmail.ID: ""}
+
+	user, err := GetUserByID(ctx, pool, withEmail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "read.back@example.com", user.Email)
+
+	users, err := GetUsersByIDs(ctx, pool, []string{withEmail.ID, withoutEmail.ID})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	for _, user := range users {
+		assert.Equal(t, want[user.ID], user.Email)
+	}
+
+	age := 97
+	params := types.GetReportsParams{Limit: 100, StartDate: &withEmail.RecordingDate, MinAge: &age, MaxAge: &age}
+	reports, _, err := GetReports(ctx, pool, params)
+	require.NoError(t, err)
+	found := 0
+	for _, user := range reports {
+		if email, ok := want[user.ID]; ok {
+			assert.Equal(t, email, user.Email)
+			found++
+		}
+	}
+	assert.Equal(t, 2, found)
+
+	err = StreamReports(ctx, pool, params, func(user models.User) error {
+		if email, ok := want[user.ID]; ok {
+			assert.Equal(t, email, user.Email)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
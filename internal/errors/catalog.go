@@ -0,0 +1,145 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is used when Accept-Language names no supported locale
+const DefaultLocale = "en"
+
+// Catalog maps error codes to message templates per locale
+// Templates are fmt format strings; handlers pass the values the message needs, such as the configured range.
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string // locale -> code -> template
+}
+
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{messages: make(map[string]map[string]string)}
+}
+
+// Register adds or replaces message templates for a locale
+func (c *Catalog) Register(locale string, messages map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	locale = strings.ToLower(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string, len(messages))
+	}
+	for code, template := range messages {
+		c.messages[locale][code] = template
+	}
+}
+
+// Message formats the message for code in locale, falling back to DefaultLocale and then to the code itself
+func (c *Catalog) Message(locale, code string, args ...any) string {
+	c.mu.RLock()
+	template, ok := c.messages[locale][code]
+	if !ok {
+		template, ok = c.messages[DefaultLocale][code]
+	}
+	c.mu.RUnlock()
+
+	if !ok {
+		return code
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// Negotiate picks the registered locale with the highest quality in an Accept-Language value
+// Region subtags are ignored (ru-RU matches ru); ties keep the first listed; DefaultLocale is the fallback.
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	best, bestQ := DefaultLocale, 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := c.messages[language]; !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ {
+			best, bestQ = language, q
+		}
+	}
+	return best
+}
+
+// Messages is the catalog used by handlers; register additional locales at startup
+var Messages = newDefaultCatalog()
+
+// RequestLocale negotiates the response locale from the request's Accept-Language headers
+func RequestLocale(r *http.Request) string {
+	return Messages.Negotiate(strings.Join(r.Header.Values("Accept-Language"), ","))
+}
+
+// Message formats the message for code in locale using Messages
+func Message(locale, code string, args ...any) string {
+	return Messages.Message(locale, code, args...)
+}
+
+// newDefaultCatalog registers the built-in English and Russian messages for query parameter validation
+func newDefaultCatalog() *Catalog {
+	catalog := NewCatalog()
+	catalog.Register("en", map[string]string{
+		"INVALID_LIMIT_PARAMETER":       "Invalid limit parameter. Must be between 1 and %d",
+		"INVALID_OFFSET_PARAMETER":      "Invalid offset parameter. Must be >= 0",
+		"OFFSET_TOO_LARGE":              "Offset must not exceed %d. Use cursor pagination to read further",
+		"INVALID_SORT_FIELD":            "Invalid sort_by parameter. Must be one of: %s",
+		"INVALID_SORT_ORDER":            "Invalid sort_order parameter. Must be 'asc' or 'desc'",
+		"INVALID_FORMAT_PARAMETER":      "Invalid format parameter. Must be one of: %s",
+		"INVALID_DATE_FORMAT_PARAMETER": "Invalid date_format parameter. Must be one of: %s",
+		"INVALID_START_DATE_PARAMETER":  "Invalid start_date parameter. Must be Unix timestamp",
+		"INVALID_END_DATE_PARAMETER":    "Invalid end_date parameter. Must be Unix timestamp",
+		"INVALID_LAST_PARAMETER":        "Invalid last parameter. Must be a positive duration of at most 36500d such as 24h, 7d or 30d",
+		"CONFLICTING_DATE_PARAMETERS":   "The last parameter cannot be combined with start_date or end_date",
+		"INVALID_MIN_AGE_PARAMETER":     "Invalid min_age parameter. Must be integer between %d and %d",
+		"INVALID_MAX_AGE_PARAMETER":     "Invalid max_age parameter. Must be integer between %d and %d",
+		"INVALID_AGE_RANGE":             "Invalid age range: min_age cannot be greater than max_age",
+		"INVALID_AGE_PARAMETER":         "Invalid age parameter. Must be integer between %d and %d",
+		"CONFLICTING_AGE_PARAMETERS":    "The age parameter cannot be combined with min_age or max_age",
+		"UNSECURE_UNICODE_INPUT":        "Invalid unicode characters in parameter '%s'",
+		"INVALID_PARAMETER_FORMAT":      "Invalid format for parameter '%s'",
+		"INVALID_FIELD_SELECTION":       "Invalid fields parameter. Must be a comma-separated list of: %s",
+		"TOO_MANY_PARAMETERS":           "Too many query parameters. At most %d are allowed",
+	})
+	catalog.Register("ru", map[string]string{
+		"INVALID_LIMIT_PARAMETER":       "Недопустимый параметр limit. Ожидается число от 1 до %d",
+		"INVALID_OFFSET_PARAMETER":      "Недопустимый параметр offset. Должен быть >= 0",
+		"OFFSET_TOO_LARGE":              "Параметр offset не может превышать %d. Для чтения дальше используйте курсорную пагинацию",
+		"INVALID_SORT_FIELD":            "Недопустимый параметр sort_by. Допустимые значения: %s",
+		"INVALID_SORT_ORDER":            "Недопустимый параметр sort_order. Допустимо 'asc' или 'desc'",
+		"INVALID_FORMAT_PARAMETER":      "Недопустимый параметр format. Допустимые значения: %s",
+		"INVALID_DATE_FORMAT_PARAMETER": "Недопустимый параметр date_format. Допустимые значения: %s",
+		"INVALID_START_DATE_PARAMETER":  "Недопустимый параметр start_date. Ожидается Unix timestamp",
+		"INVALID_END_DATE_PARAMETER":    "Недопустимый параметр end_date. Ожидается Unix timestamp",
+		"INVALID_LAST_PARAMETER":        "Недопустимый параметр last. Ожидается положительная длительность не более 36500d, например 24h, 7d или 30d",
+		"CONFLICTING_DATE_PARAMETERS":   "Параметр last нельзя сочетать с start_date или end_date",
+		"INVALID_MIN_AGE_PARAMETER":     "Недопустимый параметр min_age. Ожидается целое число от %d до %d",
+		"INVALID_MAX_AGE_PARAMETER":     "Недопустимый параметр max_age. Ожидается целое число от %d до %d",
+		"INVALID_AGE_RANGE":             "Недопустимый диапазон возраста: min_age не может быть больше max_age",
+		"INVALID_AGE_PARAMETER":         "Недопустимый параметр age. Ожидается целое число от %d до %d",
+		"CONFLICTING_AGE_PARAMETERS":    "Параметр age нельзя сочетать с min_age или max_age",
+		"UNSECURE_UNICODE_INPUT":        "Недопустимые символы Unicode в параметре '%s'",
+		"INVALID_PARAMETER_FORMAT":      "Недопустимый формат параметра '%s'",
+		"INVALID_FIELD_SELECTION":       "Недопустимый параметр fields. Ожидается список через запятую из: %s",
+		"TOO_MANY_PARAMETERS":           "Слишком много параметров запроса. Допустимо не более %d",
+	})
+	return catalog
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCatalogNegotiate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"empty", "", "en"},
+		{"russian", "ru", "ru"},
+		{"region subtag", "ru-RU", "ru"},
+		{"quality values", "en;q=0.5, ru;q=0.9", "ru"},
+		{"unsupported falls through", "de-DE, ru;q=0.3", "ru"},
+		{"only unsupported", "fr, de", "en"},
+		{"q=0 excludes a locale", "ru;q=0", "en"},
+		{"tie keeps first listed", "en, ru", "en"},
+		{"case insensitive", "RU", "ru"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Messages.Negotiate(tc.acceptLanguage); got != tc.expected {
+				t.Errorf("Negotiate(%q) = %q, expected %q", tc.acceptLanguage, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCatalogMessage(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Register("en", map[string]string{"INVALID_LIMIT_PARAMETER": "Invalid limit. Max %d", "ONLY_ENGLISH": "English only"})
+	catalog.Register("ru", map[string]string{"INVALID_LIMIT_PARAMETER": "Недопустимый limit. Максимум %d"})
+
+	if got := catalog.Message("en", "INVALID_LIMIT_PARAMETER", 100); got != "Invalid limit. Max 100" {
+		t.Errorf("Unexpected English message: %q", got)
+	}
+	if got := catalog.Message("ru", "INVALID_LIMIT_PARAMETER", 100); got != "Недопустимый limit. Максимум 100" {
+		t.Errorf("Unexpected Russian message: %q", got)
+	}
+	if got := catalog.Message("ru", "ONLY_ENGLISH"); got != "English only" {
+		t.Errorf("Expected fallback to English, got %q", got)
+	}
+	if got := catalog.Message("ru", "UNKNOWN_CODE"); got != "UNKNOWN_CODE" {
+		t.Errorf("Expected unknown code to be returned as is, got %q", got)
+	}
+}
+
+func TestRequestLocale(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Add("Accept-Language", "de;q=1")
+	req.Header.Add("Accept-Language", "ru;q=0.8")
+	if got := RequestLocale(req); got != "ru" {
+		t.Errorf("Expected ru from multiple Accept-Language headers, got %q", got)
+	}
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"net/http"
+
+	"github.com/chybatronik/goUserAPI/pkg/errors"
+	pgerr "github.com/jackc/pgx/v5/pgconn"
+)
+
+// ConstraintError describes the user-facing error returned when a named constraint is violated
+// The message is fixed per constraint so raw PostgreSQL text never reaches the client
+type ConstraintError struct {
+	Code       string
+	Message    string
+	HTTPStatus int
+}
+
+// constraintErrors maps known constraint names to specific error codes
+// Constraints not listed here keep the generic validation error
+var constraintErrors = map[string]ConstraintError{
+	"users_age_check": {
+		Code:       "INVALID_AGE_RANGE",
+		Message:    "Age is outside the allowed range",
+		HTTPStatus: http.StatusBadRequest,
+	},
+	"users_email_key": {
+		Code:       errors.ErrCodeDuplicateEmail,
+		Message:    "A user with this email already exists",
+		HTTPStatus: http.StatusConflict,
+	},
+}
+
+// RegisterConstraintError adds or replaces the mapping for a constraint name
+// It is not safe for concurrent use and should only be called during startup
+func RegisterConstraintError(constraint string, mapping ConstraintError) {
+	constraintErrors[constraint] = mapping
+}
+
+// MapConstraintError returns the specific error for a known constraint violation
+// The second result is false when the constraint has no registered mapping
+func MapConstraintError(pgErr *pgerr.PgError) (*errors.UserError, bool) {
+	if pgErr == nil || pgErr.ConstraintName == "" {
+		return nil, false
+	}
+
+	mapping, ok := constraintErrors[pgErr.ConstraintName]
+	if !ok {
+		return nil, false
+	}
+
+	return &errors.UserError{
+		Code:       mapping.Code,
+		Message:    mapping.Message,
+		HTTPStatus: mapping.HTTPStatus,
+	}, true
+}
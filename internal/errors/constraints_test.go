@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRegisterConstraintError(t *testing.T) {
+	const constraint = "users_nickname_key"
+	t.Cleanup(func() { delete(constraintErrors, constraint) })
+
+	pgErr := &pgconn.PgError{Code: "23505", ConstraintName: constraint}
+	if _, ok := MapConstraintError(pgErr); ok {
+		t.Fatalf("expected no mapping before registration")
+	}
+
+	RegisterConstraintError(constraint, ConstraintError{
+		Code:       "USER_DUPLICATE_NICKNAME",
+		Message:    "A user with this nickname already exists",
+		HTTPStatus: http.StatusConflict,
+	})
+
+	userErr, ok := MapConstraintError(pgErr)
+	if !ok {
+		t.Fatalf("expected mapping after registration")
+	}
+	if userErr.Code != "USER_DUPLICATE_NICKNAME" || userErr.HTTPStatus != http.StatusConflict {
+		t.Errorf("unexpected mapping: %+v", userErr)
+	}
+}
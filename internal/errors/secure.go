@@ -2,6 +2,8 @@
 package errors
 
 import (
+	stderrors "errors"
+
 	"github.com/chybatronik/goUserAPI/pkg/errors"
 	pgerr "github.com/jackc/pgx/v5/pgconn"
 )
@@ -13,8 +15,20 @@ func MapDatabaseErrorSecure(err error) error {
 		return nil
 	}
 
+	// The duplicate name conflict is raised by the data layer itself and carries no internal details
+	if stderrors.Is(err, errors.ErrDuplicateName) {
+		return errors.ErrDuplicateName
+	}
+
+	// Waiting for a free pool connection timed out; the pool is saturated rather than the database down
+	if stderrors.Is(err, errors.ErrPoolExhausted) {
+		return errors.ErrPoolExhausted
+	}
+
 	// PostgreSQL specific errors - NEVER expose internal details to users
-	if pgErr, ok := err.(*pgerr.PgError); ok {
+	// Database functions wrap driver errors, so unwrap before inspecting
+	var pgErr *pgerr.PgError
+	if stderrors.As(err, &pgErr) {
 		// Log detailed error internally (would be done by caller)
 		// logger.Warn("Database constraint violation",
 		//     "constraint", pgErr.Constraint,
@@ -25,7 +39,18 @@ func MapDatabaseErrorSecure(err error) error {
 		// Return GENERIC error to users
 		switch pgErr.Code {
 		case "23505", "23503", "23502", "23514":
+			// Known constraints get a specific code; the raw PostgreSQL message is never used
+			if userErr, ok := MapConstraintError(pgErr); ok {
+				return userErr
+			}
 			return errors.NewUserValidationError("VALIDATION_ERROR", "Request failed validation")
+		case "57014":
+			// query_canceled, raised when statement_timeout stops a runaway query
+			return &errors.UserError{
+				Code:       "QUERY_TIMEOUT",
+				Message:    "Request took too long to process",
+				HTTPStatus: 503, // Service Unavailable
+			}
 		default:
 			return errors.NewUserDatabaseError("Database operation failed")
 		}
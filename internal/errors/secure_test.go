@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	usererrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestMapDatabaseErrorSecureConstraints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCode   string
+		expectedStatus int
+	}{
+		{
+			name:           "age check constraint",
+			err:            &pgconn.PgError{Code: "23514", ConstraintName: "users_age_check"},
+			expectedCode:   "INVALID_AGE_RANGE",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrapped age check constraint",
+			err:            fmt.Errorf("failed to create user: %w", &pgconn.PgError{Code: "23514", ConstraintName: "users_age_check"}),
+			expectedCode:   "INVALID_AGE_RANGE",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "other check constraint",
+			err:            &pgconn.PgError{Code: "23514", ConstraintName: "users_other_check"},
+			expectedCode:   "VALIDATION_ERROR",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "email unique constraint",
+			err:            &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key", Message: "duplicate key value violates unique constraint \"users_email_key\""},
+			expectedCode:   usererrors.ErrCodeDuplicateEmail,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "other unique constraint",
+			err:            &pgconn.PgError{Code: "23505", ConstraintName: "users_pkey"},
+			expectedCode:   "VALIDATION_ERROR",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "statement timeout",
+			err:            fmt.Errorf("failed to query users: %w", &pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"}),
+			expectedCode:   "QUERY_TIMEOUT",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "duplicate name",
+			err:            fmt.Errorf("transaction failed: %w", usererrors.ErrDuplicateName),
+			expectedCode:   usererrors.ErrCodeUserAlreadyExists,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userErr, ok := MapDatabaseErrorSecure(tc.err).(*usererrors.UserError)
+			if !ok {
+				t.Fatalf("expected *UserError")
+			}
+			if userErr.Code != tc.expectedCode {
+				t.Errorf("expected code %s, got %s", tc.expectedCode, userErr.Code)
+			}
+			if userErr.HTTPStatus != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, userErr.HTTPStatus)
+			}
+			if strings.Contains(userErr.Message, "duplicate key") || strings.Contains(userErr.Message, "canceling statement") {
+				t.Errorf("raw database message leaked: %s", userErr.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+)
+
+// MigrationStatusSource is the subset of database.MigrationRunner used to report migration status
+type MigrationStatusSource interface {
+	LoadMigrationFiles() ([]database.Migration, error)
+	GetExecutedMigrations(ctx context.Context) (map[string]bool, error)
+}
+
+// MigrationStatusResponse is the body of GET /admin/migrations
+type MigrationStatusResponse struct {
+	Executed []string `json:"executed"`
+	Pending  []string `json:"pending"`
+}
+
+// MigrationStatusHandler reports executed and pending migrations for operators
+type MigrationStatusHandler struct {
+	runner MigrationStatusSource
+	logger *logging.Logger
+}
+
+// NewMigrationStatusHandler creates a MigrationStatusHandler reading from runner
+func NewMigrationStatusHandler(logger *logging.Logger, runner MigrationStatusSource) *MigrationStatusHandler {
+	return &MigrationStatusHandler{
+		runner: runner,
+		logger: logger,
+	}
+}
+
+// ServeHTTP writes executed versions in ascending order and pending versions in execution order
+func (h *MigrationStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	migrations, err := h.runner.LoadMigrationFiles()
+	if err != nil {
+		h.logger.Error("Failed to load migration files", logging.FieldError, err)
+		errors.WriteInternalError(w, r)
+		return
+	}
+
+	executed, err := h.runner.GetExecutedMigrations(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to read executed migrations", logging.FieldError, err)
+		errors.WriteServiceUnavailableError(w, r)
+		return
+	}
+
+	response := MigrationStatusResponse{
+		Executed: make([]string, 0, len(executed)),
+		Pending:  make([]string, 0),
+	}
+	for version := range executed {
+		response.Executed = append(response.Executed, version)
+	}
+	sort.Strings(response.Executed)
+	for _, migration := range migrations {
+		if !executed[migration.Version] {
+			response.Pending = append(response.Pending, migration.Version)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeJSON(w, http.StatusOK, response, false); err != nil {
+		h.logger.Error("Failed to write migration status", logging.FieldError, err)
+	}
+}
+
+// MaintenanceStatus is the body of GET and PUT /admin/maintenance
+type MaintenanceStatus struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// MaintenanceHandler reports and toggles maintenance mode at runtime
+type MaintenanceHandler struct {
+	mode   *middleware.MaintenanceMode
+	logger *logging.Logger
+}
+
+// NewMaintenanceHandler creates a MaintenanceHandler switching mode
+func NewMaintenanceHandler(logger *logging.Logger, mode *middleware.MaintenanceMode) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		mode:   mode,
+		logger: logger,
+	}
+}
+
+// ServeHTTP writes the current state for GET and sets it from {"enabled": bool} for PUT
+func (h *MaintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut {
+		var status MaintenanceStatus
+		if err := json.NewDecoder(r.Body).Decode(&status); err != nil || status.Enabled == nil {
+			errors.WriteValidationError(w, r, "enabled", "Request body must be {\"enabled\": true|false}")
+			return
+		}
+		h.mode.SetEnabled(*status.Enabled)
+		h.logger.Warn("Maintenance mode changed", "enabled", *status.Enabled)
+	}
+
+	enabled := h.mode.Enabled()
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeJSON(w, http.StatusOK, MaintenanceStatus{Enabled: &enabled}, false); err != nil {
+		h.logger.Error("Failed to write maintenance status", logging.FieldError, err)
+	}
+}
+
+// LogCleaner removes log files older than a number of days, as logging.LogDir does
+type LogCleaner interface {
+	CleanupOldLogs(days int) (int, error)
+}
+
+// LogCleanupResponse is the body of POST /admin/logs/cleanup
+type LogCleanupResponse struct {
+	Removed int `json:"removed"`
+	Days    int `json:"days"`
+}
+
+// LogCleanupHandler removes old log files on demand
+type LogCleanupHandler struct {
+	cleaner     LogCleaner
+	defaultDays int
+	logger      *logging.Logger
+}
+
+// NewLogCleanupHandler creates a LogCleanupHandler; defaultDays is used when the request has no days
+// parameter and 0 makes the parameter required
+func NewLogCleanupHandler(logger *logging.Logger, cleaner LogCleaner, defaultDays int) *LogCleanupHandler {
+	return &LogCleanupHandler{
+		cleaner:     cleaner,
+		defaultDays: defaultDays,
+		logger:      logger,
+	}
+}
+
+// ServeHTTP removes log files older than ?days=N and writes how many were removed
+func (h *LogCleanupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	days := h.defaultDays
+	if value := r.URL.Query().Get("days"); value != "" || days == 0 {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			errors.WriteValidationError(w, r, "days", "days must be a positive integer")
+			return
+		}
+		days = parsed
+	}
+
+	removed, err := h.cleaner.CleanupOldLogs(days)
+	if err != nil {
+		h.logger.Error("Log cleanup failed", logging.FieldError, err, "removed", removed, "days", days)
+		errors.WriteInternalError(w, r)
+		return
+	}
+	h.logger.Warn("Old log files removed", "removed", removed, "days", days)
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeJSON(w, http.StatusOK, LogCleanupResponse{Removed: removed, Days: days}, false); err != nil {
+		h.logger.Error("Failed to write log cleanup result", logging.FieldError, err)
+	}
+}
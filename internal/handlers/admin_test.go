@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMigrationStatus serves fixed migration files and executed versions
+type fakeMigrationStatus struct {
+	files    []database.Migration
+	executed map[string]bool
+	err      error
+}
+
+func (f *fakeMigrationStatus) LoadMigrationFiles() ([]database.Migration, error) {
+	return f.files, nil
+}
+
+func (f *fakeMigrationStatus) GetExecutedMigrations(ctx context.Context) (map[string]bool, error) {
+	return f.executed, f.err
+}
+
+func TestMigrationStatusHandler(t *testing.T) {
+	runner := &fakeMigrationStatus{
+		files:    []database.Migration{{Version: "001_create_users"}, {Version: "002_add_indexes"}, {Version: "003_add_email"}},
+		executed: map[string]bool{"002_add_indexes": true, "001_create_users": true},
+	}
+	handler := NewMigrationStatusHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), runner)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/migrations", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"executed": ["001_create_users", "002_add_indexes"], "pending": ["003_add_email"]}`, w.Body.String())
+}
+
+func TestMigrationStatusHandlerEmptyListsAreArrays(t *testing.T) {
+	runner := &fakeMigrationStatus{executed: map[string]bool{}}
+	handler := NewMigrationStatusHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), runner)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/migrations", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"executed": [], "pending": []}`, w.Body.String())
+}
+
+func TestMigrationStatusHandlerDatabaseError(t *testing.T) {
+	runner := &fakeMigrationStatus{err: errors.New("connection refused")}
+	handler := NewMigrationStatusHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), runner)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/migrations", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotContains(t, w.Body.String(), "connection refused")
+}
+
+func TestMaintenanceHandler(t *testing.T) {
+	mode := middleware.NewMaintenanceMode(false)
+	handler := NewMaintenanceHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), mode)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": false}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{"enabled": true}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+	assert.True(t, mode.Enabled())
+}
+
+func TestMaintenanceHandlerRejectsMissingEnabled(t *testing.T) {
+	mode := middleware.NewMaintenanceMode(true)
+	handler := NewMaintenanceHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), mode)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, mode.Enabled(), "a rejected request must not change the mode")
+}
+
+func TestLogCleanupHandler(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := filepath.Join(dir, "app_2026-01-01_00-00-00.log")
+	newFile := filepath.Join(dir, "app_2026-10-15_00-00-00.log")
+	for _, path := range []string{oldFile, newFile} {
+		require.NoError(t, os.WriteFile(path, []byte("INFO: test\n"), 0644))
+	}
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	require.NoError(t, os.Chtimes(oldFile, monthAgo, monthAgo))
+
+	handler := NewLogCleanupHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), logging.LogDir(dir), 0)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/logs/cleanup?days=7", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"removed": 1, "days": 7}`, w.Body.String())
+	assert.NoFileExists(t, oldFile)
+	assert.FileExists(t, newFile)
+}
+
+func TestLogCleanupHandlerDaysParameter(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultDays int
+		query       string
+		wantStatus  int
+	}{
+		{"missing without retention", 0, "", http.StatusBadRequest},
+		{"missing uses retention", 30, "", http.StatusOK},
+		{"zero", 30, "?days=0", http.StatusBadRequest},
+		{"negative", 30, "?days=-1", http.StatusBadRequest},
+		{"not a number", 30, "?days=week", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLogCleanupHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), logging.LogDir(t.TempDir()), tt.defaultDays)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/logs/cleanup"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
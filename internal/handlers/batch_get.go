@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+)
+
+// GetUsersByIDsResponse represents the response for GET /users?ids=...
+type GetUsersByIDsResponse struct {
+	Users    []UserResponse `json:"users"`
+	NotFound []string       `json:"not_found"`
+}
+
+// parseUserIDs splits the comma-separated ids query parameter into distinct lowercase UUIDs
+func parseUserIDs(raw string) ([]string, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) > database.MaxBatchGetIDs {
+		return nil, pkgerrors.NewUserValidationError("TOO_MANY_IDS",
+			"Too many IDs. At most "+strconv.Itoa(database.MaxBatchGetIDs)+" IDs can be requested at once")
+	}
+
+	ids := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		id := strings.ToLower(strings.TrimSpace(part))
+		if id == "" {
+			continue
+		}
+		if !userIDPattern.MatchString(id) {
+			return nil, pkgerrors.NewUserValidationError("INVALID_USER_ID", "Invalid user ID. Must be a UUID")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, pkgerrors.NewUserValidationError("INVALID_IDS_PARAMETER",
+			"Invalid ids parameter. Must be a comma-separated list of user IDs")
+	}
+	return ids, nil
+}
+
+// getUsersByIDs serves GET /users?ids=... by resolving up to database.MaxBatchGetIDs users in one query
+func (h *UserHandler) getUsersByIDs(w http.ResponseWriter, r *http.Request, logger *logging.Logger, startTime time.Time) {
+	ids, err := parseUserIDs(r.URL.Query().Get("ids"))
+	if err != nil {
+		logger.Warn("Invalid ids parameter", "error", err.Error())
+		if userErr, ok := pkgerrors.GetUserError(err); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		}
+		return
+	}
+
+	dateFormat, err := parseDateFormat(r)
+	if err != nil {
+		logger.Warn("Invalid date_format parameter", "error", err.Error())
+		if userErr, ok := pkgerrors.GetUserError(err); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		}
+		return
+	}
+
+	users, err := h.dbService.GetUsersByIDs(r.Context(), h.pool, ids)
+	if err != nil {
+		logger.Error("Failed to retrieve users by IDs from database",
+			logging.FieldError, err,
+			"id_count", len(ids),
+		)
+
+		// SECURITY: Use secure error mapping to prevent information leakage (NFR-S3 compliance)
+		secureErr := errors.MapDatabaseErrorSecure(err)
+		if userErr, ok := secureErr.(*pkgerrors.UserError); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "Database operation failed", "")
+		}
+		return
+	}
+
+	// Report users in the order they were requested
+	byID := make(map[string]int, len(users))
+	for i, user := range users {
+		byID[strings.ToLower(user.ID)] = i
+	}
+	response := GetUsersByIDsResponse{
+		Users:    make([]UserResponse, 0, len(users)),
+		NotFound: []string{},
+	}
+	for _, id := range ids {
+		if i, ok := byID[id]; ok {
+			response.Users = append(response.Users, toUserResponse(users[i], dateFormat))
+		} else {
+			response.NotFound = append(response.NotFound, id)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error("Failed to encode batch user response", logging.FieldError, err)
+	}
+
+	duration := time.Since(startTime)
+	logger.Info("Batch user lookup request completed",
+		"duration_ms", duration.Milliseconds(),
+		"status_code", http.StatusOK,
+		"requested_count", len(ids),
+		"found_count", len(response.Users),
+	)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUsersByIDsMixedFoundAndNotFound(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	found1 := "550e8400-e29b-41d4-a716-446655440001"
+	found2 := "550e8400-e29b-41d4-a716-446655440002"
+	missing := "550e8400-e29b-41d4-a716-446655440099"
+	mockDB := &MockDBService{usersByID: map[string]*models.User{
+		found1: {ID: found1, FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+		found2: {ID: found2, FirstName: "Jane", LastName: "Roe", Age: 25, RecordingDate: 1705314700},
+	}}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	// Uppercase and duplicate IDs resolve to the same user
+	ids := strings.Join([]string{found2, missing, strings.ToUpper(found1), found2}, ",")
+	req := httptest.NewRequest(http.MethodGet, "/users?ids="+ids, nil)
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Users    []models.User `json:"users"`
+		NotFound []string      `json:"not_found"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	require.Len(t, response.Users, 2)
+	assert.Equal(t, found2, response.Users[0].ID)
+	assert.Equal(t, found1, response.Users[1].ID)
+	assert.Equal(t, []string{missing}, response.NotFound)
+}
+
+func TestGetUsersByIDsValidation(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	tooMany := make([]string, database.MaxBatchGetIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("550e8400-e29b-41d4-a716-%012d", i)
+	}
+
+	tests := []struct {
+		name      string
+		ids       string
+		errorCode string
+	}{
+		{"over the cap", strings.Join(tooMany, ","), "TOO_MANY_IDS"},
+		{"invalid uuid", "550e8400-e29b-41d4-a716-446655440001,not-a-uuid", "INVALID_USER_ID"},
+		{"empty list", ",", "INVALID_IDS_PARAMETER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users?ids="+tt.ids, nil)
+			w := httptest.NewRecorder()
+			handler.GetUsers(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.errorCode, response.Code)
+		})
+	}
+}
+
+func TestGetUsersByIDsAcceptsCap(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	ids := make([]string, database.MaxBatchGetIDs)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("550e8400-e29b-41d4-a716-%012d", i)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?ids="+strings.Join(ids, ","), nil)
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response GetUsersByIDsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Users)
+	assert.Len(t, response.NotFound, database.MaxBatchGetIDs)
+}
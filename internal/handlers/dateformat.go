@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/models"
+)
+
+const (
+	// dateFormatUnix renders recording_date as a Unix timestamp (default)
+	dateFormatUnix = "unix"
+	// dateFormatRFC3339 renders recording_date as an RFC3339 string in UTC
+	dateFormatRFC3339 = "rfc3339"
+)
+
+// UserResponse is the response representation of models.User
+// RecordingDate holds the Unix timestamp by default, or an RFC3339 UTC string when date_format=rfc3339
+type UserResponse struct {
+	ID            string      `json:"id"`
+	FirstName     string      `json:"first_name"`
+	LastName      string      `json:"last_name"`
+	Age           int         `json:"age"`
+	RecordingDate interface{} `json:"recording_date"`
+	Email         string      `json:"email,omitempty"` // Omitted for users without an email
+}
+
+// parseDateFormat reads the optional date_format query parameter
+func parseDateFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("date_format"); format {
+	case "", dateFormatUnix:
+		return dateFormatUnix, nil
+	case dateFormatRFC3339:
+		return format, nil
+	default:
+		return "", localizedValidationError(errors.RequestLocale(r), "INVALID_DATE_FORMAT_PARAMETER", "unix, rfc3339")
+	}
+}
+
+// toUserResponse maps a user to its response representation in the requested date format
+func toUserResponse(user models.User, dateFormat string) UserResponse {
+	response := UserResponse{
+		ID:            user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Age:           user.Age,
+		RecordingDate: user.RecordingDate,
+		Email:         user.Email,
+	}
+	if dateFormat == dateFormatRFC3339 {
+		response.RecordingDate = time.Unix(user.RecordingDate, 0).UTC().Format(time.RFC3339)
+	}
+	return response
+}
+
+// toUserResponses maps a page of users to their response representation
+func toUserResponses(users []models.User, dateFormat string) []UserResponse {
+	responses := make([]UserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, toUserResponse(user, dateFormat))
+	}
+	return responses
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToUserResponseDateFormats(t *testing.T) {
+	user := models.User{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600}
+
+	numeric, err := json.Marshal(toUserResponse(user, dateFormatUnix))
+	require.NoError(t, err)
+	assert.Contains(t, string(numeric), `"recording_date":1705314600`)
+
+	rfc3339, err := json.Marshal(toUserResponse(user, dateFormatRFC3339))
+	require.NoError(t, err)
+	assert.Contains(t, string(rfc3339), `"recording_date":"2024-01-15T10:30:00Z"`)
+}
+
+func TestGetUsersDateFormat(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockGetUsersDBService{})
+
+	decodeFirstRecordingDate := func(query string) (int, interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/users"+query, nil)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		var response struct {
+			Users []map[string]interface{} `json:"users"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.NotEmpty(t, response.Users)
+			return w.Code, response.Users[0]["recording_date"]
+		}
+		return w.Code, nil
+	}
+
+	code, recordingDate := decodeFirstRecordingDate("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1705314600), recordingDate, "default must stay a numeric Unix timestamp")
+
+	code, recordingDate = decodeFirstRecordingDate("?date_format=rfc3339")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "2024-01-15T10:30:00Z", recordingDate)
+
+	code, _ = decodeFirstRecordingDate("?date_format=iso")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestGetReportsDateFormat(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600}}
+	dbService.totalCount = 1
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?date_format=rfc3339", nil)
+	w := httptest.NewRecorder()
+	handler.GetReports(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response GetReportsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Users, 1)
+	assert.Equal(t, "2024-01-15T10:30:00Z", response.Users[0].RecordingDate)
+}
+
+func TestGetUserByIDDateFormat(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	mockDB := &MockDBService{usersByID: map[string]*models.User{
+		userID: {ID: userID, FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+	}}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID+query, nil)
+		req.SetPathValue("id", userID)
+		w := httptest.NewRecorder()
+		handler.GetUserByID(w, req)
+		return w
+	}
+
+	numeric := get("")
+	require.Equal(t, http.StatusOK, numeric.Code)
+	var user models.User
+	require.NoError(t, json.Unmarshal(numeric.Body.Bytes(), &user))
+	assert.Equal(t, int64(1705314600), user.RecordingDate)
+
+	rfc3339 := get("?date_format=rfc3339")
+	require.Equal(t, http.StatusOK, rfc3339.Code)
+	var response UserResponse
+	require.NoError(t, json.Unmarshal(rfc3339.Body.Bytes(), &response))
+	assert.Equal(t, "2024-01-15T10:30:00Z", response.RecordingDate)
+	assert.NotEqual(t, numeric.Header().Get("ETag"), rfc3339.Header().Get("ETag"), "each representation needs its own ETag")
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// FeatureOffsetPagination is the offset query parameter of GET /users and GET /reports
+// It is meant to be registered once cursor pagination is available to migrate to.
+const FeatureOffsetPagination = "offset"
+
+// Deprecation describes a deprecated request feature
+type Deprecation struct {
+	Message string    // Sent to clients in the Warning header
+	Sunset  time.Time // When the feature is removed; zero omits the Sunset header
+}
+
+// deprecations is set by SetDeprecations; features missing from it are not deprecated
+var deprecations map[string]Deprecation
+
+// SetDeprecations replaces the registry of deprecated features by name, such as FeatureOffsetPagination
+// It must be called during startup before any requests are served.
+func SetDeprecations(registry map[string]Deprecation) {
+	deprecations = registry
+}
+
+// warnDeprecated marks the response as using feature when it is registered as deprecated:
+// Deprecation: true, a Sunset date (RFC 8594) when one is set, and a 299 Warning carrying the message
+// It must be called before the response header is written and reports whether the feature is deprecated.
+func warnDeprecated(w http.ResponseWriter, feature string) bool {
+	deprecation, ok := deprecations[feature]
+	if !ok {
+		return false
+	}
+
+	header := w.Header()
+	header.Set("Deprecation", "true")
+	if !deprecation.Sunset.IsZero() {
+		header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+	header.Add("Warning", `299 - `+strconv.Quote(deprecation.Message))
+	return true
+}
+
+// warnOffsetDeprecated marks a successful response to a request paging by offset
+// Error responses never carry the headers, so clients only see them for requests that would keep working.
+func warnOffsetDeprecated(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("offset") {
+		warnDeprecated(w, FeatureOffsetPagination)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarnDeprecatedUnregisteredFeature(t *testing.T) {
+	w := httptest.NewRecorder()
+	assert.False(t, warnDeprecated(w, FeatureOffsetPagination))
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Warning"))
+}
+
+func TestOffsetDeprecationHeaders(t *testing.T) {
+	SetDeprecations(map[string]Deprecation{
+		FeatureOffsetPagination: {
+			Message: "offset pagination is deprecated; use cursor",
+			Sunset:  time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	defer SetDeprecations(nil)
+
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	users := NewUserHandler(logger, nil, &MockGetUsersDBService{})
+	reports := setupTestReportHandler()
+
+	endpoints := map[string]http.HandlerFunc{
+		"/users":   users.GetUsers,
+		"/reports": reports.GetReports,
+	}
+	for path, handle := range endpoints {
+		t.Run(path+" with offset", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handle(w, httptest.NewRequest(http.MethodGet, path+"?limit=10&offset=20", nil))
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, "true", w.Header().Get("Deprecation"))
+			assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+			assert.Equal(t, `299 - "offset pagination is deprecated; use cursor"`, w.Header().Get("Warning"))
+		})
+
+		t.Run(path+" without offset", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handle(w, httptest.NewRequest(http.MethodGet, path+"?limit=10", nil))
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Empty(t, w.Header().Get("Deprecation"))
+			assert.Empty(t, w.Header().Get("Sunset"))
+			assert.Empty(t, w.Header().Get("Warning"))
+		})
+	}
+}
+
+func TestOffsetDeprecationHeadersOmittedOnErrors(t *testing.T) {
+	SetDeprecations(map[string]Deprecation{FeatureOffsetPagination: {Message: "offset is deprecated"}})
+	defer SetDeprecations(nil)
+
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	users := NewUserHandler(logger, nil, &MockGetUsersDBService{})
+	reports := setupTestReportHandler()
+
+	endpoints := map[string]http.HandlerFunc{
+		"/users":   users.GetUsers,
+		"/reports": reports.GetReports,
+	}
+	for path, handle := range endpoints {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handle(w, httptest.NewRequest(http.MethodGet, path+"?limit=0&offset=20", nil))
+
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			assert.Empty(t, w.Header().Get("Deprecation"))
+			assert.Empty(t, w.Header().Get("Warning"))
+		})
+	}
+}
+
+func TestDeprecationWithoutSunset(t *testing.T) {
+	SetDeprecations(map[string]Deprecation{FeatureOffsetPagination: {Message: "offset is deprecated"}})
+	defer SetDeprecations(nil)
+
+	w := httptest.NewRecorder()
+	assert.True(t, warnDeprecated(w, FeatureOffsetPagination))
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
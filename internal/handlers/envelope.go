@@ -0,0 +1,26 @@
+package handlers
+
+// envelopeListResponses is set by SetEnvelopeResponses
+var envelopeListResponses bool
+
+// SetEnvelopeResponses selects the {data, meta} envelope for GET /users and GET /reports instead of the flat shape
+// It must be called during startup before any requests are served.
+func SetEnvelopeResponses(enabled bool) {
+	envelopeListResponses = enabled
+}
+
+// listEnvelope wraps a list of users as {"data": [...], "meta": {"pagination": {...}}}
+type listEnvelope struct {
+	Data any      `json:"data"`
+	Meta listMeta `json:"meta"`
+}
+
+// listMeta holds everything about an enveloped list other than its items
+type listMeta struct {
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// newListEnvelope wraps data and its pagination in the list envelope
+func newListEnvelope(data any, pagination PaginationInfo) listEnvelope {
+	return listEnvelope{Data: data, Meta: listMeta{Pagination: pagination}}
+}
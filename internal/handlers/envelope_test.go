@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeTopLevel decodes a JSON response body into its top-level members
+func decodeTopLevel(t *testing.T, w *httptest.ResponseRecorder) map[string]json.RawMessage {
+	t.Helper()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestGetUsersEnvelopeResponses(t *testing.T) {
+	defer SetEnvelopeResponses(false)
+
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	// The mock returns John Doe and Jane Smith out of 150 users
+	handler := NewUserHandler(logger, nil, &MockGetUsersDBService{})
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	t.Run("flat by default", func(t *testing.T) {
+		body := decodeTopLevel(t, get("/users"))
+		assert.ElementsMatch(t, []string{"users", "pagination"}, mapKeys(body))
+	})
+
+	t.Run("enveloped", func(t *testing.T) {
+		SetEnvelopeResponses(true)
+		defer SetEnvelopeResponses(false)
+
+		var response struct {
+			Data []UserResponse `json:"data"`
+			Meta struct {
+				Pagination PaginationInfo `json:"pagination"`
+			} `json:"meta"`
+		}
+		w := get("/users")
+		assert.ElementsMatch(t, []string{"data", "meta"}, mapKeys(decodeTopLevel(t, w)))
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "John", response.Data[0].FirstName)
+		assert.Equal(t, int64(150), response.Meta.Pagination.TotalCount)
+	})
+
+	t.Run("enveloped with field selection", func(t *testing.T) {
+		SetEnvelopeResponses(true)
+		defer SetEnvelopeResponses(false)
+
+		var response struct {
+			Data []map[string]any `json:"data"`
+		}
+		w := get("/users?fields=id,first_name")
+		require.NoError(t, json.Unmarshal(decodeTopLevel(t, w)["data"], &response.Data))
+		require.Len(t, response.Data, 2)
+		assert.ElementsMatch(t, []string{"id", "first_name"}, mapKeys(response.Data[0]))
+	})
+}
+
+func TestGetReportsEnvelopeResponses(t *testing.T) {
+	defer SetEnvelopeResponses(false)
+
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{{ID: "1", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1700000000}}
+	dbService.totalCount = 1
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports", nil))
+		return w
+	}
+
+	body := decodeTopLevel(t, get())
+	assert.ElementsMatch(t, []string{"count", "users", "pagination"}, mapKeys(body))
+
+	SetEnvelopeResponses(true)
+	body = decodeTopLevel(t, get())
+	assert.ElementsMatch(t, []string{"data", "meta"}, mapKeys(body))
+
+	var meta struct {
+		Pagination PaginationInfo `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(body["meta"], &meta))
+	assert.Equal(t, int64(1), meta.Pagination.TotalCount)
+}
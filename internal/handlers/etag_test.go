@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeETag(t *testing.T) {
+	user := models.User{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600}
+
+	first, err := computeETag(user)
+	require.NoError(t, err)
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, first)
+
+	second, err := computeETag(user)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "ETag must be stable for identical content")
+
+	user.Age = 31
+	changed, err := computeETag(user)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changed, "ETag must change when content changes")
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc123"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"empty header", "", false},
+		{"exact match", `W/"abc123"`, true},
+		{"strong form matches weakly", `"abc123"`, true},
+		{"wildcard", "*", true},
+		{"list containing match", `"other", W/"abc123"`, true},
+		{"no match", `W/"zzz"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.ifNoneMatch, etag))
+		})
+	}
+}
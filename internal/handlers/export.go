@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+	"github.com/chybatronik/goUserAPI/internal/types"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultExportWorkers is the number of report exports generated concurrently
+	DefaultExportWorkers = 2
+	// DefaultExportQueueSize bounds how many exports may wait for a worker
+	DefaultExportQueueSize = 16
+	// DefaultExportTTL is how long a finished export can be downloaded
+	DefaultExportTTL = time.Hour
+)
+
+// Export job statuses reported by GET /reports/export/{job_id}
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+// ExportJobResponse describes an export job while it is queued, running or failed
+type ExportJobResponse struct {
+	JobID     string `json:"job_id"`
+	Status    string `json:"status"`
+	RowCount  int    `json:"row_count"`
+	Error     string `json:"error,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// exportJob is one report export tracked by the registry
+type exportJob struct {
+	id         string
+	tenantID   string // tenant the export was requested for; empty unless MULTI_TENANT is set
+	params     types.GetReportsParams
+	dateFormat string
+	status     string
+	rowCount   int
+	filePath   string
+	err        *pkgerrors.UserError
+	expiresAt  time.Time // zero until the job finishes
+}
+
+// ExportManager runs report exports on a bounded worker pool and keeps finished jobs for a TTL
+// Jobs and files are per process, so polling must reach the instance that accepted the export
+type ExportManager struct {
+	pool      *pgxpool.Pool
+	dbService DatabaseService
+	logger    *logging.Logger
+	dir       string
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	jobs      map[string]*exportJob
+	nextSweep time.Time
+
+	queue  chan *exportJob
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewExportManager creates an export manager and starts its workers
+// Files are written to dir, or to the OS temp directory when dir is empty
+func NewExportManager(logger *logging.Logger, pool *pgxpool.Pool, dbService DatabaseService, dir string, workers, queueSize int, ttl time.Duration) *ExportManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &ExportManager{
+		pool:      pool,
+		dbService: dbService,
+		logger:    logger,
+		dir:       dir,
+		ttl:       ttl,
+		now:       time.Now,
+		jobs:      make(map[string]*exportJob),
+		queue:     make(chan *exportJob, queueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	for i := 0; i < workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+	return m
+}
+
+// Stop cancels running exports, waits for the workers to exit and removes all export files
+func (m *ExportManager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, job := range m.jobs {
+		m.removeFile(job)
+		delete(m.jobs, id)
+	}
+}
+
+// enqueue registers a job for tenantID and hands it to the worker pool
+// It returns false when the queue is full
+func (m *ExportManager) enqueue(tenantID string, params types.GetReportsParams, dateFormat string) (*exportJob, bool) {
+	job := &exportJob{
+		id:         newExportJobID(),
+		tenantID:   tenantID,
+		params:     params,
+		dateFormat: dateFormat,
+		status:     ExportStatusPending,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep(m.now())
+
+	select {
+	case m.queue <- job:
+		m.jobs[job.id] = job
+		return job, true
+	default:
+		return nil, false
+	}
+}
+
+// lookup returns a snapshot of the job with the given ID
+// Jobs of other tenants are reported as missing, so job IDs cannot be used to read across tenants
+func (m *ExportManager) lookup(tenantID, id string) (exportJob, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep(m.now())
+
+	job, ok := m.jobs[id]
+	if !ok || job.tenantID != tenantID || (!job.expiresAt.IsZero() && !m.now().Before(job.expiresAt)) {
+		return exportJob{}, false
+	}
+	return *job, true
+}
+
+// worker generates queued exports until the manager is stopped
+func (m *ExportManager) worker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case job := <-m.queue:
+			m.run(job)
+		}
+	}
+}
+
+// run generates one export and records its outcome
+func (m *ExportManager) run(job *exportJob) {
+	m.mu.Lock()
+	job.status = ExportStatusRunning
+	m.mu.Unlock()
+
+	logger := m.logger.WithRequestID(job.id)
+	start := time.Now()
+
+	filePath, rows, err := m.writeCSV(job)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.rowCount = rows
+	job.expiresAt = m.now().Add(m.ttl)
+	if err != nil {
+		logger.Error("Report export failed", logging.FieldError, err, "row_count", rows)
+		job.status = ExportStatusFailed
+		// SECURITY: Use secure error mapping to prevent information leakage (NFR-S3 compliance)
+		if userErr, ok := errors.MapDatabaseErrorSecure(err).(*pkgerrors.UserError); ok {
+			job.err = userErr
+		} else {
+			job.err = &pkgerrors.UserError{Code: "DATABASE_ERROR", Message: "Database operation failed", HTTPStatus: http.StatusInternalServerError}
+		}
+		return
+	}
+
+	job.status = ExportStatusDone
+	job.filePath = filePath
+	logger.Info("Report export completed",
+		"duration_ms", time.Since(start).Milliseconds(),
+		"row_count", rows,
+	)
+}
+
+// writeCSV streams the report into a new temp file
+// Streaming reads every matching row in one query, so exports are not bounded by MAX_OFFSET the way
+// paging through GetReports is. The file is removed again when the export fails.
+func (m *ExportManager) writeCSV(job *exportJob) (path string, rows int, err error) {
+	file, err := os.CreateTemp(m.dir, "report-export-*.csv")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close export file: %w", closeErr)
+		}
+		if err != nil {
+			os.Remove(file.Name())
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(userCSVHeader); err != nil {
+		return "", 0, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	// Jobs outlive the request, so the tenant is carried over from the job rather than the request context
+	ctx := m.ctx
+	if job.tenantID != "" {
+		ctx = tenant.WithID(ctx, job.tenantID)
+	}
+
+	err = m.dbService.StreamReports(ctx, m.pool, job.params, func(user models.User) error {
+		if err := writer.Write(userCSVRecord(toUserResponse(user, job.dateFormat))); err != nil {
+			return fmt.Errorf("failed to write export row: %w", err)
+		}
+		rows++
+		return nil
+	})
+	if err != nil {
+		return "", rows, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", rows, fmt.Errorf("failed to flush export file: %w", err)
+	}
+	return file.Name(), rows, nil
+}
+
+// sweep drops finished jobs past their TTL at most once per TTL; callers must hold m.mu
+func (m *ExportManager) sweep(now time.Time) {
+	if now.Before(m.nextSweep) {
+		return
+	}
+	for id, job := range m.jobs {
+		if !job.expiresAt.IsZero() && !now.Before(job.expiresAt) {
+			m.removeFile(job)
+			delete(m.jobs, id)
+		}
+	}
+	m.nextSweep = now.Add(m.ttl)
+}
+
+// removeFile deletes a job's export file if it has one; callers must hold m.mu
+func (m *ExportManager) removeFile(job *exportJob) {
+	if job.filePath == "" {
+		return
+	}
+	if err := os.Remove(job.filePath); err != nil && !os.IsNotExist(err) {
+		m.logger.Warn("Failed to remove export file", logging.FieldError, err, "job_id", job.id)
+	}
+	job.filePath = ""
+}
+
+// newExportJobID returns an unguessable job ID, since it is the only credential for the download
+func newExportJobID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error since Go 1.24
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SetExportManager enables POST /reports/export and GET /reports/export/{job_id}
+func (h *ReportHandler) SetExportManager(exports *ExportManager) {
+	h.exports = exports
+}
+
+// CreateExport handles POST /reports/export by queueing a CSV export of the filtered report
+// Filters use the same query parameters as GET /reports; limit and offset are ignored
+func (h *ReportHandler) CreateExport(w http.ResponseWriter, r *http.Request) {
+	reqID := h.extractRequestID(r)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
+
+	if h.exports == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "NOT_FOUND", "Report exports are not enabled", "")
+		return
+	}
+
+	params, ok := h.readReportParams(w, r, logger)
+	if !ok {
+		return
+	}
+
+	// Exports always cover every matching row; a zero limit streams them all
+	dbParams := types.GetReportsParams{
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		MinAge:    params.MinAge,
+		MaxAge:    params.MaxAge,
+		SortBy:    params.SortBy,
+		SortOrder: params.SortOrder,
+	}
+
+	tenantID, _ := tenant.FromContext(r.Context())
+	job, ok := h.exports.enqueue(tenantID, dbParams, params.DateFormat)
+	if !ok {
+		logger.Warn("Report export queue is full")
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "EXPORT_QUEUE_FULL",
+			"Too many report exports in progress. Please retry later", "")
+		return
+	}
+
+	logger.Info("Report export queued", "job_id", job.id)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/reports/export/"+job.id)
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(ExportJobResponse{JobID: job.id, Status: job.status}); err != nil {
+		logger.Error("Failed to encode export job response", logging.FieldError, err)
+	}
+}
+
+// GetExport handles GET /reports/export/{job_id}
+// It reports the job status until the export is done, then returns the CSV file
+func (h *ReportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	reqID := h.extractRequestID(r)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
+
+	if h.exports == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "NOT_FOUND", "Report exports are not enabled", "")
+		return
+	}
+
+	id := r.PathValue("job_id")
+	tenantID, _ := tenant.FromContext(r.Context())
+	job, ok := h.exports.lookup(tenantID, id)
+	if !ok {
+		logger.Info("Report export not found", "job_id", id)
+		h.writeErrorResponse(w, http.StatusNotFound, "EXPORT_NOT_FOUND", "Report export not found or expired", "")
+		return
+	}
+
+	if job.status == ExportStatusDone {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="report-`+job.id+`.csv"`)
+		http.ServeFile(w, r, job.filePath)
+		return
+	}
+
+	response := ExportJobResponse{JobID: job.id, Status: job.status, RowCount: job.rowCount}
+	if job.err != nil {
+		response.Error = job.err.Message
+		response.ErrorCode = job.err.Code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.Error("Failed to encode export job response", logging.FieldError, err)
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagingReportsDB serves reports from a fixed user list, honouring limit and offset
+// Like the database layer, GetReports rejects offsets above maxOffset when it is set.
+type pagingReportsDB struct {
+	MockDatabaseService
+	calls     chan types.GetReportsParams
+	maxOffset int
+}
+
+func (m *pagingReportsDB) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
+	m.calls <- params
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	if m.maxOffset > 0 && params.Offset > m.maxOffset {
+		return nil, 0, fmt.Errorf("parameter validation failed: invalid offset: %d (must not exceed %d)", params.Offset, m.maxOffset)
+	}
+	start := min(params.Offset, len(m.users))
+	end := min(start+params.Limit, len(m.users))
+	return m.users[start:end], int64(len(m.users)), nil
+}
+
+// StreamReports hands every user after offset to fn, at most limit of them unless limit is 0
+func (m *pagingReportsDB) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	m.calls <- params
+	if m.err != nil {
+		return m.err
+	}
+	users := m.users[min(params.Offset, len(m.users)):]
+	if params.Limit > 0 {
+		users = users[:min(params.Limit, len(users))]
+	}
+	for _, user := range users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupExportHandler wires a report handler to an export manager writing into a test directory
+func setupExportHandler(t *testing.T, db *pagingReportsDB) *ReportHandler {
+	t.Helper()
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewReportHandler(logger, nil, db)
+	handler.SetPageSize(validation.PageSize{Default: 2, Max: 2})
+
+	exports := NewExportManager(logger, nil, db, t.TempDir(), 1, 4, time.Hour)
+	t.Cleanup(exports.Stop)
+	handler.SetExportManager(exports)
+	return handler
+}
+
+// pollExport polls GET /reports/export/{job_id} until the job leaves the pending and running states
+func pollExport(t *testing.T, handler *ReportHandler, jobID string) *httptest.ResponseRecorder {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/export/"+jobID, nil)
+		req.SetPathValue("job_id", jobID)
+		w := httptest.NewRecorder()
+		handler.GetExport(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			return w
+		}
+		var status ExportJobResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		if status.Status != ExportStatusPending && status.Status != ExportStatusRunning {
+			return w
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("export did not finish in time")
+	return nil
+}
+
+func TestReportExportEnqueuePollDownload(t *testing.T) {
+	db := &pagingReportsDB{
+		MockDatabaseService: MockDatabaseService{users: []models.User{
+			{ID: "550e8400-e29b-41d4-a716-446655440001", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+			{ID: "550e8400-e29b-41d4-a716-446655440002", FirstName: "Jane", LastName: "Roe, Jr.", Age: 25, RecordingDate: 1705314700, Email: "jane.roe@example.com"},
+			{ID: "550e8400-e29b-41d4-a716-446655440003", FirstName: "Max", LastName: "Mustermann", Age: 40, RecordingDate: 1705314800},
+		}},
+		calls: make(chan types.GetReportsParams, 10),
+	}
+	handler := setupExportHandler(t, db)
+
+	req := httptest.NewRequest(http.MethodPost, "/reports/export?min_age=18&date_format=rfc3339&limit=1&offset=5", nil)
+	w := httptest.NewRecorder()
+	handler.CreateExport(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var created ExportJobResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.JobID)
+	assert.Equal(t, ExportStatusPending, created.Status)
+	assert.Equal(t, "/reports/export/"+created.JobID, w.Header().Get("Location"))
+
+	download := pollExport(t, handler, created.JobID)
+	assert.Equal(t, "text/csv; charset=utf-8", download.Header().Get("Content-Type"))
+	assert.Contains(t, download.Header().Get("Content-Disposition"), created.JobID)
+
+	records, err := csv.NewReader(download.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"id", "first_name", "last_name", "age", "recording_date", "email"}, records[0])
+	assert.Equal(t, []string{"550e8400-e29b-41d4-a716-446655440002", "Jane", "Roe, Jr.", "25", "2024-01-15T10:31:40Z", "jane.roe@example.com"}, records[2])
+	assert.Equal(t, "", records[1][5], "a user without an email exports an empty column")
+
+	// The request's limit and offset are ignored; the worker streams every matching row in one read
+	first := <-db.calls
+	assert.Equal(t, 0, first.Limit)
+	assert.Equal(t, 0, first.Offset)
+	require.NotNil(t, first.MinAge)
+	assert.Equal(t, 18, *first.MinAge)
+	assert.Empty(t, db.calls, "a single stream covers the whole export")
+}
+
+func TestReportExportBeyondMaxOffset(t *testing.T) {
+	// More rows than MaxOffset+Limit, which paging through GetReports could never reach
+	pageSize := validation.PageSize{Default: 2, Max: 2, MaxOffset: 10}
+	users := make([]models.User, pageSize.MaxOffset+pageSize.Max+5)
+	for i := range users {
+		users[i] = models.User{ID: fmt.Sprintf("550e8400-e29b-41d4-a716-%012d", i), FirstName: "User", LastName: "Export", Age: 30, RecordingDate: 1705314600}
+	}
+	db := &pagingReportsDB{
+		MockDatabaseService: MockDatabaseService{users: users},
+		calls:               make(chan types.GetReportsParams, 100),
+		maxOffset:           pageSize.MaxOffset,
+	}
+	handler := setupExportHandler(t, db)
+	handler.SetPageSize(pageSize)
+
+	w := httptest.NewRecorder()
+	handler.CreateExport(w, httptest.NewRequest(http.MethodPost, "/reports/export", nil))
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var created ExportJobResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	download := pollExport(t, handler, created.JobID)
+	require.Equal(t, "text/csv; charset=utf-8", download.Header().Get("Content-Type"), download.Body.String())
+
+	records, err := csv.NewReader(download.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, len(users)+1, "header plus every matching row")
+	assert.Equal(t, users[len(users)-1].ID, records[len(records)-1][0])
+}
+
+func TestReportExportFailureIsReportedSecurely(t *testing.T) {
+	db := &pagingReportsDB{
+		MockDatabaseService: MockDatabaseService{err: errors.New("connection refused to 10.0.0.5:5432")},
+		calls:               make(chan types.GetReportsParams, 10),
+	}
+	handler := setupExportHandler(t, db)
+
+	w := httptest.NewRecorder()
+	handler.CreateExport(w, httptest.NewRequest(http.MethodPost, "/reports/export", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var created ExportJobResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	result := pollExport(t, handler, created.JobID)
+	var status ExportJobResponse
+	require.NoError(t, json.Unmarshal(result.Body.Bytes(), &status))
+	assert.Equal(t, ExportStatusFailed, status.Status)
+	assert.NotEmpty(t, status.ErrorCode)
+	assert.NotContains(t, status.Error, "10.0.0.5")
+
+	// The partial export file is removed
+	entries, err := os.ReadDir(handler.exports.dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReportExportUnknownJob(t *testing.T) {
+	handler := setupExportHandler(t, &pagingReportsDB{calls: make(chan types.GetReportsParams, 1)})
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/export/deadbeef", nil)
+	req.SetPathValue("job_id", "deadbeef")
+	w := httptest.NewRecorder()
+	handler.GetExport(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "EXPORT_NOT_FOUND", response.Code)
+}
+
+func TestReportExportExpiresAfterTTL(t *testing.T) {
+	db := &pagingReportsDB{calls: make(chan types.GetReportsParams, 10)}
+	handler := setupExportHandler(t, db)
+
+	now := time.Now()
+	handler.exports.mu.Lock()
+	handler.exports.now = func() time.Time { return now }
+	handler.exports.mu.Unlock()
+
+	w := httptest.NewRecorder()
+	handler.CreateExport(w, httptest.NewRequest(http.MethodPost, "/reports/export", nil))
+	var created ExportJobResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	pollExport(t, handler, created.JobID)
+
+	handler.exports.mu.Lock()
+	filePath := handler.exports.jobs[created.JobID].filePath
+	handler.exports.now = func() time.Time { return now.Add(2 * time.Hour) }
+	handler.exports.mu.Unlock()
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/export/"+created.JobID, nil)
+	req.SetPathValue("job_id", created.JobID)
+	w = httptest.NewRecorder()
+	handler.GetExport(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	_, err := os.Stat(filePath)
+	assert.True(t, os.IsNotExist(err), "expired export file should be removed")
+}
+
+// tenantReportsDB records the tenant each StreamReports call runs for
+type tenantReportsDB struct {
+	pagingReportsDB
+	tenants chan string
+}
+
+func (m *tenantReportsDB) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	id, _ := tenant.FromContext(ctx)
+	m.tenants <- id
+	return m.pagingReportsDB.StreamReports(ctx, pool, params, fn)
+}
+
+func TestReportExportIsScopedToTenant(t *testing.T) {
+	db := &tenantReportsDB{
+		pagingReportsDB: pagingReportsDB{calls: make(chan types.GetReportsParams, 10)},
+		tenants:         make(chan string, 10),
+	}
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewReportHandler(logger, nil, db)
+	exports := NewExportManager(logger, nil, db, t.TempDir(), 1, 4, time.Hour)
+	t.Cleanup(exports.Stop)
+	handler.SetExportManager(exports)
+
+	req := httptest.NewRequest(http.MethodPost, "/reports/export", nil)
+	w := httptest.NewRecorder()
+	handler.CreateExport(w, req.WithContext(tenant.WithID(req.Context(), "acme")))
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var created ExportJobResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	// The worker queries on behalf of the tenant that requested the export
+	assert.Equal(t, "acme", <-db.tenants)
+
+	for _, other := range []string{"globex", ""} {
+		req := httptest.NewRequest(http.MethodGet, "/reports/export/"+created.JobID, nil)
+		if other != "" {
+			req = req.WithContext(tenant.WithID(req.Context(), other))
+		}
+		req.SetPathValue("job_id", created.JobID)
+		w := httptest.NewRecorder()
+		handler.GetExport(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, "tenant %q must not see another tenant's export", other)
+	}
+
+	_, ok := exports.lookup("acme", created.JobID)
+	assert.True(t, ok)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Response field cases accepted by SetResponseFieldCase
+const (
+	// FieldCaseSnake keeps the model's snake_case JSON keys (default)
+	FieldCaseSnake = "snake"
+	// FieldCaseCamel renders user keys in camelCase, e.g. firstName and recordingDate
+	FieldCaseCamel = "camel"
+)
+
+// camelCaseUserFields is set by SetResponseFieldCase
+var camelCaseUserFields bool
+
+// SetResponseFieldCase selects the key case used for users in JSON responses
+// Only user objects are affected; envelopes such as pagination and errors keep their keys.
+// It must be called during startup before any requests are served.
+func SetResponseFieldCase(fieldCase string) {
+	camelCaseUserFields = fieldCase == FieldCaseCamel
+}
+
+// camelUserResponse has the fields of UserResponse with camelCase keys
+type camelUserResponse struct {
+	ID            string      `json:"id"`
+	FirstName     string      `json:"firstName"`
+	LastName      string      `json:"lastName"`
+	Age           int         `json:"age"`
+	RecordingDate interface{} `json:"recordingDate"`
+	Email         string      `json:"email,omitempty"`
+}
+
+// MarshalJSON renders the user with the keys selected by SetResponseFieldCase
+func (u UserResponse) MarshalJSON() ([]byte, error) {
+	if camelCaseUserFields {
+		return json.Marshal(camelUserResponse(u))
+	}
+	// The local type drops this method so the struct tags apply
+	type snakeUserResponse UserResponse
+	return json.Marshal(snakeUserResponse(u))
+}
+
+// userJSONKey returns the response key for a user field named by its JSON tag
+func userJSONKey(field string) string {
+	if !camelCaseUserFields {
+		return field
+	}
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
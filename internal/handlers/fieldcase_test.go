@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserResponseFieldCase(t *testing.T) {
+	defer SetResponseFieldCase(FieldCaseSnake)
+
+	user := models.User{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1700000000}
+
+	tests := []struct {
+		fieldCase string
+		keys      []string
+	}{
+		{FieldCaseSnake, []string{"id", "first_name", "last_name", "age", "recording_date"}},
+		{FieldCaseCamel, []string{"id", "firstName", "lastName", "age", "recordingDate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldCase, func(t *testing.T) {
+			SetResponseFieldCase(tt.fieldCase)
+
+			data, err := json.Marshal(toUserResponse(user, dateFormatUnix))
+			require.NoError(t, err)
+
+			var decoded map[string]any
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.ElementsMatch(t, tt.keys, mapKeys(decoded))
+			assert.Equal(t, "John", decoded[tt.keys[1]])
+			assert.Equal(t, float64(1700000000), decoded[tt.keys[4]])
+
+			selected := selectUserFields(toUserResponse(user, dateFormatUnix), []string{"first_name", "recording_date"})
+			assert.ElementsMatch(t, []string{tt.keys[1], tt.keys[4]}, mapKeys(selected))
+		})
+	}
+}
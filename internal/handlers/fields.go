@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+)
+
+// userFieldNames are the fields accepted by ?fields=, taken from the models.User JSON tags in declaration order
+var userFieldNames = jsonFieldNames(reflect.TypeOf(models.User{}))
+
+// jsonFieldNames returns the JSON names of a struct type's exported fields
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseFields reads the optional fields query parameter as a comma-separated list of user fields
+// It returns nil when the parameter is absent, meaning every field is returned. Repeated names are
+// ignored; unknown or empty names are rejected with INVALID_FIELD_SELECTION.
+func parseFields(r *http.Request, locale string) ([]string, error) {
+	if !r.URL.Query().Has("fields") {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool, len(userFieldNames))
+	for _, field := range strings.Split(r.URL.Query().Get("fields"), ",") {
+		field = strings.TrimSpace(field)
+		if !isUserField(field) {
+			return nil, localizedValidationError(locale, "INVALID_FIELD_SELECTION", strings.Join(userFieldNames, ", "))
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// isUserField reports whether name is one of userFieldNames
+func isUserField(name string) bool {
+	for _, field := range userFieldNames {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectUserFields returns only the named fields of a user response, keyed in the configured field case
+func selectUserFields(user UserResponse, fields []string) map[string]any {
+	selected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		key := userJSONKey(field)
+		switch field {
+		case "id":
+			selected[key] = user.ID
+		case "first_name":
+			selected[key] = user.FirstName
+		case "last_name":
+			selected[key] = user.LastName
+		case "age":
+			selected[key] = user.Age
+		case "recording_date":
+			selected[key] = user.RecordingDate
+		case "email":
+			if user.Email != "" {
+				selected[key] = user.Email
+			}
+		}
+	}
+	return selected
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/types"
 )
 
 // HealthCheckResponse represents the structured health check response format
@@ -23,11 +24,7 @@ type HealthCheckResponse struct {
 }
 
 // HealthCheck represents individual health check result with timing
-type HealthCheck struct {
-	Status         string `json:"status"`           // healthy|unhealthy
-	ResponseTimeMs int64  `json:"response_time_ms"` // Response time in ms
-	Error          string `json:"error,omitempty"`  // Only present if unhealthy
-}
+type HealthCheck = types.ComponentHealth
 
 // HealthChecker interface for health check components
 type HealthChecker interface {
@@ -43,6 +40,9 @@ type HealthHandler struct {
 	service   string
 	mu        sync.RWMutex
 	logger    *logging.Logger
+
+	// timeout is the budget shared by all checkers of one request (0 disables)
+	timeout time.Duration
 }
 
 // NewHealthHandler creates a new health handler
@@ -63,6 +63,47 @@ func (h *HealthHandler) AddChecker(checker HealthChecker) {
 	h.checkers = append(h.checkers, checker)
 }
 
+// SetTimeout bounds the total time spent running checkers per request; 0 disables the budget
+func (h *HealthHandler) SetTimeout(timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.timeout = timeout
+}
+
+// healthCheckSkippedError is reported for checks that did not finish within the timeout budget
+const healthCheckSkippedError = "skipped due to timeout budget"
+
+// runHealthChecks runs checkers in order under one shared deadline of budget (0 disables it)
+// Checkers receive the deadline through ctx; one that ignores it is abandoned when the budget runs out,
+// so the caller always returns in time. The check in progress and all remaining ones are then reported
+// unhealthy with healthCheckSkippedError.
+func runHealthChecks(ctx context.Context, checkers []HealthChecker, budget time.Duration) map[string]HealthCheck {
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	results := make(map[string]HealthCheck, len(checkers))
+	for i, checker := range checkers {
+		done := make(chan HealthCheck, 1)
+		go func(checker HealthChecker) {
+			done <- checker.CheckHealth(ctx)
+		}(checker)
+
+		select {
+		case check := <-done:
+			results[checker.Name()] = check
+		case <-ctx.Done():
+			for _, skipped := range checkers[i:] {
+				results[skipped.Name()] = HealthCheck{Status: "unhealthy", Error: healthCheckSkippedError}
+			}
+			return results
+		}
+	}
+	return results
+}
+
 // ServeHTTP handles health check requests with proper format and performance tracking
 func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
@@ -93,11 +134,12 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.mu.RLock()
 	checkers := make([]HealthChecker, len(h.checkers))
 	copy(checkers, h.checkers)
+	timeout := h.timeout
 	h.mu.RUnlock()
 
+	response.Checks = runHealthChecks(ctx, checkers, timeout)
 	for _, checker := range checkers {
-		healthCheck := checker.CheckHealth(ctx)
-		response.Checks[checker.Name()] = healthCheck
+		healthCheck := response.Checks[checker.Name()]
 
 		if healthCheck.Status != "healthy" {
 			allHealthy = false
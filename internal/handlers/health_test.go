@@ -197,6 +197,35 @@ func TestHealthHandlerUnhealthy(t *testing.T) {
 	}
 }
 
+func TestHealthHandlerTimeoutBudget(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "test-service", "1.0.0")
+	handler := NewHealthHandler("goUserAPI", "1.0.0", logger)
+	handler.SetTimeout(50 * time.Millisecond)
+	handler.AddChecker(&MockHealthChecker{name: "slow-check", delay: 300 * time.Millisecond})
+	handler.AddChecker(&MockHealthChecker{name: "next-check"})
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Errorf("Expected response within the timeout budget, took %v", elapsed)
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	var response HealthCheckResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	for _, name := range []string{"slow-check", "next-check"} {
+		if check := response.Checks[name]; check.Error != healthCheckSkippedError {
+			t.Errorf("Expected %s to be skipped, got %+v", name, check)
+		}
+	}
+}
+
 func TestNewDatabaseHealthChecker(t *testing.T) {
 	logger := logging.NewStructuredLogger("info", "test-service", "1.0.0")
 	mockDB := &MockHealthCheckerDatabase{}
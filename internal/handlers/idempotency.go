@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients safely retry POST /users
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyReplayedHeader marks a response replayed from an earlier request with the same key
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength bounds client-supplied keys kept in memory
+	maxIdempotencyKeyLength = 255
+	// defaultIdempotencyTTL is how long a key is remembered after the original request
+	defaultIdempotencyTTL = time.Hour
+)
+
+// idempotencyOutcome describes how a request relates to earlier uses of its key
+type idempotencyOutcome int
+
+const (
+	// idempotencyNew means the key is unused and now reserved for this request
+	idempotencyNew idempotencyOutcome = iota
+	// idempotencyReplay means the same key and body already created a user
+	idempotencyReplay
+	// idempotencyConflict means the key was already used with a different body
+	idempotencyConflict
+	// idempotencyInProgress means the original request with this key has not finished yet
+	idempotencyInProgress
+)
+
+// idempotencyScope identifies a stored key; keys are scoped by tenant so two tenants
+// sending the same Idempotency-Key never see each other's users
+type idempotencyScope struct {
+	tenantID string // empty when MULTI_TENANT is disabled
+	key      string
+}
+
+// newIdempotencyScope scopes key to the tenant carried by ctx, if any
+func newIdempotencyScope(ctx context.Context, key string) idempotencyScope {
+	tenantID, _ := tenant.FromContext(ctx)
+	return idempotencyScope{tenantID: tenantID, key: key}
+}
+
+// idempotencyEntry remembers the outcome of a create request for one key
+type idempotencyEntry struct {
+	bodyHash  string
+	user      *models.User // nil while the original request is still in flight
+	expiresAt time.Time
+}
+
+// idempotencyStore is an in-memory, TTL-bound map of idempotency keys to created users
+// Entries are per process, so retries must reach the same instance to be deduplicated
+type idempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[idempotencyScope]*idempotencyEntry
+	ttl       time.Duration
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+// newIdempotencyStore creates a store remembering keys for ttl
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		entries: make(map[idempotencyScope]*idempotencyEntry),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// begin looks up key and reserves it when unused
+// For a replay the originally created user is returned
+func (s *idempotencyStore) begin(key idempotencyScope, bodyHash string) (*models.User, idempotencyOutcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		switch {
+		case entry.bodyHash != bodyHash:
+			return nil, idempotencyConflict
+		case entry.user == nil:
+			return nil, idempotencyInProgress
+		default:
+			replayed := *entry.user
+			return &replayed, idempotencyReplay
+		}
+	}
+
+	s.entries[key] = &idempotencyEntry{bodyHash: bodyHash, expiresAt: now.Add(s.ttl)}
+	return nil, idempotencyNew
+}
+
+// complete records the user created for a reserved key
+func (s *idempotencyStore) complete(key idempotencyScope, user *models.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok {
+		stored := *user
+		entry.user = &stored
+		entry.expiresAt = s.now().Add(s.ttl)
+	}
+}
+
+// release forgets a reserved key so a failed request can be retried with it
+func (s *idempotencyStore) release(key idempotencyScope) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && entry.user == nil {
+		delete(s.entries, key)
+	}
+}
+
+// sweep drops expired entries at most once per TTL; callers must hold s.mu
+func (s *idempotencyStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.nextSweep = now.Add(s.ttl)
+}
+
+// hashCreateUserRequest fingerprints a decoded request body so formatting differences do not count as a different body
+func hashCreateUserRequest(req *CreateUserRequest) string {
+	// Marshalling a struct of strings and ints cannot fail
+	data, _ := json.Marshal(req)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotentCreateRequest(t *testing.T, key string, body map[string]interface{}) *http.Request {
+	t.Helper()
+	bodyBytes, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestCreateUserIdempotentReplay(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	body := map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30}
+
+	first := httptest.NewRecorder()
+	handler.CreateUser(first, newIdempotentCreateRequest(t, "retry-123", body))
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	second := httptest.NewRecorder()
+	handler.CreateUser(second, newIdempotentCreateRequest(t, "retry-123", body))
+	require.Equal(t, http.StatusCreated, second.Code)
+
+	assert.Len(t, mockDB.createdUsers, 1, "replayed request must not insert again")
+	assert.Equal(t, "true", second.Header().Get(IdempotencyReplayedHeader))
+	assert.Empty(t, first.Header().Get(IdempotencyReplayedHeader))
+	assert.JSONEq(t, first.Body.String(), second.Body.String(), "replay must return the original response")
+}
+
+func TestCreateUserIdempotencyKeyReused(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	first := httptest.NewRecorder()
+	handler.CreateUser(first, newIdempotentCreateRequest(t, "retry-456",
+		map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30}))
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	second := httptest.NewRecorder()
+	handler.CreateUser(second, newIdempotentCreateRequest(t, "retry-456",
+		map[string]interface{}{"first_name": "Jane", "last_name": "Doe", "age": 31}))
+
+	assert.Equal(t, http.StatusConflict, second.Code)
+	var errorResp ErrorResponse
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &errorResp))
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", errorResp.Code)
+	assert.Len(t, mockDB.createdUsers, 1)
+}
+
+func TestCreateUserIdempotencyKeyReleasedOnFailure(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{shouldFailCreate: true}
+	handler := NewUserHandler(logger, nil, mockDB)
+	body := map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30}
+
+	failed := httptest.NewRecorder()
+	handler.CreateUser(failed, newIdempotentCreateRequest(t, "retry-789", body))
+	require.NotEqual(t, http.StatusCreated, failed.Code)
+
+	mockDB.shouldFailCreate = false
+	retried := httptest.NewRecorder()
+	handler.CreateUser(retried, newIdempotentCreateRequest(t, "retry-789", body))
+
+	assert.Equal(t, http.StatusCreated, retried.Code)
+	assert.Len(t, mockDB.createdUsers, 1)
+}
+
+func TestCreateUserIdempotencyKeyScopedByTenant(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	body := map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30}
+
+	requestFor := func(tenantID string) *http.Request {
+		req := newIdempotentCreateRequest(t, "shared-key", body)
+		return req.WithContext(tenant.WithID(req.Context(), tenantID))
+	}
+
+	first := httptest.NewRecorder()
+	handler.CreateUser(first, requestFor("tenant-a"))
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	second := httptest.NewRecorder()
+	handler.CreateUser(second, requestFor("tenant-b"))
+	require.Equal(t, http.StatusCreated, second.Code)
+
+	assert.Empty(t, second.Header().Get(IdempotencyReplayedHeader), "another tenant's key must not replay")
+	assert.Len(t, mockDB.createdUsers, 2, "each tenant must get its own user")
+}
+
+func TestIdempotencyStoreExpiry(t *testing.T) {
+	now := time.Unix(1705314600, 0)
+	store := newIdempotencyStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	key := idempotencyScope{key: "key"}
+
+	_, outcome := store.begin(key, "hash-a")
+	require.Equal(t, idempotencyNew, outcome)
+
+	_, outcome = store.begin(key, "hash-a")
+	assert.Equal(t, idempotencyInProgress, outcome)
+
+	store.complete(key, &models.User{ID: "550e8400-e29b-41d4-a716-446655440000"})
+	user, outcome := store.begin(key, "hash-a")
+	require.Equal(t, idempotencyReplay, outcome)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", user.ID)
+
+	// After the TTL the key may be used again, even with a different body
+	now = now.Add(2 * time.Minute)
+	_, outcome = store.begin(key, "hash-b")
+	assert.Equal(t, idempotencyNew, outcome)
+}
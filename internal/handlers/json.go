@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// prettyJSONIndent is the indentation used for ?pretty=true responses
+const prettyJSONIndent = "  "
+
+// wantsPrettyJSON reports whether the client asked for indented JSON and it is allowed in this environment
+func wantsPrettyJSON(r *http.Request, allowed bool) bool {
+	return allowed && r.URL.Query().Get("pretty") == "true"
+}
+
+// writeJSON encodes v with the given status, indenting it when pretty is set
+// v is marshalled before anything is written, so on error the caller can still send an error response
+func writeJSON(w http.ResponseWriter, status int, v any, pretty bool) error {
+	var (
+		body []byte
+		err  error
+	)
+	if pretty {
+		body, err = json.MarshalIndent(v, "", prettyJSONIndent)
+	} else {
+		body, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(body, '\n'))
+	return err
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSON(t *testing.T) {
+	payload := map[string]int{"count": 1}
+
+	compact := httptest.NewRecorder()
+	require.NoError(t, writeJSON(compact, http.StatusCreated, payload, false))
+	assert.Equal(t, http.StatusCreated, compact.Code)
+	assert.Equal(t, "application/json", compact.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"count\":1}\n", compact.Body.String())
+
+	pretty := httptest.NewRecorder()
+	require.NoError(t, writeJSON(pretty, http.StatusOK, payload, true))
+	assert.Equal(t, "{\n  \"count\": 1\n}\n", pretty.Body.String())
+
+	// Unencodable values fail before anything is written
+	failed := httptest.NewRecorder()
+	assert.Error(t, writeJSON(failed, http.StatusOK, map[string]any{"bad": func() {}}, false))
+	assert.Empty(t, failed.Body.String())
+	assert.Empty(t, failed.Header().Get("Content-Type"))
+}
+
+func TestGetUsersPrettyJSON(t *testing.T) {
+	mockDB := &MockGetUsersDBService{
+		mockUsers:      []models.User{{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600}},
+		mockTotalCount: 1,
+	}
+
+	tests := []struct {
+		name       string
+		allowed    bool // true outside production
+		query      string
+		wantPretty bool
+	}{
+		{"development honours pretty", true, "?pretty=true", true},
+		{"development defaults to compact", true, "", false},
+		{"production ignores pretty", false, "?pretty=true", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, mockDB)
+			handler.SetPrettyJSON(tt.allowed)
+
+			req := httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handler.GetUsers(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantPretty, strings.Contains(w.Body.String(), "\n  \"users\": ["))
+
+			// Both forms carry the same document
+			var compacted bytes.Buffer
+			require.NoError(t, json.Compact(&compacted, w.Body.Bytes()))
+			if !tt.wantPretty {
+				assert.Equal(t, compacted.String()+"\n", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetReportsPrettyJSON(t *testing.T) {
+	handler := setupTestReportHandler()
+	handler.SetPrettyJSON(true)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?pretty=true", nil)
+	w := httptest.NewRecorder()
+	handler.GetReports(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "\n  \"count\": 0")
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// setPaginationLinks sets an RFC 5988 Link header with first, prev, next and last page links
+// Each link is the request path with its query unchanged apart from offset, so filters, sorting
+// and limit carry over. prev is omitted on the first page and next on the last one.
+func setPaginationLinks(w http.ResponseWriter, requestURL *url.URL, totalCount int64, limit, offset int) {
+	if limit <= 0 {
+		return
+	}
+
+	lastOffset := 0
+	if totalCount > 0 {
+		lastOffset = int((totalCount - 1) / int64(limit) * int64(limit))
+	}
+
+	links := []string{paginationLink(requestURL, 0, "first")}
+	if offset > 0 {
+		links = append(links, paginationLink(requestURL, max(offset-limit, 0), "prev"))
+	}
+	if int64(offset)+int64(limit) < totalCount {
+		links = append(links, paginationLink(requestURL, offset+limit, "next"))
+	}
+	links = append(links, paginationLink(requestURL, lastOffset, "last"))
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+// setTotalCount sets X-Total-Count to the number of rows matching the request across all pages
+// It is sent for every list format, so a HEAD request can learn the size without fetching a body.
+func setTotalCount(w http.ResponseWriter, totalCount int64) {
+	w.Header().Set("X-Total-Count", strconv.FormatInt(totalCount, 10))
+}
+
+// paginationLink formats one Link header entry pointing at the given offset
+func paginationLink(requestURL *url.URL, offset int, rel string) string {
+	query := requestURL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/models"
+)
+
+// linkRels parses a Link header into a map of rel to target
+func linkRels(t *testing.T, header string) map[string]string {
+	t.Helper()
+	rels := make(map[string]string)
+	for _, entry := range strings.Split(header, ", ") {
+		target, rel, ok := strings.Cut(entry, "; rel=")
+		if !ok {
+			t.Fatalf("Malformed Link entry %q", entry)
+		}
+		rels[strings.Trim(rel, `"`)] = strings.Trim(target, "<>")
+	}
+	return rels
+}
+
+func TestGetReports_PaginationLinks(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{{ID: "1", FirstName: "John", LastName: "Doe", Age: 30}}
+	dbService.totalCount = 45
+
+	w := httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports?min_age=18&limit=20&offset=20", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	rels := linkRels(t, w.Header().Get("Link"))
+
+	want := map[string]string{
+		"first": "/reports?limit=20&min_age=18&offset=0",
+		"prev":  "/reports?limit=20&min_age=18&offset=0",
+		"next":  "/reports?limit=20&min_age=18&offset=40",
+		"last":  "/reports?limit=20&min_age=18&offset=40",
+	}
+	for rel, target := range want {
+		if rels[rel] != target {
+			t.Errorf("Expected rel=%q link %q, got %q", rel, target, rels[rel])
+		}
+	}
+}
+
+func TestGetReports_TotalCountMatchesPagination(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{{ID: "1", FirstName: "John", LastName: "Doe", Age: 30}}
+	dbService.totalCount = 45
+
+	w := httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports?min_age=18&limit=20&offset=20", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var response GetReportsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := strconv.FormatInt(response.Pagination.TotalCount, 10)
+	if got := w.Header().Get("X-Total-Count"); got != want {
+		t.Errorf("Expected X-Total-Count %q to match pagination total, got %q", want, got)
+	}
+}
+
+func TestSetPaginationLinks_LastPageHasNoNext(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users?limit=20&offset=40&sort_by=age", nil)
+
+	setPaginationLinks(w, req.URL, 45, 20, 40)
+
+	rels := linkRels(t, w.Header().Get("Link"))
+	if _, ok := rels["next"]; ok {
+		t.Errorf("Expected no next link on the last page, got %q", rels["next"])
+	}
+	if rels["prev"] != "/users?limit=20&offset=20&sort_by=age" {
+		t.Errorf("Unexpected prev link %q", rels["prev"])
+	}
+	if rels["last"] != "/users?limit=20&offset=40&sort_by=age" {
+		t.Errorf("Unexpected last link %q", rels["last"])
+	}
+}
+
+func TestSetPaginationLinks_FirstPageHasNoPrev(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	setPaginationLinks(w, req.URL, 0, 20, 0)
+
+	rels := linkRels(t, w.Header().Get("Link"))
+	if len(rels) != 2 || rels["first"] != "/users?offset=0" || rels["last"] != "/users?offset=0" {
+		t.Errorf("Expected only first and last links to offset 0, got %v", rels)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Methods served by each route, shared by the router and the handlers so every 405 advertises the same Allow set
+var (
+	// UsersMethods are served by the /users collection
+	UsersMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut}
+	// UserMethods are served by /users/{id}; PUT, PATCH and DELETE belong here once they have handlers
+	UserMethods = []string{http.MethodGet, http.MethodHead}
+	// ReportsMethods are served by /reports
+	ReportsMethods = []string{http.MethodGet, http.MethodHead}
+)
+
+// MethodNotAllowed writes the standard 405 response, advertising the allowed methods in the Allow header
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed []string) {
+	supported := strings.Join(allowed, ", ")
+	w.Header().Set("Allow", supported)
+
+	errorResp := ErrorResponse{
+		Error:   "Method not allowed",
+		Code:    "METHOD_NOT_ALLOWED",
+		Details: fmt.Sprintf("Method %s is not allowed. Supported methods: %s", r.Method, supported),
+	}
+	if err := writeJSON(w, http.StatusMethodNotAllowed, errorResp, false); err != nil {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// isReadMethod reports whether r is a GET or a HEAD, which GET handlers also serve
+func isReadMethod(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead
+}
+
+// withoutBodyForHead lets a GET handler serve HEAD on the same code path: for HEAD requests the returned
+// writer keeps the status and headers but discards the body
+func withoutBodyForHead(w http.ResponseWriter, r *http.Request) http.ResponseWriter {
+	if r.Method != http.MethodHead {
+		return w
+	}
+	return bodylessResponseWriter{w}
+}
+
+// bodylessResponseWriter drops everything written to the response body
+type bodylessResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w bodylessResponseWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w bodylessResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+)
+
+// DBMetricsResponse is the body of GET /metrics/db
+type DBMetricsResponse struct {
+	WindowSize int                                `json:"window_size"`
+	Operations map[string]database.LatencySummary `json:"operations"`
+}
+
+// DBMetricsHandler serves a JSON summary of recent database operation latency
+type DBMetricsHandler struct {
+	metrics *database.MetricsCollector
+	logger  *logging.Logger
+}
+
+// NewDBMetricsHandler creates a DBMetricsHandler reporting from metrics
+func NewDBMetricsHandler(logger *logging.Logger, metrics *database.MetricsCollector) *DBMetricsHandler {
+	return &DBMetricsHandler{
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// ServeHTTP writes the per-operation latency summary
+func (h *DBMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := DBMetricsResponse{
+		WindowSize: h.metrics.Window(),
+		Operations: h.metrics.Snapshot(),
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeJSON(w, http.StatusOK, response, false); err != nil {
+		h.logger.Error("Failed to write database metrics", logging.FieldError, err)
+	}
+}
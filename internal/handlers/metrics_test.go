@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBMetricsHandlerReportsLatencySummary(t *testing.T) {
+	metrics := database.NewMetricsCollector(8)
+	for _, ms := range []int{4, 1, 3, 2} {
+		metrics.Record("GetUsers", time.Duration(ms)*time.Millisecond)
+	}
+	handler := NewDBMetricsHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), metrics)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics/db", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{
+		"window_size": 8,
+		"operations": {
+			"GetUsers": {"count": 4, "p50_ms": 2, "p95_ms": 4, "p99_ms": 4, "max_ms": 4}
+		}
+	}`, w.Body.String())
+}
+
+func TestDBMetricsHandlerEmpty(t *testing.T) {
+	handler := NewDBMetricsHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), database.NewMetricsCollector(8))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics/db", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"window_size": 8, "operations": {}}`, w.Body.String())
+}
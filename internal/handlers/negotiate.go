@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+)
+
+const (
+	// formatJSON is the default paginated JSON envelope
+	formatJSON = "json"
+	// formatCSV renders a page of users as CSV with a header row
+	formatCSV = "csv"
+	// formatNDJSON renders one user object per line
+	formatNDJSON = "ndjson"
+)
+
+// acceptFormats maps the media types understood in the Accept header to response formats
+var acceptFormats = map[string]string{
+	"application/json":     formatJSON,
+	"text/csv":             formatCSV,
+	"application/x-ndjson": formatNDJSON,
+	"application/ndjson":   formatNDJSON,
+	"*/*":                  formatJSON,
+	"application/*":        formatJSON,
+}
+
+// userCSVHeader is the header row of CSV user listings and report exports
+var userCSVHeader = []string{"id", "first_name", "last_name", "age", "recording_date", "email"}
+
+// NegotiateFormat returns the response format requested by r: json, csv or ndjson
+// An explicit ?format= wins over the Accept header and is returned as given, so callers can reject
+// unsupported values. Otherwise the Accept media range with the highest quality value is used
+// (earlier entries win ties), falling back to json when nothing acceptable is listed.
+func NegotiateFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+
+	best, bestQuality := formatJSON, 0.0
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+			format, ok := acceptFormats[mediaType]
+			if !ok {
+				continue
+			}
+
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					continue
+				}
+			}
+			if quality > bestQuality {
+				best, bestQuality = format, quality
+			}
+		}
+	}
+	return best
+}
+
+// parseFormat negotiates the response format and rejects explicit ?format= values that are not supported
+func parseFormat(r *http.Request) (string, error) {
+	switch format := NegotiateFormat(r); format {
+	case formatJSON, formatCSV, formatNDJSON:
+		return format, nil
+	default:
+		return "", localizedValidationError(errors.RequestLocale(r), "INVALID_FORMAT_PARAMETER", "json, csv, ndjson")
+	}
+}
+
+// localizedValidationError builds a validation error whose message comes from the error catalog
+func localizedValidationError(locale, code string, args ...any) *pkgerrors.UserError {
+	return pkgerrors.NewUserValidationError(code, errors.Message(locale, code, args...))
+}
+
+// userCSVRecord renders one user response as a CSV row matching userCSVHeader
+func userCSVRecord(user UserResponse) []string {
+	return []string{user.ID, user.FirstName, user.LastName, strconv.Itoa(user.Age), fmt.Sprint(user.RecordingDate), user.Email}
+}
+
+// writeUsersCSV writes a page of users as CSV with a header row
+func writeUsersCSV(w http.ResponseWriter, users []models.User, dateFormat string) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(userCSVHeader); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := writer.Write(userCSVRecord(toUserResponse(user, dateFormat))); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeUsersNDJSON writes a page of users with one JSON object per line
+func writeUsersNDJSON(w http.ResponseWriter, users []models.User, dateFormat string) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	for _, user := range users {
+		if err := encoder.Encode(toUserResponse(user, dateFormat)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		accept []string
+		want   string
+	}{
+		{"no preference", "", nil, formatJSON},
+		{"json", "", []string{"application/json"}, formatJSON},
+		{"csv", "", []string{"text/csv"}, formatCSV},
+		{"ndjson", "", []string{"application/x-ndjson"}, formatNDJSON},
+		{"ndjson without x- prefix", "", []string{"application/ndjson"}, formatNDJSON},
+		{"quality values prefer csv", "", []string{"text/csv;q=0.9, application/json;q=0.8"}, formatCSV},
+		{"quality values prefer json", "", []string{"text/csv;q=0.5, application/json"}, formatJSON},
+		{"tie keeps first listed", "", []string{"text/csv, application/json"}, formatCSV},
+		{"q=0 excludes a type", "", []string{"text/csv;q=0, */*;q=0.1"}, formatJSON},
+		{"browser accept falls back to json", "", []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}, formatJSON},
+		{"unsupported only", "", []string{"application/xml"}, formatJSON},
+		{"malformed entries are skipped", "", []string{"text/csv;q=abc, ;;, application/x-ndjson;q=0.3"}, formatNDJSON},
+		{"multiple Accept headers", "", []string{"application/json;q=0.2", "text/csv;q=0.7"}, formatCSV},
+		{"explicit format wins", "format=json", []string{"text/csv"}, formatJSON},
+		{"explicit unsupported format returned as given", "format=xml", []string{"text/csv"}, "xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users?"+tt.query, nil)
+			for _, accept := range tt.accept {
+				req.Header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.want, NegotiateFormat(req))
+		})
+	}
+}
+
+func TestGetUsersNegotiatesCSV(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/users?date_format=rfc3339", nil)
+	req.Header.Set("Accept", "text/csv;q=0.9, application/json;q=0.8")
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		userCSVHeader,
+		{"550e8400-e29b-41d4-a716-446655440000", "John", "Doe", "30", "2024-01-15T10:30:00Z", "john.doe@example.com"},
+		{"550e8400-e29b-41d4-a716-446655440001", "Jane", "Smith", "25", "2024-01-15T10:31:40Z", "jane.smith@example.com"},
+	}, records)
+}
+
+func TestGetUsersNegotiatesNDJSON(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, httptest.NewRequest(http.MethodGet, "/users?format=ndjson", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	var ids []string
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var user UserResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &user))
+		ids = append(ids, user.ID)
+	}
+	assert.Equal(t, []string{"550e8400-e29b-41d4-a716-446655440000", "550e8400-e29b-41d4-a716-446655440001"}, ids)
+}
+
+func TestGetUsersRejectsUnsupportedFormat(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, httptest.NewRequest(http.MethodGet, "/users?format=xml", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_FORMAT_PARAMETER", response.Code)
+}
+
+func TestGetReportsNegotiatesFormatFromAccept(t *testing.T) {
+	users := []models.User{
+		{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+	}
+
+	t.Run("csv page", func(t *testing.T) {
+		handler := setupTestReportHandler()
+		dbService := handler.dbService.(*MockDatabaseService)
+		dbService.users = users
+
+		req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+		req.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+		handler.GetReports(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, handler.pageSize.Default, dbService.lastParams.Limit, "csv is paginated like json")
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "1705314600", records[1][4])
+	})
+
+	t.Run("ndjson stream", func(t *testing.T) {
+		handler := setupTestReportHandler()
+		dbService := handler.dbService.(*MockDatabaseService)
+		dbService.users = users
+
+		req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+		req.Header.Set("Accept", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		handler.GetReports(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Equal(t, 0, dbService.lastParams.Limit, "an ndjson stream is unbounded by default")
+	})
+}
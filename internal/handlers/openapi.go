@@ -0,0 +1,43 @@
+// Package handlers provides HTTP request handlers for the goUserAPI service.
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/openapi"
+)
+
+// OpenAPIHandler serves the OpenAPI contract as JSON
+type OpenAPIHandler struct {
+	spec   []byte
+	logger *logging.Logger
+}
+
+// NewOpenAPIHandler creates a new OpenAPIHandler with the spec marshaled once at startup
+func NewOpenAPIHandler(logger *logging.Logger) (*OpenAPIHandler, error) {
+	spec, err := json.Marshal(openapi.Build())
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIHandler{
+		spec:   spec,
+		logger: logger,
+	}, nil
+}
+
+// ServeHTTP writes the OpenAPI document
+func (h *OpenAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		MethodNotAllowed(w, r, []string{http.MethodGet})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(h.spec); err != nil {
+		h.logger.Error("Failed to write OpenAPI spec", logging.FieldError, err)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIHandlerServesSpec(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler, err := NewOpenAPIHandler(logger)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var spec openapi.Spec
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+
+	assert.Equal(t, openapi.Version, spec.OpenAPI)
+
+	requiredPaths := map[string][]string{
+		"/users":      {"get", "head", "post"},
+		"/users/{id}": {"get", "head"},
+		"/reports":    {"get", "head"},
+		"/health":     {"get"},
+		"/healthz":    {"get"},
+	}
+	for path, methods := range requiredPaths {
+		item, ok := spec.Paths[path]
+		require.True(t, ok, "spec missing path %s", path)
+		for _, method := range methods {
+			switch method {
+			case "get":
+				assert.NotNil(t, item.Get, "%s missing GET", path)
+			case "head":
+				if assert.NotNil(t, item.Head, "%s missing HEAD", path) {
+					for status, response := range item.Head.Responses {
+						assert.Empty(t, response.Content, "%s HEAD %s documents a body", path, status)
+					}
+				}
+			case "post":
+				assert.NotNil(t, item.Post, "%s missing POST", path)
+			}
+		}
+	}
+
+	errorSchema, ok := spec.Components.Schemas["ErrorResponse"]
+	require.True(t, ok, "spec missing ErrorResponse schema")
+	assert.ElementsMatch(t, []string{"error", "code"}, errorSchema.Required)
+	assert.Contains(t, errorSchema.Properties["code"].Enum, "INVALID_LIMIT_PARAMETER")
+
+	for _, name := range []string{"User", "CreateUserRequest", "GetUsersResponse", "GetReportsResponse"} {
+		assert.Contains(t, spec.Components.Schemas, name)
+	}
+}
+
+func TestOpenAPIHandlerMethodNotAllowed(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler, err := NewOpenAPIHandler(logger)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET", w.Header().Get("Allow"))
+}
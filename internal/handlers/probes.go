@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProbeHandler serves lightweight liveness and readiness probes for orchestrators and load balancers
+type ProbeHandler struct {
+	shuttingDown atomic.Bool
+	mu           sync.RWMutex
+	checkers     []HealthChecker
+	timeout      time.Duration // budget shared by all checkers of one request (0 disables)
+}
+
+// readinessResponse is the /readyz body; checks are included only when readiness checkers are registered
+type readinessResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// NewProbeHandler creates a ProbeHandler that reports ready until shutdown begins
+func NewProbeHandler() *ProbeHandler {
+	return &ProbeHandler{}
+}
+
+// SetShuttingDown marks the service as draining so readiness fails and load balancers deregister it
+func (p *ProbeHandler) SetShuttingDown(shuttingDown bool) {
+	p.shuttingDown.Store(shuttingDown)
+}
+
+// ShuttingDown reports whether shutdown has begun
+func (p *ProbeHandler) ShuttingDown() bool {
+	return p.shuttingDown.Load()
+}
+
+// AddChecker registers a check that must be healthy for /readyz to report ready
+func (p *ProbeHandler) AddChecker(checker HealthChecker) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkers = append(p.checkers, checker)
+}
+
+// SetTimeout bounds the total time spent running readiness checkers per request; 0 disables the budget
+func (p *ProbeHandler) SetTimeout(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = timeout
+}
+
+// Livez reports that the process is running; it stays 200 while draining so the process is not restarted
+func (p *ProbeHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	writeProbeResponse(w, http.StatusOK, "ok")
+}
+
+// Readyz reports whether the service should receive traffic; it returns 503 once shutdown has begun
+// or while any registered checker is unhealthy
+func (p *ProbeHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if p.ShuttingDown() {
+		writeProbeResponse(w, http.StatusServiceUnavailable, "shutting_down")
+		return
+	}
+
+	p.mu.RLock()
+	checkers := make([]HealthChecker, len(p.checkers))
+	copy(checkers, p.checkers)
+	timeout := p.timeout
+	p.mu.RUnlock()
+
+	if len(checkers) == 0 {
+		writeProbeResponse(w, http.StatusOK, "ready")
+		return
+	}
+
+	response := readinessResponse{Status: "ready", Checks: runHealthChecks(r.Context(), checkers, timeout)}
+	statusCode := http.StatusOK
+	for _, check := range response.Checks {
+		if check.Status != "healthy" {
+			response.Status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// writeProbeResponse writes a minimal JSON probe body
+func writeProbeResponse(w http.ResponseWriter, statusCode int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": status})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbesDuringShutdown(t *testing.T) {
+	probes := NewProbeHandler()
+
+	probe := func(handler http.HandlerFunc) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, probe(probes.Readyz).Code)
+	assert.Equal(t, http.StatusOK, probe(probes.Livez).Code)
+
+	probes.SetShuttingDown(true)
+
+	ready := probe(probes.Readyz)
+	assert.Equal(t, http.StatusServiceUnavailable, ready.Code)
+	assert.JSONEq(t, `{"status":"shutting_down"}`, ready.Body.String())
+	assert.Equal(t, http.StatusOK, probe(probes.Livez).Code, "liveness must not fail while draining")
+
+	probes.SetShuttingDown(false)
+	assert.Equal(t, http.StatusOK, probe(probes.Readyz).Code)
+}
+
+func TestReadyzRunsReadinessCheckers(t *testing.T) {
+	probes := NewProbeHandler()
+	probes.AddChecker(&MockHealthChecker{name: "database"})
+	probes.AddChecker(&MockHealthChecker{name: "migrations", err: errors.New("2 pending migrations")})
+
+	w := httptest.NewRecorder()
+	probes.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var response readinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "not_ready", response.Status)
+	assert.Equal(t, "healthy", response.Checks["database"].Status)
+	assert.Equal(t, "2 pending migrations", response.Checks["migrations"].Error)
+
+	healthy := NewProbeHandler()
+	healthy.AddChecker(&MockHealthChecker{name: "migrations"})
+	w = httptest.NewRecorder()
+	healthy.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ready"`)
+}
+
+func TestReadyzReturnsWithinTimeoutBudget(t *testing.T) {
+	probes := NewProbeHandler()
+	probes.SetTimeout(50 * time.Millisecond)
+	probes.AddChecker(&MockHealthChecker{name: "database"})
+	probes.AddChecker(&MockHealthChecker{name: "migrations", delay: 300 * time.Millisecond})
+	probes.AddChecker(&MockHealthChecker{name: "pool", delay: 300 * time.Millisecond})
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	probes.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	elapsed := time.Since(start)
+
+	assert.Less(t, elapsed, 200*time.Millisecond, "slow checkers must not extend the request past the budget")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response readinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "healthy", response.Checks["database"].Status)
+	for _, name := range []string{"migrations", "pool"} {
+		assert.Equal(t, "unhealthy", response.Checks[name].Status, name)
+		assert.Equal(t, healthCheckSkippedError, response.Checks[name].Error, name)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/chybatronik/goUserAPI/internal/database"
 	"github.com/chybatronik/goUserAPI/internal/errors"
 	"github.com/chybatronik/goUserAPI/internal/logging"
 	"github.com/chybatronik/goUserAPI/internal/middleware"
@@ -24,17 +25,55 @@ type ReportHandler struct {
 	pool      *pgxpool.Pool
 	logger    *logging.Logger
 	dbService DatabaseService
+	ageBounds validation.AgeBounds
+	pageSize  validation.PageSize
+	exports   *ExportManager
+	now       func() time.Time
+
+	// prettyJSON allows ?pretty=true to indent responses (disabled in production)
+	prettyJSON bool
+
+	// maxQueryParams caps the number of query parameter values per request (0 disables)
+	maxQueryParams int
 }
 
+// DefaultMaxQueryParams is the query parameter cap used when none is configured
+const DefaultMaxQueryParams = 50
+
 // NewReportHandler creates a new ReportHandler instance
 func NewReportHandler(logger *logging.Logger, pool *pgxpool.Pool, dbService DatabaseService) *ReportHandler {
 	return &ReportHandler{
 		pool:      pool,
 		logger:    logger,
 		dbService: dbService,
+		ageBounds: validation.DefaultAgeBounds,
+		pageSize:  validation.DefaultPageSize,
+		now:       time.Now,
+
+		maxQueryParams: DefaultMaxQueryParams,
 	}
 }
 
+// SetAgeBounds configures the accepted range for the min_age and max_age filters
+func (h *ReportHandler) SetAgeBounds(bounds validation.AgeBounds) {
+	h.ageBounds = bounds
+}
+
+// SetPageSize configures the default and maximum limit for paginated reports
+func (h *ReportHandler) SetPageSize(size validation.PageSize) {
+	h.pageSize = size
+}
+
+// SetPrettyJSON allows clients to request indented JSON with ?pretty=true
+func (h *ReportHandler) SetPrettyJSON(allowed bool) {
+	h.prettyJSON = allowed
+}
+
+// SetMaxQueryParams configures how many query parameter values a report request may carry (0 disables the cap)
+func (h *ReportHandler) SetMaxQueryParams(max int) {
+	h.maxQueryParams = max
+}
+
 // writeErrorResponse writes a unified error response
 func (h *ReportHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, errCode, message, details string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -58,55 +97,85 @@ func (h *ReportHandler) writeErrorResponse(w http.ResponseWriter, statusCode int
 
 // GetReportsRequestParams represents query parameters for GetReports
 type GetReportsRequestParams struct {
-	Limit     int
-	Offset    int
-	StartDate *int64
-	EndDate   *int64
-	MinAge    *int
-	MaxAge    *int
+	Limit      int
+	Offset     int
+	StartDate  *int64
+	EndDate    *int64
+	MinAge     *int
+	MaxAge     *int
+	SortBy     string
+	SortOrder  string
+	Format     string
+	DateFormat string
+	Locale     string // Accept-Language locale for validation messages
 }
 
+const (
+	// ndjsonFlushInterval is the number of streamed rows between flushes
+	ndjsonFlushInterval = 100
+)
+
 // GetReportsResponse represents the response format for GetReports
 type GetReportsResponse struct {
 	Count      int64          `json:"count"`
-	Users      []models.User  `json:"users"`
+	Users      []UserResponse `json:"users"`
 	Pagination PaginationInfo `json:"pagination"`
 }
 
 // parseAndValidateReportsQueryParams parses and validates query parameters for GetReports
 func (h *ReportHandler) parseAndValidateReportsQueryParams(r *http.Request) (*GetReportsRequestParams, error) {
-	params := &GetReportsRequestParams{}
+	params := &GetReportsRequestParams{Locale: errors.RequestLocale(r)}
 
 	// SECURITY: Apply Story 2.4 Unicode security validation only to string parameters
 	// Numeric parameters don't need Unicode validation for performance
 	// This prevents homograph attacks, control characters, and other Unicode-based attacks
 	queryParams := r.URL.Query()
+
+	// Reject oversized queries before validating each value, so thousands of parameters cost one count
+	if h.maxQueryParams > 0 {
+		count := 0
+		for _, values := range queryParams {
+			count += len(values)
+		}
+		if count > h.maxQueryParams {
+			return nil, localizedValidationError(params.Locale, "TOO_MANY_PARAMETERS", h.maxQueryParams)
+		}
+	}
+
 	for key, values := range queryParams {
 		// Skip Unicode validation for known numeric parameters to improve performance
-		isNumericParam := key == "limit" || key == "offset" || key == "start_date" || key == "end_date" || key == "min_age" || key == "max_age"
+		isNumericParam := key == "limit" || key == "offset" || key == "start_date" || key == "end_date" || key == "min_age" || key == "max_age" || key == "age"
 
 		for _, value := range values {
 			if !isNumericParam {
 				if err := validation.ValidateUnicodeSecurity(value); err != nil {
-					return nil, pkgerrors.NewUserValidationError("UNSECURE_UNICODE_INPUT",
-						fmt.Sprintf("Invalid unicode characters in parameter '%s'", key))
+					return nil, localizedValidationError(params.Locale, "UNSECURE_UNICODE_INPUT", key)
 				}
 			}
 			if err := validation.ValidateFieldSecurity(value, key, 1000); err != nil {
-				return nil, pkgerrors.NewUserValidationError("INVALID_PARAMETER_FORMAT",
-					fmt.Sprintf("Invalid format for parameter '%s'", key))
+				return nil, localizedValidationError(params.Locale, "INVALID_PARAMETER_FORMAT", key)
 			}
 		}
 	}
 
-	// Parse limit with default
+	// Negotiate format from ?format= or the Accept header
+	format, err := parseFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	params.Format = format
+
+	// Parse limit with default; NDJSON exports stream every matching row unless limited
 	limitStr := r.URL.Query().Get("limit")
 	if limitStr == "" {
-		params.Limit = 20 // default
+		params.Limit = h.pageSize.Default
+		if params.Format == formatNDJSON {
+			params.Limit = 0
+		}
 	} else {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_LIMIT_PARAMETER", "Invalid limit parameter. Must be between 1 and 100")
+			return nil, localizedValidationError(params.Locale, "INVALID_LIMIT_PARAMETER", h.pageSize.Max)
 		}
 		params.Limit = limit
 	}
@@ -118,7 +187,7 @@ func (h *ReportHandler) parseAndValidateReportsQueryParams(r *http.Request) (*Ge
 	} else {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_OFFSET_PARAMETER", "Invalid offset parameter. Must be >= 0")
+			return nil, localizedValidationError(params.Locale, "INVALID_OFFSET_PARAMETER")
 		}
 		params.Offset = offset
 	}
@@ -128,7 +197,7 @@ func (h *ReportHandler) parseAndValidateReportsQueryParams(r *http.Request) (*Ge
 	if startDateStr != "" {
 		startDate, err := strconv.ParseInt(startDateStr, 10, 64)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_START_DATE_PARAMETER", "Invalid start_date parameter. Must be Unix timestamp")
+			return nil, localizedValidationError(params.Locale, "INVALID_START_DATE_PARAMETER")
 		}
 		params.StartDate = &startDate
 	}
@@ -138,17 +207,30 @@ func (h *ReportHandler) parseAndValidateReportsQueryParams(r *http.Request) (*Ge
 	if endDateStr != "" {
 		endDate, err := strconv.ParseInt(endDateStr, 10, 64)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_END_DATE_PARAMETER", "Invalid end_date parameter. Must be Unix timestamp")
+			return nil, localizedValidationError(params.Locale, "INVALID_END_DATE_PARAMETER")
 		}
 		params.EndDate = &endDate
 	}
 
+	// Parse last (optional): a relative window ending now, exclusive with absolute dates
+	if lastStr := r.URL.Query().Get("last"); lastStr != "" {
+		if params.StartDate != nil || params.EndDate != nil {
+			return nil, localizedValidationError(params.Locale, "CONFLICTING_DATE_PARAMETERS")
+		}
+		window, err := parseRelativeDuration(lastStr)
+		if err != nil {
+			return nil, localizedValidationError(params.Locale, "INVALID_LAST_PARAMETER")
+		}
+		startDate := h.now().Add(-window).Unix()
+		params.StartDate = &startDate
+	}
+
 	// Parse min_age (optional)
 	minAgeStr := r.URL.Query().Get("min_age")
 	if minAgeStr != "" {
 		minAge, err := strconv.Atoi(minAgeStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_MIN_AGE_PARAMETER", "Invalid min_age parameter. Must be integer between 1 and 120")
+			return nil, localizedValidationError(params.Locale, "INVALID_MIN_AGE_PARAMETER", h.ageBounds.Min, h.ageBounds.Max)
 		}
 		params.MinAge = &minAge
 	}
@@ -158,83 +240,143 @@ func (h *ReportHandler) parseAndValidateReportsQueryParams(r *http.Request) (*Ge
 	if maxAgeStr != "" {
 		maxAge, err := strconv.Atoi(maxAgeStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_MAX_AGE_PARAMETER", "Invalid max_age parameter. Must be integer between 1 and 120")
+			return nil, localizedValidationError(params.Locale, "INVALID_MAX_AGE_PARAMETER", h.ageBounds.Min, h.ageBounds.Max)
 		}
 		params.MaxAge = &maxAge
 	}
 
+	// Parse age (optional): an exact match, exclusive with min_age and max_age, that sets both bounds
+	if ageStr := r.URL.Query().Get("age"); ageStr != "" {
+		if params.MinAge != nil || params.MaxAge != nil {
+			return nil, localizedValidationError(params.Locale, "CONFLICTING_AGE_PARAMETERS")
+		}
+		age, err := strconv.Atoi(ageStr)
+		if err != nil || validation.ValidateAge(age, h.ageBounds.Min, h.ageBounds.Max) != nil {
+			return nil, localizedValidationError(params.Locale, "INVALID_AGE_PARAMETER", h.ageBounds.Min, h.ageBounds.Max)
+		}
+		params.MinAge = &age
+		params.MaxAge = &age
+	}
+
+	// Parse sort_by with default
+	params.SortBy = r.URL.Query().Get("sort_by")
+	if params.SortBy == "" {
+		params.SortBy = database.DefaultSortField
+	}
+
+	// Parse sort_order, defaulting to the natural direction of the sort field
+	params.SortOrder = r.URL.Query().Get("sort_order")
+	if params.SortOrder == "" {
+		params.SortOrder = database.DefaultSortOrder(params.SortBy)
+	}
+
+	// Parse date_format with default
+	dateFormat, err := parseDateFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	params.DateFormat = dateFormat
+
 	return params, nil
 }
 
+// maxRelativeWindowDays bounds ?last so the window cannot overflow time.Duration (about 292 years)
+const maxRelativeWindowDays = 100 * 365
+
+// parseRelativeDuration parses a positive window such as 24h, 7d or 30d, at most maxRelativeWindowDays long
+// Days are whole multiples of 24h; anything else uses time.ParseDuration syntax.
+func parseRelativeDuration(value string) (time.Duration, error) {
+	var window time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		// Check before multiplying: a large n would wrap around instead of failing
+		if n > maxRelativeWindowDays {
+			return 0, fmt.Errorf("window must be at most %dd, got %q", maxRelativeWindowDays, value)
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, err
+		}
+		window = d
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("window must be positive, got %q", value)
+	}
+	if window > maxRelativeWindowDays*24*time.Hour {
+		return 0, fmt.Errorf("window must be at most %dd, got %q", maxRelativeWindowDays, value)
+	}
+	return window, nil
+}
+
 // validateGetReportsParams validates parsed query parameters against business rules
 func (h *ReportHandler) validateGetReportsParams(params *GetReportsRequestParams) error {
-	// Validate limit range (1-100)
-	if params.Limit < 1 || params.Limit > 100 {
-		return pkgerrors.NewUserValidationError("INVALID_LIMIT_PARAMETER",
-			"Invalid limit parameter. Must be between 1 and 100")
+	// Validate limit range against the configured page size; an NDJSON stream may also use 0 for no limit
+	unboundedStream := params.Format == formatNDJSON && params.Limit == 0
+	if !unboundedStream && !h.pageSize.Contains(params.Limit) {
+		return localizedValidationError(params.Locale, "INVALID_LIMIT_PARAMETER", h.pageSize.Max)
 	}
 
 	// Validate offset range (>= 0)
 	if params.Offset < 0 {
-		return pkgerrors.NewUserValidationError("INVALID_OFFSET_PARAMETER",
-			"Invalid offset parameter. Must be >= 0")
+		return localizedValidationError(params.Locale, "INVALID_OFFSET_PARAMETER")
 	}
 
-	// Validate age range if both provided
-	if params.MinAge != nil && params.MaxAge != nil {
-		if *params.MinAge < 1 || *params.MinAge > 120 {
-			return pkgerrors.NewUserValidationError("INVALID_MIN_AGE_PARAMETER",
-				"Invalid min_age parameter. Must be between 1 and 120")
-		}
-		if *params.MaxAge < 1 || *params.MaxAge > 120 {
-			return pkgerrors.NewUserValidationError("INVALID_MAX_AGE_PARAMETER",
-				"Invalid max_age parameter. Must be between 1 and 120")
-		}
-		if *params.MinAge > *params.MaxAge {
-			return pkgerrors.NewUserValidationError("INVALID_AGE_RANGE",
-				"Invalid age range: min_age cannot be greater than max_age")
-		}
+	// Reject deep pagination; PostgreSQL reads and discards every skipped row
+	if !h.pageSize.AllowsOffset(params.Offset) {
+		return localizedValidationError(params.Locale, "OFFSET_TOO_LARGE", h.pageSize.MaxOffset)
 	}
 
-	// Validate individual min_age
+	// Validate min_age and max_age against the configured bounds, then their order when both are provided
 	if params.MinAge != nil {
-		if *params.MinAge < 1 || *params.MinAge > 120 {
-			return pkgerrors.NewUserValidationError("INVALID_MIN_AGE_PARAMETER",
-				"Invalid min_age parameter. Must be between 1 and 120")
+		if err := validation.ValidateAge(*params.MinAge, h.ageBounds.Min, h.ageBounds.Max); err != nil {
+			return localizedValidationError(params.Locale, "INVALID_MIN_AGE_PARAMETER", h.ageBounds.Min, h.ageBounds.Max)
 		}
 	}
-
-	// Validate individual max_age
 	if params.MaxAge != nil {
-		if *params.MaxAge < 1 || *params.MaxAge > 120 {
-			return pkgerrors.NewUserValidationError("INVALID_MAX_AGE_PARAMETER",
-				"Invalid max_age parameter. Must be between 1 and 120")
+		if err := validation.ValidateAge(*params.MaxAge, h.ageBounds.Min, h.ageBounds.Max); err != nil {
+			return localizedValidationError(params.Locale, "INVALID_MAX_AGE_PARAMETER", h.ageBounds.Min, h.ageBounds.Max)
 		}
 	}
+	if params.MinAge != nil && params.MaxAge != nil && *params.MinAge > *params.MaxAge {
+		return localizedValidationError(params.Locale, validation.CodeInvalidAgeRange)
+	}
+
+	// Validate sorting against the same whitelist as GET /users (empty values fall back to defaults)
+	sortBy, sortOrder := params.SortBy, params.SortOrder
+	if sortBy == "" {
+		sortBy = database.DefaultSortField
+	}
+	if sortOrder == "" {
+		sortOrder = database.DefaultSortOrder(sortBy)
+	}
+	if err := validateSortParams(params.Locale, sortBy, sortOrder); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-// writeGetReportsResponse writes a successful GetReports response with pagination metadata
-func (h *ReportHandler) writeGetReportsResponse(w http.ResponseWriter, users []models.User, totalCount int64, limit, offset int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	// Calculate has_more for pagination
-	hasMore := int64(offset+limit) < totalCount
-
-	response := GetReportsResponse{
-		Count: totalCount,
-		Users: users,
-		Pagination: PaginationInfo{
-			TotalCount: totalCount,
-			Limit:      limit,
-			Offset:     offset,
-			HasMore:    hasMore,
-		},
+// writeGetReportsResponse writes a successful GetReports response with pagination metadata and Link headers
+func (h *ReportHandler) writeGetReportsResponse(w http.ResponseWriter, r *http.Request, users []models.User, totalCount int64, limit, offset int, dateFormat string, pretty bool) {
+	userResponses := toUserResponses(users, dateFormat)
+	pagination := newPaginationInfo(totalCount, limit, offset)
+	var response any = GetReportsResponse{
+		Count:      totalCount,
+		Users:      userResponses,
+		Pagination: pagination,
+	}
+	if envelopeListResponses {
+		// count duplicates pagination.total_count, so the envelope carries only the latter
+		response = newListEnvelope(userResponses, pagination)
 	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	setPaginationLinks(w, r.URL, totalCount, limit, offset)
+	if err := writeJSON(w, http.StatusOK, response, pretty); err != nil {
 		h.logger.Error("Failed to encode GetReports response",
 			logging.FieldError, err,
 			"total_count", totalCount,
@@ -258,7 +400,7 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 	// Start timer for performance monitoring
 	startTime := time.Now()
 	reqID := h.extractRequestID(r)
-	logger := h.logger.WithRequestID(reqID)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
 
 	logger.Info("Starting report generation request",
 		"method", r.Method,
@@ -267,17 +409,104 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 		"remote_addr", r.RemoteAddr,
 	)
 
-	// Validate HTTP method - only GET is allowed
-	if r.Method != http.MethodGet {
+	// Validate HTTP method - GET, or HEAD for the same response without a body
+	if !isReadMethod(r) {
 		logger.Warn("Invalid HTTP method for report generation",
 			"method", r.Method,
 			"expected_method", "GET",
 		)
-		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
-			"Only GET method is allowed", "")
+		MethodNotAllowed(w, r, ReportsMethods)
 		return
 	}
+	w = withoutBodyForHead(w, r)
+
+	params, ok := h.readReportParams(w, r, logger)
+	if !ok {
+		return
+	}
+	w.Header().Add("Vary", "Accept")
+
+	// Prepare database parameters with Epic 3 defaults
+	dbParams := types.GetReportsParams{
+		Limit:     params.Limit,
+		Offset:    params.Offset,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		MinAge:    params.MinAge,
+		MaxAge:    params.MaxAge,
+		SortBy:    params.SortBy,
+		SortOrder: params.SortOrder,
+	}
+
+	logger.Info("Generating report from database",
+		"limit", params.Limit,
+		"offset", params.Offset,
+		"start_date", params.StartDate,
+		"end_date", params.EndDate,
+		"min_age", params.MinAge,
+		"max_age", params.MaxAge,
+		"sort_by", params.SortBy,
+		"sort_order", params.SortOrder,
+		"format", params.Format,
+	)
 
+	if params.Format == formatNDJSON {
+		h.streamReports(w, r, logger, dbParams, params.DateFormat, startTime)
+		return
+	}
+
+	// Get reports from database
+	users, totalCount, err := h.dbService.GetReports(r.Context(), h.pool, dbParams)
+	if err != nil {
+		logger.Error("Failed to generate report from database",
+			logging.FieldError, err,
+			"limit", params.Limit,
+			"offset", params.Offset,
+		)
+
+		// SECURITY: Use secure error mapping to prevent information leakage (NFR-S3 compliance)
+		secureErr := errors.MapDatabaseErrorSecure(err)
+		if userErr, ok := secureErr.(*pkgerrors.UserError); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "Database operation failed", "")
+		}
+		return
+	}
+
+	// Log successful report generation
+	logger.Info("Report generated successfully",
+		"user_count", len(users),
+		"total_count", totalCount,
+		"limit", params.Limit,
+		"offset", params.Offset,
+	)
+
+	// Tell clients still paging by offset that it is going away, once it is registered as deprecated
+	warnOffsetDeprecated(w, r)
+	setTotalCount(w, totalCount)
+
+	// Write success response in the negotiated format
+	if params.Format == formatCSV {
+		if err := writeUsersCSV(w, users, params.DateFormat); err != nil {
+			logger.Error("Failed to write CSV report", logging.FieldError, err)
+		}
+	} else {
+		h.writeGetReportsResponse(w, r, users, totalCount, params.Limit, params.Offset, params.DateFormat, wantsPrettyJSON(r, h.prettyJSON))
+	}
+
+	// Log request completion for performance monitoring
+	duration := time.Since(startTime)
+	logger.Info("Report generation request completed",
+		"duration_ms", duration.Milliseconds(),
+		"status_code", http.StatusOK,
+		"user_count", len(users),
+		"total_count", totalCount,
+	)
+}
+
+// readReportParams parses and validates report filters, writing a 400 response when they are invalid
+func (h *ReportHandler) readReportParams(w http.ResponseWriter, r *http.Request, logger *logging.Logger) (*GetReportsRequestParams, bool) {
 	// Parse and validate query parameters
 	params, err := h.parseAndValidateReportsQueryParams(r)
 	if err != nil {
@@ -293,7 +522,7 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_QUERY_PARAMETERS",
 				"Invalid query parameters", err.Error())
 		}
-		return
+		return nil, false
 	}
 
 	// Validate parameters against business rules
@@ -310,10 +539,15 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 		// Safe type assertion with fallback
 		if userErr, ok := err.(*pkgerrors.UserError); ok {
 			details := ""
-			if strings.Contains(userErr.Message, "limit") {
-				details = "parameter: limit, value: " + strconv.Itoa(params.Limit) + ", valid_range: 1-100"
-			} else if strings.Contains(userErr.Message, "age") {
-				details = "parameters: min_age, max_age, valid_range: 1-120"
+			switch userErr.Code {
+			case "INVALID_LIMIT_PARAMETER":
+				details = "parameter: limit, value: " + strconv.Itoa(params.Limit) + ", valid_range: 1-" + strconv.Itoa(h.pageSize.Max)
+			case "OFFSET_TOO_LARGE":
+				details = "parameter: offset, value: " + strconv.Itoa(params.Offset) + ", max: " + strconv.Itoa(h.pageSize.MaxOffset)
+			case "INVALID_SORT_FIELD":
+				details = "parameter: sort_by, value: " + params.SortBy + ", allowed_fields: " + strings.Join(database.SortFieldNames(), ",")
+			case "INVALID_MIN_AGE_PARAMETER", "INVALID_MAX_AGE_PARAMETER", "INVALID_AGE_RANGE":
+				details = fmt.Sprintf("parameters: min_age, max_age, valid_range: %d-%d", h.ageBounds.Min, h.ageBounds.Max)
 			}
 			h.writeErrorResponse(w, http.StatusBadRequest, userErr.Code, userErr.Message, details)
 		} else {
@@ -321,32 +555,47 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR",
 				"Parameter validation failed", err.Error())
 		}
-		return
+		return nil, false
 	}
 
-	// Prepare database parameters with Epic 3 defaults
-	dbParams := types.GetReportsParams{
-		Limit:     params.Limit,
-		Offset:    params.Offset,
-		StartDate: params.StartDate,
-		EndDate:   params.EndDate,
-		MinAge:    params.MinAge,
-		MaxAge:    params.MaxAge,
-	}
+	return params, true
+}
 
-	logger.Info("Generating report from database",
-		"limit", params.Limit,
-		"offset", params.Offset,
-		"start_date", params.StartDate,
-		"end_date", params.EndDate,
-		"min_age", params.MinAge,
-		"max_age", params.MaxAge,
-	)
+// streamReports writes the report as NDJSON, one user per line, while rows are read from the database
+// Headers are only sent with the first row so a failing query still gets a regular JSON error response.
+func (h *ReportHandler) streamReports(w http.ResponseWriter, r *http.Request, logger *logging.Logger, params types.GetReportsParams, dateFormat string, startTime time.Time) {
+	rc := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	streamed := 0
+
+	err := h.dbService.StreamReports(r.Context(), h.pool, params, func(user models.User) error {
+		if streamed == 0 {
+			warnOffsetDeprecated(w, r)
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		if err := encoder.Encode(toUserResponse(user, dateFormat)); err != nil {
+			return fmt.Errorf("failed to write NDJSON row: %w", err)
+		}
+		streamed++
+		if streamed%ndjsonFlushInterval == 0 {
+			// Not every writer supports flushing; the rows are still delivered when the handler returns
+			_ = rc.Flush()
+		}
+		return nil
+	})
 
-	// Get reports from database
-	users, totalCount, err := h.dbService.GetReports(r.Context(), h.pool, dbParams)
 	if err != nil {
-		logger.Error("Failed to generate report from database",
+		if streamed > 0 {
+			// The status line is already sent, so the client sees a truncated stream
+			logger.Error("Report stream aborted",
+				logging.FieldError, err,
+				"user_count", streamed,
+			)
+			return
+		}
+
+		logger.Error("Failed to stream report from database",
 			logging.FieldError, err,
 			"limit", params.Limit,
 			"offset", params.Offset,
@@ -362,23 +611,18 @@ func (h *ReportHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Log successful report generation
-	logger.Info("Report generated successfully",
-		"user_count", len(users),
-		"total_count", totalCount,
-		"limit", params.Limit,
-		"offset", params.Offset,
-	)
-
-	// Write success response
-	h.writeGetReportsResponse(w, users, totalCount, params.Limit, params.Offset)
+	if streamed == 0 {
+		// An empty result is still a valid, empty stream
+		warnOffsetDeprecated(w, r)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = rc.Flush()
 
-	// Log request completion for performance monitoring
 	duration := time.Since(startTime)
-	logger.Info("Report generation request completed",
+	logger.Info("Report stream completed",
 		"duration_ms", duration.Milliseconds(),
 		"status_code", http.StatusOK,
-		"user_count", len(users),
-		"total_count", totalCount,
+		"user_count", streamed,
 	)
 }
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -14,7 +17,9 @@ import (
 	"github.com/chybatronik/goUserAPI/internal/logging"
 	"github.com/chybatronik/goUserAPI/internal/models"
 	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
 	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,23 +28,50 @@ type MockDatabaseService struct {
 	users      []models.User
 	totalCount int64
 	err        error
+	lastParams types.GetReportsParams
 }
 
 func (m *MockDatabaseService) CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
 	return nil, nil
 }
 
+func (m *MockDatabaseService) UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	return nil, false, nil
+}
+
 func (m *MockDatabaseService) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
 	return nil, 0, nil
 }
 
+func (m *MockDatabaseService) GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (m *MockDatabaseService) GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error) {
+	return []models.User{}, nil
+}
+
 func (m *MockDatabaseService) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
+	m.lastParams = params
 	if m.err != nil {
 		return nil, 0, m.err
 	}
 	return m.users, m.totalCount, nil
 }
 
+func (m *MockDatabaseService) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	m.lastParams = params
+	if m.err != nil {
+		return m.err
+	}
+	for _, user := range m.users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func setupTestReportHandler() *ReportHandler {
 	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
 	dbService := &MockDatabaseService{}
@@ -218,6 +250,162 @@ func TestGetReports_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestGetReports_WithSorting(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?sort_by=age&sort_order=asc", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if dbService.lastParams.SortBy != "age" || dbService.lastParams.SortOrder != "asc" {
+		t.Errorf("Expected sort age asc, got %s %s", dbService.lastParams.SortBy, dbService.lastParams.SortOrder)
+	}
+}
+
+func TestGetReports_DefaultSorting(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if dbService.lastParams.SortBy != "recording_date" || dbService.lastParams.SortOrder != "desc" {
+		t.Errorf("Expected default sort recording_date desc, got %s %s", dbService.lastParams.SortBy, dbService.lastParams.SortOrder)
+	}
+}
+
+func TestGetReports_DefaultSortOrderForNameField(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	handler.GetReports(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/reports?sort_by=first_name", nil))
+
+	if dbService.lastParams.SortOrder != "asc" {
+		t.Errorf("Expected first_name to default to asc, got %s", dbService.lastParams.SortOrder)
+	}
+}
+
+func TestGetReports_InvalidSortField(t *testing.T) {
+	testCases := []struct {
+		query        string
+		expectedCode string
+	}{
+		{"sort_by=password", "INVALID_SORT_FIELD"},
+		{"sort_by=age%3BDROP%20TABLE%20users", "INVALID_SORT_FIELD"},
+		{"sort_order=sideways", "INVALID_SORT_ORDER"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			handler := setupTestReportHandler()
+			req := httptest.NewRequest(http.MethodGet, "/reports?"+tc.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetReports(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			var errorResp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&errorResp); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+			if errorResp.Code != tc.expectedCode {
+				t.Errorf("Expected error code '%s', got '%s'", tc.expectedCode, errorResp.Code)
+			}
+		})
+	}
+}
+
+func TestGetReports_ConfiguredPageSize(t *testing.T) {
+	handler := setupTestReportHandler()
+	handler.SetPageSize(validation.PageSize{Default: 7, Max: 30})
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if dbService.lastParams.Limit != 7 {
+		t.Errorf("Expected configured default limit 7, got %d", dbService.lastParams.Limit)
+	}
+}
+
+func TestGetReports_NDJSONStream(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{
+		{ID: "550e8400-e29b-41d4-a716-446655440000", FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+		{ID: "550e8400-e29b-41d4-a716-446655440001", FirstName: "Jane", LastName: "Smith", Age: 25, RecordingDate: 1705314700},
+		{ID: "550e8400-e29b-41d4-a716-446655440002", FirstName: "Ivan", LastName: "Petrov", Age: 41, RecordingDate: 1705314800},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?format=ndjson&min_age=20", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Errorf("Expected Content-Type application/x-ndjson, got %s", contentType)
+	}
+	if dbService.lastParams.Limit != 0 {
+		t.Errorf("Expected unbounded stream (limit 0) by default, got %d", dbService.lastParams.Limit)
+	}
+
+	var streamed []models.User
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var user models.User
+		if err := json.Unmarshal(scanner.Bytes(), &user); err != nil {
+			t.Fatalf("Failed to decode NDJSON line %q: %v", scanner.Text(), err)
+		}
+		streamed = append(streamed, user)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read NDJSON stream: %v", err)
+	}
+
+	if !reflect.DeepEqual(streamed, dbService.users) {
+		t.Errorf("Expected streamed users %+v, got %+v", dbService.users, streamed)
+	}
+}
+
+func TestGetReports_InvalidFormat(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?format=xml", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var errorResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errorResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errorResp.Code != "INVALID_FORMAT_PARAMETER" {
+		t.Errorf("Expected error code 'INVALID_FORMAT_PARAMETER', got '%s'", errorResp.Code)
+	}
+}
+
 func TestGetReports_InvalidOffset(t *testing.T) {
 	handler := setupTestReportHandler()
 
@@ -235,6 +423,34 @@ func TestGetReports_InvalidOffset(t *testing.T) {
 	}
 }
 
+func TestGetReports_MaxOffset(t *testing.T) {
+	handler := setupTestReportHandler()
+	handler.SetPageSize(validation.PageSize{Default: 20, Max: 100, MaxOffset: 500})
+
+	w := httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports?offset=500", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d at the maximum offset, got %d", http.StatusOK, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports?offset=501", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d above the maximum offset, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var errorResp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&errorResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errorResp.Code != "OFFSET_TOO_LARGE" {
+		t.Errorf("Expected error code 'OFFSET_TOO_LARGE', got '%s'", errorResp.Code)
+	}
+	if !strings.Contains(errorResp.Error, "500") || !strings.Contains(errorResp.Error, "cursor pagination") {
+		t.Errorf("Expected the limit and a cursor pagination hint in the message, got %q", errorResp.Error)
+	}
+}
+
 func TestGetReports_InvalidAgeRange(t *testing.T) {
 	handler := setupTestReportHandler()
 
@@ -255,6 +471,42 @@ func TestGetReports_InvalidAgeRange(t *testing.T) {
 	}
 }
 
+func TestGetReports_TooManyParameters(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	params := url.Values{}
+	for i := 0; i < 100; i++ {
+		params.Add("p"+strconv.Itoa(i), "x")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/reports?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	var errorResp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errorResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errorResp.Code != "TOO_MANY_PARAMETERS" {
+		t.Errorf("Expected error code TOO_MANY_PARAMETERS, got %q", errorResp.Code)
+	}
+	if dbService.lastParams.Limit != 0 {
+		t.Error("Expected the database not to be queried")
+	}
+
+	// Disabling the cap lets the same request through
+	handler.SetMaxQueryParams(0)
+	w = httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodGet, "/reports?"+params.Encode(), nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d with the cap disabled, got %d", http.StatusOK, w.Code)
+	}
+}
+
 func TestGetReports_InvalidMethod(t *testing.T) {
 	handler := setupTestReportHandler()
 
@@ -345,6 +597,133 @@ func TestParseAndValidateReportsQueryParams_ValidParameters(t *testing.T) {
 	}
 }
 
+func TestParseAndValidateReportsQueryParams_LastWindow(t *testing.T) {
+	handler := setupTestReportHandler()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	handler.now = func() time.Time { return now }
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?last=7d", nil)
+	parsedParams, err := handler.parseAndValidateReportsQueryParams(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC).Unix()
+	if parsedParams.StartDate == nil || *parsedParams.StartDate != want {
+		t.Errorf("Expected start_date %d, got %v", want, parsedParams.StartDate)
+	}
+	if parsedParams.EndDate != nil {
+		t.Errorf("Expected no end_date, got %d", *parsedParams.EndDate)
+	}
+}
+
+func TestParseAndValidateReportsQueryParams_LastConflictsWithDates(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	for _, query := range []string{"last=7d&start_date=1609459200", "last=24h&end_date=1640995200"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports?"+query, nil)
+		_, err := handler.parseAndValidateReportsQueryParams(req)
+
+		var userErr *pkgerrors.UserError
+		if !errors.As(err, &userErr) || userErr.Code != "CONFLICTING_DATE_PARAMETERS" {
+			t.Errorf("%s: expected CONFLICTING_DATE_PARAMETERS, got %v", query, err)
+		}
+	}
+}
+
+func TestGetReports_ExactAge(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?age=30", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if dbService.lastParams.MinAge == nil || *dbService.lastParams.MinAge != 30 {
+		t.Errorf("Expected min_age 30 at the database layer, got %v", dbService.lastParams.MinAge)
+	}
+	if dbService.lastParams.MaxAge == nil || *dbService.lastParams.MaxAge != 30 {
+		t.Errorf("Expected max_age 30 at the database layer, got %v", dbService.lastParams.MaxAge)
+	}
+}
+
+func TestGetReports_ExactAgeConflictsWithRange(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	for _, query := range []string{"age=30&min_age=18", "age=30&max_age=65", "min_age=18&max_age=65&age=30"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.GetReports(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+			continue
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", query, err)
+		}
+		if response.Code != "CONFLICTING_AGE_PARAMETERS" {
+			t.Errorf("%s: expected CONFLICTING_AGE_PARAMETERS, got %s", query, response.Code)
+		}
+	}
+}
+
+func TestParseAndValidateReportsQueryParams_InvalidExactAge(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	for _, value := range []string{"0", "121", "-5", "thirty", "30.5"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports?age="+value, nil)
+		_, err := handler.parseAndValidateReportsQueryParams(req)
+
+		var userErr *pkgerrors.UserError
+		if !errors.As(err, &userErr) || userErr.Code != "INVALID_AGE_PARAMETER" {
+			t.Errorf("age=%s: expected INVALID_AGE_PARAMETER, got %v", value, err)
+		}
+	}
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"30d": 30 * 24 * time.Hour,
+		"90m": 90 * time.Minute,
+	}
+	for value, want := range valid {
+		got, err := parseRelativeDuration(value)
+		if err != nil || got != want {
+			t.Errorf("parseRelativeDuration(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"d", "7", "0d", "-3d", "1.5d", "week", "36501d", "300000d", "9223372036854775807d", "876001h"} {
+		if _, err := parseRelativeDuration(value); err == nil {
+			t.Errorf("parseRelativeDuration(%q) expected an error", value)
+		}
+	}
+}
+
+func TestParseAndValidateReportsQueryParams_LastTooLong(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?last=300000d", nil)
+	_, err := handler.parseAndValidateReportsQueryParams(req)
+
+	var userErr *pkgerrors.UserError
+	if !errors.As(err, &userErr) || userErr.Code != "INVALID_LAST_PARAMETER" {
+		t.Fatalf("Expected INVALID_LAST_PARAMETER, got %v", err)
+	}
+	if userErr.GetHTTPStatus() != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", userErr.GetHTTPStatus())
+	}
+}
+
 func TestParseAndValidateReportsQueryParams_DefaultValues(t *testing.T) {
 	handler := setupTestReportHandler()
 
@@ -448,3 +827,79 @@ func TestValidateGetReportsParams_InvalidAgeRange(t *testing.T) {
 		t.Errorf("Expected error to mention age parameters, got: %v", err)
 	}
 }
+
+func TestValidateGetReportsParams_ConfiguredAgeBounds(t *testing.T) {
+	handler := setupTestReportHandler()
+	handler.SetAgeBounds(validation.AgeBounds{Min: 18, Max: 65})
+
+	minAge := 16
+	params := &GetReportsRequestParams{
+		Limit:  10,
+		Offset: 0,
+		MinAge: &minAge,
+	}
+
+	err := handler.validateGetReportsParams(params)
+	if err == nil {
+		t.Fatal("Expected error for min_age below configured bounds")
+	}
+	if !strings.Contains(err.Error(), "between 18 and 65") {
+		t.Errorf("Expected error to mention configured bounds, got: %v", err)
+	}
+}
+
+func TestGetReports_LocalizedValidationMessage(t *testing.T) {
+	handler := setupTestReportHandler()
+
+	messages := make(map[string]ErrorResponse)
+	for _, acceptLanguage := range []string{"", "en-US", "ru-RU,ru;q=0.9"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports?limit=0", nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.GetReports(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+		var errorResp ErrorResponse
+		if err := json.NewDecoder(w.Body).Decode(&errorResp); err != nil {
+			t.Fatalf("Failed to decode error response: %v", err)
+		}
+		messages[acceptLanguage] = errorResp
+	}
+
+	if messages[""].Code != "INVALID_LIMIT_PARAMETER" || messages["ru-RU,ru;q=0.9"].Code != "INVALID_LIMIT_PARAMETER" {
+		t.Errorf("Expected the same error code in every locale, got %+v", messages)
+	}
+	if messages[""].Error != "Invalid limit parameter. Must be between 1 and 100" || messages["en-US"].Error != messages[""].Error {
+		t.Errorf("Expected English message by default, got %q and %q", messages[""].Error, messages["en-US"].Error)
+	}
+	if messages["ru-RU,ru;q=0.9"].Error != "Недопустимый параметр limit. Ожидается число от 1 до 100" {
+		t.Errorf("Expected Russian message, got %q", messages["ru-RU,ru;q=0.9"].Error)
+	}
+	if messages["ru-RU,ru;q=0.9"].Details == "" {
+		t.Error("Expected limit details to be derived from the error code in every locale")
+	}
+}
+
+func TestGetReports_HeadSetsTotalCountWithoutBody(t *testing.T) {
+	handler := setupTestReportHandler()
+	dbService := handler.dbService.(*MockDatabaseService)
+	dbService.users = []models.User{{ID: "1", FirstName: "John", LastName: "Doe", Age: 30}}
+	dbService.totalCount = 45
+
+	w := httptest.NewRecorder()
+	handler.GetReports(w, httptest.NewRequest(http.MethodHead, "/reports", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("X-Total-Count"); got != "45" {
+		t.Errorf("Expected X-Total-Count 45, got %q", got)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected no body for HEAD, got %q", w.Body.String())
+	}
+}
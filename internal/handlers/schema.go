@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	stderrors "errors"
+	"sort"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// createUserSchemaJSON is the JSON Schema of CreateUserRequest checked when strict schema validation is on
+//
+//go:embed schemas/create_user.json
+var createUserSchemaJSON string
+
+// createUserSchema is compiled once at startup; the embedded schema is part of the binary, so a
+// compile failure is a programming error
+var createUserSchema = jsonschema.MustCompileString("create_user.json", createUserSchemaJSON)
+
+// SchemaViolation is one failing location of a request body checked against its JSON Schema
+type SchemaViolation struct {
+	Field  string `json:"field"`  // JSON pointer (RFC 6901) to the failing value; "" is the whole body
+	Reason string `json:"reason"` // Why the value does not match the schema
+}
+
+// schemaValidationError carries every schema violation of a request body
+type schemaValidationError struct {
+	violations []SchemaViolation
+}
+
+func (e *schemaValidationError) Error() string {
+	return "request body does not match the schema: " + e.violations[0].Field + ": " + e.violations[0].Reason
+}
+
+// validateSchema checks body against schema and returns a *schemaValidationError listing every violation
+// Bodies that are not valid JSON pass through, so the regular decoder reports them with its usual codes.
+func validateSchema(schema *jsonschema.Schema, body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil
+	}
+
+	err := schema.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !stderrors.As(err, &validationErr) {
+		return err
+	}
+
+	var violations []SchemaViolation
+	collectSchemaViolations(validationErr, &violations)
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Field < violations[j].Field
+	})
+	return &schemaValidationError{violations: violations}
+}
+
+// collectSchemaViolations appends the leaves of the error tree; inner nodes only summarise their causes
+func collectSchemaViolations(err *jsonschema.ValidationError, violations *[]SchemaViolation) {
+	if len(err.Causes) == 0 {
+		*violations = append(*violations, SchemaViolation{Field: err.InstanceLocation, Reason: err.Message})
+		return
+	}
+	for _, cause := range err.Causes {
+		collectSchemaViolations(cause, violations)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postUser(handler *UserHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/users", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.CreateUser(w, req)
+	return w
+}
+
+func TestCreateUserStrictSchemaViolations(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetStrictSchema(true)
+
+	w := postUser(handler, `{"first_name": "", "last_name": "Doe", "age": "thirty", "nickname": "JD"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, "SCHEMA_VALIDATION_FAILED", response.Code)
+	fields := make([]string, 0, len(response.Violations))
+	for _, violation := range response.Violations {
+		assert.NotEmpty(t, violation.Reason)
+		fields = append(fields, violation.Field)
+	}
+	assert.Equal(t, []string{"", "/age", "/first_name"}, fields, "violations must carry JSON-pointer paths; \"\" is the unknown root property")
+	assert.Empty(t, mockDB.createdUsers, "invalid request must not reach the database")
+}
+
+func TestCreateUserStrictSchemaRangeViolation(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+	handler.SetStrictSchema(true)
+
+	w := postUser(handler, `{"first_name": "John", "last_name": "Doe", "age": 121}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Violations, 1)
+	assert.Equal(t, "/age", response.Violations[0].Field)
+	assert.Contains(t, response.Violations[0].Reason, "120")
+}
+
+func TestCreateUserStrictSchemaValidBody(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetStrictSchema(true)
+
+	w := postUser(handler, `{"first_name": "John", "last_name": "Doe", "age": 30}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, mockDB.createdUsers, 1)
+}
+
+func TestCreateUserStrictSchemaLeavesMalformedJSONToDecoder(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+	handler.SetStrictSchema(true)
+
+	w := postUser(handler, `{"first_name": "John",`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_JSON", response.Code)
+}
+
+func TestCreateUserLaxSchemaKeepsManualValidation(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	w := postUser(handler, `{"first_name": "John", "last_name": "Doe", "age": 121}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_AGE_RANGE", response.Code)
+	assert.Empty(t, response.Violations)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/audit"
+	"github.com/chybatronik/goUserAPI/internal/errors"
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
+	"github.com/chybatronik/goUserAPI/internal/models"
+	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+)
+
+// UpsertUser handles PUT /users: the body of POST /users, where email is required and is the natural key
+// A new email creates the user (201); a known one updates its name and age (200). recording_date is only
+// applied on insert.
+func (h *UserHandler) UpsertUser(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	logger := h.logger.WithRequestID(h.extractRequestID(r)).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
+
+	if r.Method != http.MethodPut {
+		logger.Warn("Invalid HTTP method for user upsert",
+			"method", r.Method,
+			"expected_method", "PUT",
+		)
+		MethodNotAllowed(w, r, UsersMethods)
+		return
+	}
+
+	req, ok := h.readUserRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	if req.Email == "" {
+		logger.Warn("User upsert without the email natural key")
+		h.writeErrorResponse(w, http.StatusBadRequest, "MISSING_REQUIRED_FIELD", "Missing required field: email", "field: email")
+		return
+	}
+
+	user, inserted, err := h.dbService.UpsertUser(r.Context(), h.pool, h.convertToModel(req))
+	if err != nil {
+		logger.Error("Failed to upsert user in database", logging.FieldError, err)
+
+		// SECURITY: Use secure error mapping to prevent information leakage (NFR-S3 compliance)
+		secureErr := errors.MapDatabaseErrorSecure(err)
+		if userErr, ok := secureErr.(*pkgerrors.UserError); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "Database operation failed", "")
+		}
+		return
+	}
+
+	status, action := http.StatusOK, audit.ActionUserUpdate
+	if inserted {
+		status, action = http.StatusCreated, audit.ActionUserCreate
+	}
+	audit.Record(r.Context(), action, user.ID, r.Header.Get(audit.APIKeyHeader))
+
+	h.writeUpsertResponse(w, user, status, wantsPrettyJSON(r, h.prettyJSON))
+
+	logger.Info("User upsert request completed",
+		"duration_ms", time.Since(startTime).Milliseconds(),
+		"status_code", status,
+		"user_id", user.ID,
+		"inserted", inserted,
+	)
+}
+
+// writeUpsertResponse writes the upserted user with status and a Location header for it
+func (h *UserHandler) writeUpsertResponse(w http.ResponseWriter, user *models.User, status int, pretty bool) {
+	w.Header().Set("Location", "/users/"+user.ID)
+	if err := writeJSON(w, status, toUserResponse(*user, dateFormatUnix), pretty); err != nil {
+		h.logger.Error("Failed to encode upsert response",
+			logging.FieldError, err,
+			"user_id", user.ID,
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
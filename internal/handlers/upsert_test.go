@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUpsertRequest(t *testing.T, body map[string]interface{}) *http.Request {
+	t.Helper()
+	bodyBytes, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestUpsertUserInsertsThenUpdates(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	first := httptest.NewRecorder()
+	handler.UpsertUser(first, newUpsertRequest(t, map[string]interface{}{
+		"first_name": "Jane", "last_name": "Doe", "age": 30, "email": "Jane@Example.com",
+	}))
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+
+	var created UserResponse
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &created))
+	assert.Equal(t, "jane@example.com", created.Email, "emails are stored normalized")
+	assert.Equal(t, "/users/"+created.ID, first.Header().Get("Location"))
+
+	second := httptest.NewRecorder()
+	handler.UpsertUser(second, newUpsertRequest(t, map[string]interface{}{
+		"first_name": "Janet", "last_name": "Smith", "age": 31, "email": "jane@example.com",
+	}))
+	require.Equal(t, http.StatusOK, second.Code, second.Body.String())
+
+	var updated UserResponse
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &updated))
+	assert.Equal(t, created.ID, updated.ID)
+	assert.Equal(t, "Janet", updated.FirstName)
+	assert.Equal(t, "Smith", updated.LastName)
+	assert.Equal(t, 31, updated.Age)
+	assert.Len(t, mockDB.createdUsers, 1, "the update must not insert a second user")
+}
+
+func TestUpsertUserRequiresEmail(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	w := httptest.NewRecorder()
+	handler.UpsertUser(w, newUpsertRequest(t, map[string]interface{}{"first_name": "Jane", "last_name": "Doe", "age": 30}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResp))
+	assert.Equal(t, "MISSING_REQUIRED_FIELD", errorResp.Code)
+	assert.Equal(t, "field: email", errorResp.Details)
+	assert.Empty(t, mockDB.createdUsers)
+}
+
+func TestUpsertUserRejectsInvalidEmail(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	w := httptest.NewRecorder()
+	handler.UpsertUser(w, newUpsertRequest(t, map[string]interface{}{
+		"first_name": "Jane", "last_name": "Doe", "age": 30, "email": "Jane <jane@example.com>",
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResp))
+	assert.Equal(t, "INVALID_EMAIL", errorResp.Code)
+}
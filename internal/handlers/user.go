@@ -2,15 +2,21 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/chybatronik/goUserAPI/internal/audit"
+	"github.com/chybatronik/goUserAPI/internal/database"
 	"github.com/chybatronik/goUserAPI/internal/errors"
 	"github.com/chybatronik/goUserAPI/internal/logging"
 	"github.com/chybatronik/goUserAPI/internal/middleware"
@@ -18,14 +24,20 @@ import (
 	"github.com/chybatronik/goUserAPI/internal/types"
 	"github.com/chybatronik/goUserAPI/internal/validation"
 	pkgerrors "github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/text/unicode/norm"
 )
 
 // DatabaseService defines the interface for database operations
 type DatabaseService interface {
 	CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error)
+	UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error)
 	GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error)
+	GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error)
+	GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error)
 	GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error)
+	StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error
 }
 
 // UserHandler handles HTTP requests for user operations
@@ -33,6 +45,25 @@ type UserHandler struct {
 	pool      *pgxpool.Pool
 	logger    *logging.Logger
 	dbService DatabaseService
+
+	// aggregateValidationErrors reports all failing fields at once instead of only the first
+	aggregateValidationErrors bool
+	ageBounds                 validation.AgeBounds
+	pageSize                  validation.PageSize
+	limits                    validation.Limits
+	nameNormalizer            validation.Normalizer
+
+	// importAPIKey is the X-API-Key that allows a request to set recording_date; empty allows none
+	importAPIKey string
+
+	// idempotency remembers Idempotency-Key values so retried creates are not inserted twice
+	idempotency *idempotencyStore
+
+	// prettyJSON allows ?pretty=true to indent responses (disabled in production)
+	prettyJSON bool
+
+	// strictSchema checks request bodies against the embedded JSON Schema before the manual validation
+	strictSchema bool
 }
 
 // NewUserHandler creates a new UserHandler instance
@@ -41,21 +72,78 @@ func NewUserHandler(logger *logging.Logger, pool *pgxpool.Pool, dbService Databa
 		pool:      pool,
 		logger:    logger,
 		dbService: dbService,
+		ageBounds: validation.DefaultAgeBounds,
+		pageSize:  validation.DefaultPageSize,
+		limits:    validation.DefaultLimits,
+
+		nameNormalizer: validation.DefaultNormalizer,
+		idempotency:    newIdempotencyStore(defaultIdempotencyTTL),
 	}
 }
 
+// SetAggregateValidationErrors switches CreateUser between reporting only the first
+// failing field (default) and a single VALIDATION_FAILED response listing every field
+func (h *UserHandler) SetAggregateValidationErrors(enabled bool) {
+	h.aggregateValidationErrors = enabled
+}
+
+// SetLimits configures the maximum name length and request body size for user creation
+func (h *UserHandler) SetLimits(limits validation.Limits) {
+	h.limits = limits
+}
+
+// SetAgeBounds configures the accepted age range for user creation
+func (h *UserHandler) SetAgeBounds(bounds validation.AgeBounds) {
+	h.ageBounds = bounds
+}
+
+// SetNameNormalizer configures how first and last names are rewritten before validation
+func (h *UserHandler) SetNameNormalizer(normalizer validation.Normalizer) {
+	h.nameNormalizer = normalizer
+}
+
+// SetImportAPIKey configures the X-API-Key that lets POST /users import a recording_date
+func (h *UserHandler) SetImportAPIKey(key string) {
+	h.importAPIKey = key
+}
+
+// SetPageSize configures the default and maximum limit for GET /users
+func (h *UserHandler) SetPageSize(size validation.PageSize) {
+	h.pageSize = size
+}
+
+// SetPrettyJSON allows clients to request indented JSON with ?pretty=true
+func (h *UserHandler) SetPrettyJSON(allowed bool) {
+	h.prettyJSON = allowed
+}
+
+// SetStrictSchema enables JSON Schema validation of request bodies, reported as SCHEMA_VALIDATION_FAILED
+func (h *UserHandler) SetStrictSchema(enabled bool) {
+	h.strictSchema = enabled
+}
+
 // CreateUserRequest represents the request body for creating a user
 type CreateUserRequest struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Age       int    `json:"age"`
+
+	// RecordingDate overrides the server-assigned Unix timestamp; only trusted import clients may set it
+	RecordingDate *int64 `json:"recording_date,omitempty"`
+
+	// Email is optional for POST and required by PUT /users, which upserts on it; unique per tenant
+	Email string `json:"email,omitempty"`
 }
 
 // ErrorResponse represents the unified error response format
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code"`
-	Details string `json:"details,omitempty"`
+	Error   string       `json:"error"`
+	Code    string       `json:"code"`
+	Details string       `json:"details,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+
+	// Violations lists every JSON Schema violation (SCHEMA_VALIDATION_FAILED only)
+	Violations []SchemaViolation `json:"violations,omitempty"`
 }
 
 // writeErrorResponse writes a unified error response
@@ -105,97 +193,281 @@ func (h *UserHandler) parseRequestBody(r *http.Request) (*CreateUserRequest, err
 		return nil, pkgerrors.NewUserValidationError("EMPTY_REQUEST_BODY", "Request body cannot be empty")
 	}
 
-	// Read and limit request body size (1MB limit)
+	// Read request body; the MaxBodySize middleware bounds every request, and reading one byte
+	// past the configured payload limit is enough to reject larger bodies
 	defer r.Body.Close()
-	body, err := io.ReadAll(io.LimitReader(r.Body, 1048576))
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.limits.MaxPayloadBytes+1))
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			return nil, payloadTooLargeError(maxBytesErr.Limit)
+		}
 		return nil, pkgerrors.NewUserValidationError("EMPTY_REQUEST_BODY", "Failed to read request body")
 	}
+	if err := h.limits.ValidatePayload(body); err != nil {
+		return nil, payloadTooLargeError(h.limits.MaxPayloadBytes)
+	}
 
 	// Check for empty body after read
 	if len(body) == 0 {
 		return nil, pkgerrors.NewUserValidationError("EMPTY_REQUEST_BODY", "Request body cannot be empty")
 	}
 
-	// Parse JSON
+	// An array, string or other non-object root would otherwise decode into zero-value fields
+	if kind := jsonRootKind(body); kind != "" && kind != "object" {
+		return nil, pkgerrors.NewUserValidationError("INVALID_JSON_STRUCTURE", "Request body must be a JSON object, got "+kind)
+	}
+
+	if h.strictSchema {
+		if err := validateSchema(createUserSchema, body); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse JSON strictly: unknown fields and wrongly typed values are rejected
+	// instead of being silently dropped or zeroed
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
 	var req CreateUserRequest
-	if err := json.Unmarshal(body, &req); err != nil {
-		return nil, pkgerrors.NewUserValidationError("INVALID_JSON", "Invalid JSON format")
+	if err := decoder.Decode(&req); err != nil {
+		return nil, mapJSONDecodeError(err)
+	}
+
+	// Reject trailing data after the JSON object
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return nil, pkgerrors.NewUserValidationError("INVALID_JSON", "Request body must contain a single JSON object")
 	}
 
 	return &req, nil
 }
 
+// jsonRootKind names the JSON type at the root of body ("object", "array", "string", "number", "boolean"
+// or "null"), or returns "" when body is not valid JSON so the decoder reports the syntax error
+func jsonRootKind(body []byte) string {
+	if !json.Valid(body) {
+		return ""
+	}
+	switch bytes.TrimLeft(body, " \t\r\n")[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// payloadTooLargeError reports a request body above limit bytes
+func payloadTooLargeError(limit int64) *pkgerrors.UserError {
+	return &pkgerrors.UserError{
+		Code:       "PAYLOAD_TOO_LARGE",
+		Message:    fmt.Sprintf("Request body too large. Must not exceed %d bytes", limit),
+		HTTPStatus: http.StatusRequestEntityTooLarge,
+	}
+}
+
+// mapJSONDecodeError converts a json.Decoder error into a client-facing validation error
+func mapJSONDecodeError(err error) *pkgerrors.UserError {
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) && typeErr.Field != "" {
+		return pkgerrors.NewUserValidationError("INVALID_FIELD_TYPE",
+			fmt.Sprintf("Invalid type for field %s: expected %s", typeErr.Field, typeErr.Type.String()))
+	}
+
+	// encoding/json has no typed error for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return pkgerrors.NewUserValidationError("UNKNOWN_FIELD", "Unknown field: "+strings.Trim(field, `"`))
+	}
+
+	return pkgerrors.NewUserValidationError("INVALID_JSON", "Invalid JSON format")
+}
+
+// FieldError describes a single failing field in a validation error response
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 // validateUserRequest validates the user request data with security checks
-func (h *UserHandler) validateUserRequest(req *CreateUserRequest) error {
+// It returns every failing field, at most one error per field, in check order
+func (h *UserHandler) validateUserRequest(req *CreateUserRequest) []FieldError {
+	var fieldErrors []FieldError
+	failed := make(map[string]bool)
+	addError := func(field, code, message string) {
+		failed[field] = true
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Code: code, Message: message})
+	}
+
 	// Check for missing required fields
 	if req.FirstName == "" {
-		return pkgerrors.NewUserValidationError("MISSING_REQUIRED_FIELD", "Missing required field: first_name")
+		addError("first_name", "MISSING_REQUIRED_FIELD", "Missing required field: first_name")
 	}
 	if req.LastName == "" {
-		return pkgerrors.NewUserValidationError("MISSING_REQUIRED_FIELD", "Missing required field: last_name")
+		addError("last_name", "MISSING_REQUIRED_FIELD", "Missing required field: last_name")
 	}
 
-	// Trim whitespace from string fields
-	req.FirstName = strings.TrimSpace(req.FirstName)
-	req.LastName = strings.TrimSpace(req.LastName)
+	// Apply the configured normalization (trim by default) before any content checks
+	req.FirstName = h.nameNormalizer.Normalize(req.FirstName)
+	req.LastName = h.nameNormalizer.Normalize(req.LastName)
 
-	// Validate fields are not empty after trimming
-	if req.FirstName == "" {
-		return pkgerrors.NewUserValidationError("EMPTY_FIELD_AFTER_TRIM", "First name cannot be empty after removing whitespace")
+	// Validate fields are not blank, even when normalization keeps surrounding whitespace
+	if !failed["first_name"] && strings.TrimSpace(req.FirstName) == "" {
+		addError("first_name", "EMPTY_FIELD_AFTER_TRIM", "First name cannot be empty after removing whitespace")
 	}
-	if req.LastName == "" {
-		return pkgerrors.NewUserValidationError("EMPTY_FIELD_AFTER_TRIM", "Last name cannot be empty after removing whitespace")
+	if !failed["last_name"] && strings.TrimSpace(req.LastName) == "" {
+		addError("last_name", "EMPTY_FIELD_AFTER_TRIM", "Last name cannot be empty after removing whitespace")
 	}
 
-	// SECURITY: Unicode security validation (NFR-S2 compliance)
-	if err := validation.ValidateUnicodeSecurity(req.FirstName); err != nil {
-		h.logger.Warn("Unicode security validation failed for first_name",
-			"field", "first_name",
-			"error", err.Error(),
-		)
-		return pkgerrors.NewUserValidationError("UNICODE_SECURITY_VIOLATION", "Invalid characters in first name")
+	// SECURITY: Unicode security and length validation of both names at once (NFR-S2 compliance)
+	nameFields := make(map[string]string, 2)
+	if !failed["first_name"] {
+		nameFields["first_name"] = req.FirstName
 	}
-
-	if err := validation.ValidateUnicodeSecurity(req.LastName); err != nil {
-		h.logger.Warn("Unicode security validation failed for last_name",
-			"field", "last_name",
-			"error", err.Error(),
-		)
-		return pkgerrors.NewUserValidationError("UNICODE_SECURITY_VIOLATION", "Invalid characters in last name")
+	if !failed["last_name"] {
+		nameFields["last_name"] = req.LastName
+	}
+	for _, fieldErr := range h.validateNameFields(nameFields) {
+		addError(fieldErr.Field, fieldErr.Code, fieldErr.Message)
 	}
 
-	// Validate field lengths (after security validation)
-	if len(req.FirstName) > 100 {
-		return pkgerrors.NewUserValidationError("INVALID_FIELD_LENGTH", "First name cannot exceed 100 characters")
+	// Store names in NFC so composed and decomposed spellings are the same record
+	// This runs after the NFKC-based security checks, so it cannot change their outcome
+	if !failed["first_name"] {
+		req.FirstName = norm.NFC.String(req.FirstName)
 	}
-	if len(req.LastName) > 100 {
-		return pkgerrors.NewUserValidationError("INVALID_FIELD_LENGTH", "Last name cannot exceed 100 characters")
+	if !failed["last_name"] {
+		req.LastName = norm.NFC.String(req.LastName)
 	}
 
 	// Validate age range
-	if req.Age < 1 || req.Age > 120 {
-		return pkgerrors.NewUserValidationError("INVALID_AGE_RANGE", "Age must be between 1 and 120")
+	if err := validation.ValidateAge(req.Age, h.ageBounds.Min, h.ageBounds.Max); err != nil {
+		addError("age", validation.CodeInvalidAgeRange, "Age must be "+h.ageBounds.String())
 	}
 
-	return nil
+	// Emails are compared as natural keys, so store them trimmed and lowercased
+	if req.Email != "" {
+		req.Email = validation.NormalizeEmail(req.Email)
+		if err := validation.ValidateEmail(req.Email); err != nil {
+			addError("email", validation.CodeInvalidEmail, "Email must be a valid address such as jane@example.com")
+		}
+	}
+
+	// Validate an imported recording date
+	if req.RecordingDate != nil {
+		if err := validation.ValidateRecordingDate(*req.RecordingDate, time.Now()); err != nil {
+			addError("recording_date", validation.CodeInvalidRecordingDate, "Recording date must be a Unix timestamp between 2000-01-01 and now")
+		}
+	}
+
+	return fieldErrors
+}
+
+// nameFieldLabels are the human-readable names used in name field error messages
+var nameFieldLabels = map[string]string{
+	"first_name": "First name",
+	"last_name":  "Last name",
+}
+
+// validateNameFields checks length and Unicode safety of every given name field against the
+// configured limits, returning one FieldError per failing field in field order
+func (h *UserHandler) validateNameFields(fields map[string]string) []FieldError {
+	var fieldErrors []FieldError
+	for _, err := range h.limits.ValidateNameFields(fields) {
+		var fieldErr *validation.FieldValidationError
+		if !stderrors.As(err, &fieldErr) {
+			continue
+		}
+
+		label := nameFieldLabels[fieldErr.Field]
+		switch fieldErr.Code {
+		case validation.CodeFieldTooLong:
+			fieldErrors = append(fieldErrors, FieldError{Field: fieldErr.Field, Code: fieldErr.Code,
+				Message: fmt.Sprintf("%s cannot exceed %d characters", label, h.limits.MaxNameLength)})
+		case validation.CodeUnicodeFormatChar:
+			h.logger.Warn("Unicode format character in "+fieldErr.Field, "field", fieldErr.Field)
+			fieldErrors = append(fieldErrors, FieldError{Field: fieldErr.Field, Code: fieldErr.Code,
+				Message: label + " cannot contain invisible formatting characters such as zero-width spaces or bidi overrides"})
+		case validation.CodeDisallowedScript:
+			fieldErrors = append(fieldErrors, FieldError{Field: fieldErr.Field, Code: fieldErr.Code,
+				Message: fmt.Sprintf("%s may only use the %s script(s)", label, h.limits.Scripts)})
+		default:
+			h.logger.Warn("Unicode security validation failed for "+fieldErr.Field,
+				"field", fieldErr.Field,
+				"error", err.Error(),
+			)
+			fieldErrors = append(fieldErrors, FieldError{Field: fieldErr.Field, Code: fieldErr.Code,
+				Message: "Invalid characters in " + strings.ToLower(label)})
+		}
+	}
+	return fieldErrors
+}
+
+// writeValidationErrorResponse writes all field errors in a single VALIDATION_FAILED response
+func (h *UserHandler) writeValidationErrorResponse(w http.ResponseWriter, fieldErrors []FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	errorResp := ErrorResponse{
+		Error:  "User input validation failed",
+		Code:   "VALIDATION_FAILED",
+		Fields: fieldErrors,
+	}
+
+	if err := json.NewEncoder(w).Encode(errorResp); err != nil {
+		h.logger.Error("Failed to encode validation error response",
+			logging.FieldError, err,
+			"field_error_count", len(fieldErrors),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// writeSchemaErrorResponse writes a SCHEMA_VALIDATION_FAILED response listing every schema violation
+func (h *UserHandler) writeSchemaErrorResponse(w http.ResponseWriter, violations []SchemaViolation) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	errorResp := ErrorResponse{
+		Error:      "Request body does not match the schema",
+		Code:       "SCHEMA_VALIDATION_FAILED",
+		Violations: violations,
+	}
+
+	if err := json.NewEncoder(w).Encode(errorResp); err != nil {
+		h.logger.Error("Failed to encode schema error response",
+			logging.FieldError, err,
+			"violation_count", len(violations),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
 }
 
 // convertToModel converts request to User model
 func (h *UserHandler) convertToModel(req *CreateUserRequest) *models.User {
-	return &models.User{
+	user := &models.User{
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Age:       req.Age,
+		Email:     req.Email,
+	}
+	if req.RecordingDate != nil {
+		user.RecordingDate = *req.RecordingDate
 	}
+	return user
 }
 
-// writeSuccessResponse writes a successful user creation response
-func (h *UserHandler) writeSuccessResponse(w http.ResponseWriter, user *models.User) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	if err := json.NewEncoder(w).Encode(user); err != nil {
+// writeSuccessResponse writes a successful user creation response with a Location header for the new user
+func (h *UserHandler) writeSuccessResponse(w http.ResponseWriter, user *models.User, pretty bool) {
+	w.Header().Set("Location", "/users/"+user.ID)
+	if err := writeJSON(w, http.StatusCreated, toUserResponse(*user, dateFormatUnix), pretty); err != nil {
 		h.logger.Error("Failed to encode success response",
 			logging.FieldError, err,
 			"user_id", user.ID,
@@ -212,29 +484,9 @@ func (h *UserHandler) extractRequestID(r *http.Request) string {
 	return "unknown"
 }
 
-// CreateUser handles user creation requests
-func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
-	// Start timer for performance monitoring
-	startTime := time.Now()
-	reqID := h.extractRequestID(r)
-	logger := h.logger.WithRequestID(reqID)
-
-	logger.Info("Starting user creation request",
-		"method", r.Method,
-		"path", r.URL.Path,
-		"remote_addr", r.RemoteAddr,
-	)
-
-	// Validate HTTP method - only POST is allowed
-	if r.Method != http.MethodPost {
-		logger.Warn("Invalid HTTP method for user creation",
-			"method", r.Method,
-			"expected_method", "POST",
-		)
-		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only POST method is allowed", "")
-		return
-	}
-
+// readUserRequest checks the Content-Type, decodes and validates a user body for POST and PUT /users,
+// writing the error response itself when the request is rejected
+func (h *UserHandler) readUserRequest(w http.ResponseWriter, r *http.Request, logger *logging.Logger) (*CreateUserRequest, bool) {
 	// Validate Content-Type header
 	if err := h.validateContentType(r); err != nil {
 		logger.Warn("Invalid Content-Type header",
@@ -242,7 +494,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 			"error", err.Error(),
 		)
 		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_CONTENT_TYPE", err.Error(), "Content-Type header must be 'application/json'")
-		return
+		return nil, false
 	}
 
 	// Parse and validate request body
@@ -252,39 +504,104 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 			"error", err.Error(),
 		)
 		// Safe type assertion with fallback
-		if userErr, ok := err.(*pkgerrors.UserError); ok {
-			h.writeErrorResponse(w, http.StatusBadRequest, userErr.Code, userErr.Message, "")
+		var schemaErr *schemaValidationError
+		if stderrors.As(err, &schemaErr) {
+			h.writeSchemaErrorResponse(w, schemaErr.violations)
+		} else if userErr, ok := err.(*pkgerrors.UserError); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
 		} else {
 			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST_BODY",
 				"Invalid request body format", err.Error())
 		}
-		return
+		return nil, false
+	}
+
+	// Only trusted import clients may backdate a user
+	if req.RecordingDate != nil && !middleware.HasAPIKey(r, h.importAPIKey) {
+		logger.Warn("recording_date set without the import API key")
+		h.writeErrorResponse(w, http.StatusForbidden, "FORBIDDEN", "recording_date can only be set by trusted import clients", "field: recording_date")
+		return nil, false
 	}
 
 	// Validate user input fields
-	if err := h.validateUserRequest(req); err != nil {
+	if fieldErrors := h.validateUserRequest(req); len(fieldErrors) > 0 {
 		logger.Warn("User input validation failed",
 			"first_name", req.FirstName,
 			"last_name", req.LastName,
 			"age", req.Age,
-			"error", err.Error(),
+			"field_errors", fieldErrors,
 		)
-		// Safe type assertion with fallback
-		if userErr, ok := err.(*pkgerrors.UserError); ok {
-			details := ""
-			if strings.Contains(userErr.Message, "first_name") {
-				details = "field: first_name"
-			} else if strings.Contains(userErr.Message, "last_name") {
-				details = "field: last_name"
-			}
-			h.writeErrorResponse(w, http.StatusBadRequest, userErr.Code, userErr.Message, details)
+		if h.aggregateValidationErrors {
+			h.writeValidationErrorResponse(w, fieldErrors)
 		} else {
-			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR",
-				"User input validation failed", err.Error())
+			first := fieldErrors[0]
+			h.writeErrorResponse(w, http.StatusBadRequest, first.Code, first.Message, "field: "+first.Field)
 		}
+		return nil, false
+	}
+
+	return req, true
+}
+
+// CreateUser handles user creation requests
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	// Start timer for performance monitoring
+	startTime := time.Now()
+	reqID := h.extractRequestID(r)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
+
+	logger.Info("Starting user creation request",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	// Validate HTTP method - only POST is allowed
+	if r.Method != http.MethodPost {
+		logger.Warn("Invalid HTTP method for user creation",
+			"method", r.Method,
+			"expected_method", "POST",
+		)
+		MethodNotAllowed(w, r, UsersMethods)
+		return
+	}
+
+	req, ok := h.readUserRequest(w, r, logger)
+	if !ok {
 		return
 	}
 
+	// Deduplicate client retries carrying an Idempotency-Key
+	idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+	idempotencyScope := newIdempotencyScope(r.Context(), idempotencyKey)
+	if idempotencyKey != "" {
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			logger.Warn("Idempotency key too long", "key_length", len(idempotencyKey))
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY",
+				fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength), "")
+			return
+		}
+
+		previous, outcome := h.idempotency.begin(idempotencyScope, hashCreateUserRequest(req))
+		switch outcome {
+		case idempotencyReplay:
+			logger.Info("Replaying idempotent user creation", "user_id", previous.ID)
+			w.Header().Set(IdempotencyReplayedHeader, "true")
+			h.writeSuccessResponse(w, previous, wantsPrettyJSON(r, h.prettyJSON))
+			return
+		case idempotencyConflict:
+			logger.Warn("Idempotency key reused with a different request body")
+			h.writeErrorResponse(w, http.StatusConflict, "IDEMPOTENCY_KEY_REUSED",
+				"Idempotency-Key was already used with a different request body", "")
+			return
+		case idempotencyInProgress:
+			logger.Warn("Idempotency key used while the original request is still in progress")
+			h.writeErrorResponse(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS",
+				"A request with this Idempotency-Key is still being processed", "")
+			return
+		}
+	}
+
 	// Convert request to User model
 	user := h.convertToModel(req)
 
@@ -297,6 +614,11 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Create user in database
 	createdUser, err := h.dbService.CreateUser(r.Context(), h.pool, user)
 	if err != nil {
+		if idempotencyKey != "" {
+			// Nothing was created, so let the client retry with the same key
+			h.idempotency.release(idempotencyScope)
+		}
+
 		logger.Error("Failed to create user in database",
 			logging.FieldError, err,
 			"first_name", user.FirstName,
@@ -322,8 +644,14 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		"recording_date", createdUser.RecordingDate,
 	)
 
+	if idempotencyKey != "" {
+		h.idempotency.complete(idempotencyScope, createdUser)
+	}
+
+	audit.Record(r.Context(), audit.ActionUserCreate, createdUser.ID, r.Header.Get(audit.APIKeyHeader))
+
 	// Write success response
-	h.writeSuccessResponse(w, createdUser)
+	h.writeSuccessResponse(w, createdUser, wantsPrettyJSON(r, h.prettyJSON))
 
 	// Log request completion for performance monitoring
 	duration := time.Since(startTime)
@@ -336,38 +664,68 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 // GetUsersRequestParams represents query parameters for GetUsers
 type GetUsersRequestParams struct {
-	Limit     int
-	Offset    int
-	SortBy    string
-	SortOrder string
+	Limit      int
+	Offset     int
+	SortBy     string
+	SortOrder  string
+	Format     string
+	DateFormat string
+	Fields     []string // Fields selected with ?fields=; nil returns every field
+	Locale     string   // Accept-Language locale for validation messages
 }
 
 // GetUsersResponse represents the response format for GetUsers
 type GetUsersResponse struct {
-	Users      []models.User  `json:"users"`
+	Users      []UserResponse `json:"users"`
 	Pagination PaginationInfo `json:"pagination"`
 }
 
+// partialGetUsersResponse is GetUsersResponse with only the fields selected by ?fields= per user
+type partialGetUsersResponse struct {
+	Users      []map[string]any `json:"users"`
+	Pagination PaginationInfo   `json:"pagination"`
+}
+
 // PaginationInfo represents pagination metadata
 type PaginationInfo struct {
 	TotalCount int64 `json:"total_count"`
 	Limit      int   `json:"limit"`
 	Offset     int   `json:"offset"`
 	HasMore    bool  `json:"has_more"`
+	Page       int   `json:"page"`        // 1-based page containing the first returned row
+	TotalPages int64 `json:"total_pages"` // 0 when there are no matching rows
+}
+
+// newPaginationInfo derives has_more and page numbers from the offset, limit and total count
+// An offset that is not a multiple of limit reports the page holding its first row, e.g. offset 25 with
+// limit 10 is page 3. An empty result is page 1 of 0 pages.
+func newPaginationInfo(totalCount int64, limit, offset int) PaginationInfo {
+	info := PaginationInfo{
+		TotalCount: totalCount,
+		Limit:      limit,
+		Offset:     offset,
+		HasMore:    int64(offset+limit) < totalCount,
+		Page:       1,
+	}
+	if limit > 0 {
+		info.Page = offset/limit + 1
+		info.TotalPages = (totalCount + int64(limit) - 1) / int64(limit)
+	}
+	return info
 }
 
 // parseAndValidateQueryParams parses and validates query parameters
 func (h *UserHandler) parseAndValidateQueryParams(r *http.Request) (*GetUsersRequestParams, error) {
-	params := &GetUsersRequestParams{}
+	params := &GetUsersRequestParams{Locale: errors.RequestLocale(r)}
 
 	// Parse limit with default
 	limitStr := r.URL.Query().Get("limit")
 	if limitStr == "" {
-		params.Limit = 20 // default
+		params.Limit = h.pageSize.Default
 	} else {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_LIMIT_PARAMETER", "Invalid limit parameter. Must be between 1 and 100")
+			return nil, localizedValidationError(params.Locale, "INVALID_LIMIT_PARAMETER", h.pageSize.Max)
 		}
 		params.Limit = limit
 	}
@@ -379,7 +737,7 @@ func (h *UserHandler) parseAndValidateQueryParams(r *http.Request) (*GetUsersReq
 	} else {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil {
-			return nil, pkgerrors.NewUserValidationError("INVALID_OFFSET_PARAMETER", "Invalid offset parameter. Must be >= 0")
+			return nil, localizedValidationError(params.Locale, "INVALID_OFFSET_PARAMETER")
 		}
 		params.Offset = offset
 	}
@@ -387,78 +745,105 @@ func (h *UserHandler) parseAndValidateQueryParams(r *http.Request) (*GetUsersReq
 	// Parse sort_by with default
 	sortBy := r.URL.Query().Get("sort_by")
 	if sortBy == "" {
-		params.SortBy = "recording_date" // default
+		params.SortBy = database.DefaultSortField
 	} else {
 		params.SortBy = sortBy
 	}
 
-	// Parse sort_order with default
+	// Parse sort_order, defaulting to the natural direction of the sort field
 	sortOrder := r.URL.Query().Get("sort_order")
 	if sortOrder == "" {
-		params.SortOrder = "desc" // default for recording_date
+		params.SortOrder = database.DefaultSortOrder(params.SortBy)
 	} else {
 		params.SortOrder = sortOrder
 	}
 
+	// Negotiate format from ?format= or the Accept header
+	format, err := parseFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	params.Format = format
+
+	// Parse date_format with default
+	dateFormat, err := parseDateFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	params.DateFormat = dateFormat
+
+	// Parse optional field selection
+	fields, err := parseFields(r, params.Locale)
+	if err != nil {
+		return nil, err
+	}
+	params.Fields = fields
+
 	return params, nil
 }
 
 // validateGetUsersParams validates parsed query parameters against business rules
 func (h *UserHandler) validateGetUsersParams(params *GetUsersRequestParams) error {
-	// Validate limit range (1-100)
-	if params.Limit < 1 || params.Limit > 100 {
-		return pkgerrors.NewUserValidationError("INVALID_LIMIT_PARAMETER",
-			"Invalid limit parameter. Must be between 1 and 100")
+	// Validate limit range against the configured page size
+	if !h.pageSize.Contains(params.Limit) {
+		return localizedValidationError(params.Locale, "INVALID_LIMIT_PARAMETER", h.pageSize.Max)
 	}
 
 	// Validate offset range (>= 0)
 	if params.Offset < 0 {
-		return pkgerrors.NewUserValidationError("INVALID_OFFSET_PARAMETER",
-			"Invalid offset parameter. Must be >= 0")
+		return localizedValidationError(params.Locale, "INVALID_OFFSET_PARAMETER")
 	}
 
-	// Validate sort_by against whitelist
-	validSortFields := []string{"recording_date", "age", "first_name", "last_name"}
-	isValidSortField := false
-	for _, field := range validSortFields {
-		if params.SortBy == field {
-			isValidSortField = true
-			break
-		}
+	// Reject deep pagination; PostgreSQL reads and discards every skipped row
+	if !h.pageSize.AllowsOffset(params.Offset) {
+		return localizedValidationError(params.Locale, "OFFSET_TOO_LARGE", h.pageSize.MaxOffset)
 	}
-	if !isValidSortField {
-		return pkgerrors.NewUserValidationError("INVALID_SORT_FIELD",
-			"Invalid sort_by parameter. Must be one of: recording_date, age, first_name, last_name")
+
+	return validateSortParams(params.Locale, params.SortBy, params.SortOrder)
+}
+
+// validateSortParams validates sort_by and sort_order against the whitelist shared by list endpoints
+func validateSortParams(locale, sortBy, sortOrder string) error {
+	// Validate sort_by against the whitelist shared with the database layer
+	if _, ok := database.AllowedSortFields[sortBy]; !ok {
+		return localizedValidationError(locale, "INVALID_SORT_FIELD", strings.Join(database.SortFieldNames(), ", "))
 	}
 
 	// Validate sort_order
-	if params.SortOrder != "asc" && params.SortOrder != "desc" {
-		return pkgerrors.NewUserValidationError("INVALID_SORT_ORDER",
-			"Invalid sort_order parameter. Must be 'asc' or 'desc'")
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return localizedValidationError(locale, "INVALID_SORT_ORDER")
 	}
 
 	return nil
 }
 
-// writeGetUsersResponse writes a successful GetUsers response with pagination metadata
-func (h *UserHandler) writeGetUsersResponse(w http.ResponseWriter, users []models.User, totalCount int64, limit, offset int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	// Calculate has_more for pagination
-	hasMore := int64(offset+limit) < totalCount
-
-	response := GetUsersResponse{
-		Users: users,
-		Pagination: PaginationInfo{
-			TotalCount: totalCount,
-			Limit:      limit,
-			Offset:     offset,
-			HasMore:    hasMore,
-		},
+// writeGetUsersResponse writes a successful GetUsers response with pagination metadata and Link headers
+// When fields is non-nil each user carries only those fields.
+func (h *UserHandler) writeGetUsersResponse(w http.ResponseWriter, r *http.Request, users []models.User, totalCount int64, limit, offset int, dateFormat string, fields []string, pretty bool) {
+	pagination := newPaginationInfo(totalCount, limit, offset)
+	userResponses := toUserResponses(users, dateFormat)
+	var data any = userResponses
+	var response any = GetUsersResponse{
+		Users:      userResponses,
+		Pagination: pagination,
+	}
+	if fields != nil {
+		selected := make([]map[string]any, 0, len(users))
+		for _, user := range userResponses {
+			selected = append(selected, selectUserFields(user, fields))
+		}
+		data = selected
+		response = partialGetUsersResponse{
+			Users:      selected,
+			Pagination: pagination,
+		}
+	}
+	if envelopeListResponses {
+		response = newListEnvelope(data, pagination)
 	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	setPaginationLinks(w, r.URL, totalCount, limit, offset)
+	if err := writeJSON(w, http.StatusOK, response, pretty); err != nil {
 		h.logger.Error("Failed to encode GetUsers response",
 			logging.FieldError, err,
 			"total_count", totalCount,
@@ -474,7 +859,7 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 	// Start timer for performance monitoring
 	startTime := time.Now()
 	reqID := h.extractRequestID(r)
-	logger := h.logger.WithRequestID(reqID)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
 
 	logger.Info("Starting user retrieval request",
 		"method", r.Method,
@@ -483,14 +868,20 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		"remote_addr", r.RemoteAddr,
 	)
 
-	// Validate HTTP method - only GET is allowed
-	if r.Method != http.MethodGet {
+	// Validate HTTP method - GET, or HEAD for the same response without a body
+	if !isReadMethod(r) {
 		logger.Warn("Invalid HTTP method for user retrieval",
 			"method", r.Method,
 			"expected_method", "GET",
 		)
-		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
-			"Only GET method is allowed", "")
+		MethodNotAllowed(w, r, UsersMethods)
+		return
+	}
+	w = withoutBodyForHead(w, r)
+
+	// A list of IDs turns the request into a batch lookup instead of a page of users
+	if r.URL.Query().Has("ids") {
+		h.getUsersByIDs(w, r, logger, startTime)
 		return
 	}
 
@@ -523,10 +914,13 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		// Safe type assertion with fallback
 		if userErr, ok := err.(*pkgerrors.UserError); ok {
 			details := ""
-			if strings.Contains(userErr.Message, "limit") {
-				details = "parameter: limit, value: " + strconv.Itoa(params.Limit) + ", valid_range: 1-100"
-			} else if strings.Contains(userErr.Message, "sort_by") {
-				details = "parameter: sort_by, value: " + params.SortBy + ", allowed_fields: recording_date,age,first_name,last_name"
+			switch userErr.Code {
+			case "INVALID_LIMIT_PARAMETER":
+				details = "parameter: limit, value: " + strconv.Itoa(params.Limit) + ", valid_range: 1-" + strconv.Itoa(h.pageSize.Max)
+			case "OFFSET_TOO_LARGE":
+				details = "parameter: offset, value: " + strconv.Itoa(params.Offset) + ", max: " + strconv.Itoa(h.pageSize.MaxOffset)
+			case "INVALID_SORT_FIELD":
+				details = "parameter: sort_by, value: " + params.SortBy + ", allowed_fields: " + strings.Join(database.SortFieldNames(), ",")
 			}
 			h.writeErrorResponse(w, http.StatusBadRequest, userErr.Code, userErr.Message, details)
 		} else {
@@ -535,6 +929,7 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	w.Header().Add("Vary", "Accept")
 
 	// Prepare database parameters
 	dbParams := types.GetUsersParams{
@@ -578,8 +973,23 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		"offset", params.Offset,
 	)
 
-	// Write success response
-	h.writeGetUsersResponse(w, users, totalCount, params.Limit, params.Offset)
+	// Tell clients still paging by offset that it is going away, once it is registered as deprecated
+	warnOffsetDeprecated(w, r)
+	setTotalCount(w, totalCount)
+
+	// Write success response in the negotiated format
+	switch params.Format {
+	case formatCSV:
+		if err := writeUsersCSV(w, users, params.DateFormat); err != nil {
+			logger.Error("Failed to write CSV users response", logging.FieldError, err)
+		}
+	case formatNDJSON:
+		if err := writeUsersNDJSON(w, users, params.DateFormat); err != nil {
+			logger.Error("Failed to write NDJSON users response", logging.FieldError, err)
+		}
+	default:
+		h.writeGetUsersResponse(w, r, users, totalCount, params.Limit, params.Offset, params.DateFormat, params.Fields, wantsPrettyJSON(r, h.prettyJSON))
+	}
 
 	// Log request completion for performance monitoring
 	duration := time.Since(startTime)
@@ -590,3 +1000,98 @@ func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 		"total_count", totalCount,
 	)
 }
+
+// userIDPattern matches the canonical textual UUID form used for user IDs
+var userIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// GetUserByID handles single user retrieval requests with conditional GET support
+// Responses carry an ETag; a matching If-None-Match yields 304 Not Modified
+func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	reqID := h.extractRequestID(r)
+	logger := h.logger.WithRequestID(reqID).WithCorrelationID(middleware.GetCorrelationID(r.Context()))
+
+	// Validate HTTP method - GET, or HEAD for the same response without a body
+	if !isReadMethod(r) {
+		logger.Warn("Invalid HTTP method for user lookup",
+			"method", r.Method,
+			"expected_method", "GET",
+		)
+		MethodNotAllowed(w, r, UserMethods)
+		return
+	}
+	w = withoutBodyForHead(w, r)
+
+	id := r.PathValue("id")
+	if !userIDPattern.MatchString(id) {
+		logger.Warn("Invalid user ID in path", "user_id", id)
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_USER_ID",
+			"Invalid user ID. Must be a UUID", "")
+		return
+	}
+
+	dateFormat, err := parseDateFormat(r)
+	if err != nil {
+		logger.Warn("Invalid date_format parameter", "error", err.Error())
+		if userErr, ok := pkgerrors.GetUserError(err); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		}
+		return
+	}
+
+	user, err := h.dbService.GetUserByID(r.Context(), h.pool, id)
+	if err != nil {
+		if stderrors.Is(err, pgx.ErrNoRows) {
+			logger.Info("User not found", "user_id", id)
+			notFound := pkgerrors.NewUserNotFoundError(id)
+			h.writeErrorResponse(w, notFound.GetHTTPStatus(), notFound.Code, notFound.Message, "")
+			return
+		}
+
+		logger.Error("Failed to retrieve user from database",
+			logging.FieldError, err,
+			"user_id", id,
+		)
+
+		// SECURITY: Use secure error mapping to prevent information leakage (NFR-S3 compliance)
+		secureErr := errors.MapDatabaseErrorSecure(err)
+		if userErr, ok := secureErr.(*pkgerrors.UserError); ok {
+			h.writeErrorResponse(w, userErr.GetHTTPStatus(), userErr.Code, userErr.Message, "")
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "Database operation failed", "")
+		}
+		return
+	}
+
+	// The ETag covers the representation, so each date_format gets its own
+	response := toUserResponse(*user, dateFormat)
+	etag, err := computeETag(response)
+	if err != nil {
+		logger.Error("Failed to compute ETag", logging.FieldError, err, "user_id", id)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error", "")
+		return
+	}
+	w.Header().Set("ETag", etag)
+
+	statusCode := http.StatusOK
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		statusCode = http.StatusNotModified
+		w.WriteHeader(statusCode)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.Error("Failed to encode user response",
+				logging.FieldError, err,
+				"user_id", id,
+			)
+		}
+	}
+
+	duration := time.Since(startTime)
+	logger.Info("User lookup request completed",
+		"duration_ms", duration.Milliseconds(),
+		"status_code", statusCode,
+		"user_id", id,
+	)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,10 +13,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/chybatronik/goUserAPI/internal/audit"
+	"github.com/chybatronik/goUserAPI/internal/database"
 	"github.com/chybatronik/goUserAPI/internal/logging"
+	"github.com/chybatronik/goUserAPI/internal/middleware"
 	"github.com/chybatronik/goUserAPI/internal/models"
+	"github.com/chybatronik/goUserAPI/internal/tenant"
 	"github.com/chybatronik/goUserAPI/internal/types"
+	"github.com/chybatronik/goUserAPI/internal/validation"
 	"github.com/chybatronik/goUserAPI/pkg/errors"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +32,8 @@ import (
 type MockDBService struct {
 	shouldFailCreate bool
 	createdUsers     []*models.User
+	usersByID        map[string]*models.User
+	usersByEmail     map[string]*models.User
 }
 
 func (m *MockDBService) CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
@@ -38,17 +47,68 @@ func (m *MockDBService) CreateUser(ctx context.Context, pool *pgxpool.Pool, user
 		FirstName:     user.FirstName,
 		LastName:      user.LastName,
 		Age:           user.Age,
-		RecordingDate: time.Now().Unix(),
+		RecordingDate: user.RecordingDate,
+	}
+	// Like the column default, an unset recording date becomes the current time
+	if newUser.RecordingDate == 0 {
+		newUser.RecordingDate = time.Now().Unix()
 	}
 	m.createdUsers = append(m.createdUsers, newUser)
 	return newUser, nil
 }
 
+// UpsertUser inserts a user under a new email and updates name and age under a known one, like ON CONFLICT (email)
+func (m *MockDBService) UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	if m.shouldFailCreate {
+		return nil, false, errors.NewUserValidationError("DATABASE_QUERY_ERROR", "Database connection failed")
+	}
+	if existing, ok := m.usersByEmail[user.Email]; ok {
+		existing.FirstName, existing.LastName, existing.Age = user.FirstName, user.LastName, user.Age
+		updated := *existing
+		return &updated, false, nil
+	}
+
+	created, err := m.CreateUser(ctx, pool, user)
+	if err != nil {
+		return nil, false, err
+	}
+	created.Email = user.Email
+	if m.usersByEmail == nil {
+		m.usersByEmail = make(map[string]*models.User)
+	}
+	stored := *created
+	m.usersByEmail[user.Email] = &stored
+	return created, true, nil
+}
+
 func (m *MockDBService) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
 	// Return empty slice for tests that don't need GetUsers functionality
 	return []models.User{}, 0, nil
 }
 
+func (m *MockDBService) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	return nil
+}
+
+func (m *MockDBService) GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error) {
+	user, ok := m.usersByID[id]
+	if !ok {
+		return nil, fmt.Errorf("failed to get user by ID %s: %w", id, pgx.ErrNoRows)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *MockDBService) GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error) {
+	users := []models.User{}
+	for _, id := range ids {
+		if user, ok := m.usersByID[id]; ok {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
 func (m *MockDBService) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
 	// Return empty slice for tests that don't need GetReports functionality
 	return []models.User{}, 0, nil
@@ -87,6 +147,231 @@ func TestCreateUserSuccess(t *testing.T) {
 	assert.Equal(t, 30, response.Age)
 	assert.NotEmpty(t, response.ID)
 	assert.NotZero(t, response.RecordingDate)
+	assert.Equal(t, "/users/"+response.ID, w.Header().Get("Location"))
+}
+
+func TestCreateUserStoresNamesInNFC(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	tests := []struct {
+		name      string
+		firstName string
+		lastName  string
+	}{
+		{"decomposed", "Jose\u0301", "Mu\u0308ller"},
+		{"composed", "Jos\u00e9", "M\u00fcller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockDBService{}
+			handler := NewUserHandler(logger, nil, mockDB)
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": tt.firstName, "last_name": tt.lastName, "age": 30})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			require.Len(t, mockDB.createdUsers, 1)
+			assert.Equal(t, "Jos\u00e9", mockDB.createdUsers[0].FirstName)
+			assert.Equal(t, "M\u00fcller", mockDB.createdUsers[0].LastName)
+		})
+	}
+}
+
+func TestCreateUserAppliesNameNormalizer(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	tests := []struct {
+		normalizer    validation.Normalizer
+		wantFirstName string
+	}{
+		{validation.NormalizeTrim, "john"},
+		{validation.NormalizeTrimTitle, "John"},
+		{validation.NormalizeNone, "  john  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.normalizer), func(t *testing.T) {
+			mockDB := &MockDBService{}
+			handler := NewUserHandler(logger, nil, mockDB)
+			handler.SetNameNormalizer(tt.normalizer)
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": "  john  ", "last_name": "Doe", "age": 30})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			require.Len(t, mockDB.createdUsers, 1)
+			assert.Equal(t, tt.wantFirstName, mockDB.createdUsers[0].FirstName)
+		})
+	}
+}
+
+func TestCreateUserRejectsBlankNameWithoutNormalization(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+	handler.SetNameNormalizer(validation.NormalizeNone)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": "   ", "last_name": "Doe", "age": 30})
+	req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateUser(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "EMPTY_FIELD_AFTER_TRIM")
+}
+
+func TestCreateUserAllowedNameScripts(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	latinOnly, err := validation.ParseScriptPolicy([]string{"Latin"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		scripts    validation.ScriptPolicy
+		firstName  string
+		wantStatus int
+	}{
+		{"default permits Cyrillic", validation.ScriptPolicy{}, "Иван", http.StatusCreated},
+		{"Latin policy permits Latin", latinOnly, "John", http.StatusCreated},
+		{"Latin policy rejects Cyrillic", latinOnly, "Иван", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUserHandler(logger, nil, &MockDBService{})
+			limits := validation.DefaultLimits
+			limits.Scripts = tt.scripts
+			handler.SetLimits(limits)
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": tt.firstName, "last_name": "Doe", "age": 30})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus == http.StatusBadRequest {
+				assert.Contains(t, w.Body.String(), validation.CodeDisallowedScript)
+				assert.Contains(t, w.Body.String(), "Latin")
+			}
+		})
+	}
+}
+
+func TestCreateUserRejectsUnicodeFormatCharacters(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	tests := []struct {
+		name      string
+		firstName string
+	}{
+		{"zero-width space", "Jo\u200Bhn"},
+		{"right-to-left override", "John\u202Egnp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUserHandler(logger, nil, &MockDBService{})
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": tt.firstName, "last_name": "Doe", "age": 30})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), validation.CodeUnicodeFormatChar)
+			assert.NotContains(t, w.Body.String(), validation.CodeUnsafeUnicode)
+		})
+	}
+}
+
+func TestCreateUserImportsRecordingDate(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	past := int64(1638360000)
+	future := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name          string
+		apiKey        string
+		recordingDate int64
+		wantStatus    int
+		wantCode      string
+	}{
+		{"import sets a past date", "import-key", past, http.StatusCreated, ""},
+		{"future date rejected", "import-key", future, http.StatusBadRequest, validation.CodeInvalidRecordingDate},
+		{"date before 2000 rejected", "import-key", validation.MinRecordingDate - 1, http.StatusBadRequest, validation.CodeInvalidRecordingDate},
+		{"missing API key", "", past, http.StatusForbidden, "FORBIDDEN"},
+		{"wrong API key", "guess", past, http.StatusForbidden, "FORBIDDEN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := &MockDBService{}
+			handler := NewUserHandler(logger, nil, mockDB)
+			handler.SetImportAPIKey("import-key")
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30, "recording_date": tt.recordingDate})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantCode != "" {
+				assert.Contains(t, w.Body.String(), tt.wantCode)
+				assert.Empty(t, mockDB.createdUsers)
+				return
+			}
+			require.Len(t, mockDB.createdUsers, 1)
+			assert.Equal(t, past, mockDB.createdUsers[0].RecordingDate)
+			assert.Contains(t, w.Body.String(), `"recording_date":1638360000`)
+		})
+	}
+}
+
+func TestCreateUserRecordsAuditEntry(t *testing.T) {
+	var auditLog bytes.Buffer
+	audit.SetLogger(logging.NewStructuredLoggerWithWriter(&auditLog, logging.LevelInfo, "goUserAPI", "test"))
+	t.Cleanup(func() { audit.SetLogger(logging.NewStructuredLogger(logging.LevelInfo, "goUserAPI", "test")) })
+
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": "John", "last_name": "Doe", "age": 30})
+	req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(audit.APIKeyHeader, "client-secret")
+	req = req.WithContext(middleware.SetRequestID(req.Context(), "req-audit-1"))
+	w := httptest.NewRecorder()
+
+	handler.CreateUser(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(auditLog.Bytes(), &entry))
+	assert.Equal(t, true, entry["audit"])
+	assert.Equal(t, audit.ActionUserCreate, entry["action"])
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", entry["target_user_id"])
+	assert.Equal(t, audit.Actor("client-secret"), entry["actor"])
+	assert.Equal(t, "req-audit-1", entry["req_id"])
+	assert.NotContains(t, auditLog.String(), "client-secret")
 }
 
 func TestCreateUserMissingContentType(t *testing.T) {
@@ -137,6 +422,72 @@ func TestCreateUserInvalidJSON(t *testing.T) {
 	assert.Equal(t, "INVALID_JSON", response["code"])
 }
 
+func TestCreateUserStrictJSONDecoding(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	testCases := []struct {
+		name        string
+		body        string
+		expectedErr string
+	}{
+		{
+			name:        "unknown field",
+			body:        `{"first_name":"John","last_name":"Doe","ages":30}`,
+			expectedErr: "UNKNOWN_FIELD",
+		},
+		{
+			name:        "string age",
+			body:        `{"first_name":"John","last_name":"Doe","age":"30"}`,
+			expectedErr: "INVALID_FIELD_TYPE",
+		},
+		{
+			name:        "trailing garbage",
+			body:        `{"first_name":"John","last_name":"Doe","age":30} garbage`,
+			expectedErr: "INVALID_JSON",
+		},
+		{
+			name:        "second JSON object",
+			body:        `{"first_name":"John","last_name":"Doe","age":30}{"age":31}`,
+			expectedErr: "INVALID_JSON",
+		},
+		{
+			name:        "array root",
+			body:        `[{"first_name":"John","last_name":"Doe","age":30}]`,
+			expectedErr: "INVALID_JSON_STRUCTURE",
+		},
+		{
+			name:        "string root",
+			body:        ` "John Doe"`,
+			expectedErr: "INVALID_JSON_STRUCTURE",
+		},
+		{
+			name:        "null root",
+			body:        `null`,
+			expectedErr: "INVALID_JSON_STRUCTURE",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := &MockDBService{}
+			handler := NewUserHandler(logger, nil, mockDB)
+
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedErr, response["code"])
+			assert.Empty(t, mockDB.createdUsers)
+		})
+	}
+}
+
 func TestCreateUserMissingRequiredFields(t *testing.T) {
 	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
 	mockDB := &MockDBService{}
@@ -279,6 +630,207 @@ func TestCreateUserFieldValidation(t *testing.T) {
 	}
 }
 
+func TestCreateUserAggregatedValidationErrors(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetAggregateValidationErrors(true)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"first_name": "",
+		"last_name":  "Doe",
+		"age":        150,
+	})
+	req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateUser(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, "VALIDATION_FAILED", response.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "first_name", Code: "MISSING_REQUIRED_FIELD", Message: "Missing required field: first_name"},
+		{Field: "age", Code: "INVALID_AGE_RANGE", Message: "Age must be between 1 and 120"},
+	}, response.Fields)
+	assert.Empty(t, mockDB.createdUsers, "invalid request must not reach the database")
+}
+
+func TestCreateUserAggregatedNameFieldErrors(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetAggregateValidationErrors(true)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"first_name": strings.Repeat("a", 101),
+		"last_name":  "аdmin",
+		"age":        30,
+	})
+	req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateUser(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, "VALIDATION_FAILED", response.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "first_name", Code: "INVALID_FIELD_LENGTH", Message: "First name cannot exceed 100 characters"},
+		{Field: "last_name", Code: "UNICODE_SECURITY_VIOLATION", Message: "Invalid characters in last name"},
+	}, response.Fields)
+	assert.Empty(t, mockDB.createdUsers, "invalid request must not reach the database")
+}
+
+// nameCheckingDBService mimics database.CreateUser's optional duplicate name check
+type nameCheckingDBService struct {
+	MockDBService
+}
+
+func (m *nameCheckingDBService) CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
+	if database.RejectsDuplicateNames() {
+		for _, existing := range m.createdUsers {
+			if strings.EqualFold(existing.FirstName, user.FirstName) && strings.EqualFold(existing.LastName, user.LastName) {
+				return nil, fmt.Errorf("failed to create user: %w", errors.ErrDuplicateName)
+			}
+		}
+	}
+	return m.MockDBService.CreateUser(ctx, pool, user)
+}
+
+func TestCreateUserDuplicateNames(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	create := func(handler *UserHandler, firstName, lastName string) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{"first_name": firstName, "last_name": lastName, "age": 30})
+		req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.CreateUser(w, req)
+		return w
+	}
+
+	t.Run("rejected when enabled", func(t *testing.T) {
+		database.SetRejectDuplicateNames(true)
+		defer database.SetRejectDuplicateNames(false)
+
+		mockDB := &nameCheckingDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+
+		require.Equal(t, http.StatusCreated, create(handler, "John", "Doe").Code)
+		w := create(handler, "JOHN", "doe")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "USER_ALREADY_EXISTS", response.Code)
+		assert.Len(t, mockDB.createdUsers, 1)
+	})
+
+	t.Run("allowed when disabled", func(t *testing.T) {
+		mockDB := &nameCheckingDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+
+		require.Equal(t, http.StatusCreated, create(handler, "John", "Doe").Code)
+		assert.Equal(t, http.StatusCreated, create(handler, "JOHN", "doe").Code)
+		assert.Len(t, mockDB.createdUsers, 2)
+	})
+}
+
+func TestCreateUserConfiguredLimits(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	t.Run("name length", func(t *testing.T) {
+		mockDB := &MockDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+		handler.SetLimits(validation.Limits{MaxNameLength: 50, MaxPayloadBytes: 1024})
+
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"first_name": strings.Repeat("a", 60),
+			"last_name":  "Doe",
+			"age":        30,
+		})
+		req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "INVALID_FIELD_LENGTH", response.Code)
+		assert.Equal(t, "First name cannot exceed 50 characters", response.Error)
+		assert.Empty(t, mockDB.createdUsers)
+	})
+
+	t.Run("payload size", func(t *testing.T) {
+		mockDB := &MockDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+		handler.SetLimits(validation.Limits{MaxNameLength: 50, MaxPayloadBytes: 32})
+
+		req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"first_name":"John","last_name":"Doe","age":30}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.CreateUser(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "PAYLOAD_TOO_LARGE", response.Code)
+		assert.Contains(t, response.Error, "32 bytes")
+		assert.Empty(t, mockDB.createdUsers)
+	})
+}
+
+func TestCreateUserConfiguredAgeBounds(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetAgeBounds(validation.AgeBounds{Min: 18, Max: 65})
+
+	testCases := []struct {
+		name           string
+		age            int
+		expectedStatus int
+	}{
+		{"below minimum", 16, http.StatusBadRequest},
+		{"at minimum", 18, http.StatusCreated},
+		{"above maximum", 66, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(map[string]interface{}{
+				"first_name": "John",
+				"last_name":  "Doe",
+				"age":        tc.age,
+			})
+			req := httptest.NewRequest("POST", "/users", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.CreateUser(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusBadRequest {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "INVALID_AGE_RANGE", response.Code)
+				assert.Equal(t, "Age must be between 18 and 65", response.Error)
+			}
+		})
+	}
+}
+
 func TestCreateUserUnsupportedMethod(t *testing.T) {
 	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
 	mockDB := &MockDBService{}
@@ -442,6 +994,140 @@ func TestGetUsersDefaultValues(t *testing.T) {
 	assert.Equal(t, "desc", mockDB.lastParams.SortOrder)
 }
 
+func TestGetUsersDefaultSortOrderPerField(t *testing.T) {
+	testCases := []struct {
+		query         string
+		expectedOrder string
+	}{
+		{"sort_by=first_name", "asc"},
+		{"sort_by=last_name", "asc"},
+		{"sort_by=recording_date", "desc"},
+		{"sort_by=first_name&sort_order=desc", "desc"},
+		{"sort_by=recording_date&sort_order=asc", "asc"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+			mockDB := &MockGetUsersDBService{}
+			handler := NewUserHandler(logger, nil, mockDB)
+
+			w := httptest.NewRecorder()
+			handler.GetUsers(w, httptest.NewRequest("GET", "/users?"+tc.query, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.expectedOrder, mockDB.lastParams.SortOrder)
+		})
+	}
+}
+
+func TestGetUsersConfiguredPageSize(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockGetUsersDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetPageSize(validation.PageSize{Default: 5, Max: 10})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, mockDB.lastParams.Limit, "configured default must apply when limit is omitted")
+
+	req = httptest.NewRequest("GET", "/users?limit=11", nil)
+	w = httptest.NewRecorder()
+	handler.GetUsers(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "between 1 and 10")
+}
+
+func TestGetUsersMaxOffset(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockGetUsersDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+	handler.SetPageSize(validation.PageSize{Default: 20, Max: 100, MaxOffset: 10000})
+
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, httptest.NewRequest("GET", "/users?offset=10000", nil))
+	require.Equal(t, http.StatusOK, w.Code, "exactly MAX_OFFSET is allowed")
+	assert.Equal(t, 10000, mockDB.lastParams.Offset)
+
+	w = httptest.NewRecorder()
+	handler.GetUsers(w, httptest.NewRequest("GET", "/users?offset=10001", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "OFFSET_TOO_LARGE", response.Code)
+	assert.Equal(t, "Offset must not exceed 10000. Use cursor pagination to read further", response.Error)
+	assert.Equal(t, "parameter: offset, value: 10001, max: 10000", response.Details)
+}
+
+func TestNewPaginationInfo(t *testing.T) {
+	tests := []struct {
+		name           string
+		totalCount     int64
+		limit, offset  int
+		wantPage       int
+		wantTotalPages int64
+		wantHasMore    bool
+	}{
+		{"exact multiple first page", 100, 20, 0, 1, 5, true},
+		{"exact multiple last page", 100, 20, 80, 5, 5, false},
+		{"remainder adds a page", 101, 20, 0, 1, 6, true},
+		{"remainder last page", 101, 20, 100, 6, 6, false},
+		{"offset between pages", 150, 10, 25, 3, 15, true},
+		{"single partial page", 3, 20, 0, 1, 1, false},
+		{"empty result", 0, 20, 0, 1, 0, false},
+		{"offset beyond total", 10, 20, 40, 3, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := newPaginationInfo(tt.totalCount, tt.limit, tt.offset)
+			assert.Equal(t, tt.wantPage, info.Page)
+			assert.Equal(t, tt.wantTotalPages, info.TotalPages)
+			assert.Equal(t, tt.wantHasMore, info.HasMore)
+			assert.Equal(t, tt.totalCount, info.TotalCount)
+		})
+	}
+}
+
+func TestGetUsersPageNumbers(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, httptest.NewRequest("GET", "/users?limit=20&offset=40", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Pagination map[string]any `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(3), response.Pagination["page"])
+	assert.Equal(t, float64(8), response.Pagination["total_pages"], "150 users in pages of 20")
+}
+
+func TestGetUsersSortFieldFromSharedWhitelist(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockGetUsersDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	req := httptest.NewRequest("GET", "/users?sort_by=email", nil)
+	w := httptest.NewRecorder()
+	handler.GetUsers(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code, "email is not sortable until whitelisted")
+
+	database.AllowedSortFields["email"] = "email"
+	t.Cleanup(func() { delete(database.AllowedSortFields, "email") })
+
+	w = httptest.NewRecorder()
+	handler.GetUsers(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "email", mockDB.lastParams.SortBy)
+}
+
 // MockGetUsersDBService mocks the database service for GetUsers testing
 type MockGetUsersDBService struct {
 	shouldFail     bool
@@ -454,6 +1140,10 @@ func (m *MockGetUsersDBService) CreateUser(ctx context.Context, pool *pgxpool.Po
 	return nil, nil // Not used in GetUsers tests
 }
 
+func (m *MockGetUsersDBService) UpsertUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, bool, error) {
+	return nil, false, nil // Not used in GetUsers tests
+}
+
 func (m *MockGetUsersDBService) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
 	if m.shouldFail {
 		return nil, 0, fmt.Errorf("database error")
@@ -469,6 +1159,7 @@ func (m *MockGetUsersDBService) GetUsers(ctx context.Context, pool *pgxpool.Pool
 			LastName:      "Doe",
 			Age:           30,
 			RecordingDate: 1705314600,
+			Email:         "john.doe@example.com",
 		},
 		{
 			ID:            "550e8400-e29b-41d4-a716-446655440001",
@@ -476,12 +1167,21 @@ func (m *MockGetUsersDBService) GetUsers(ctx context.Context, pool *pgxpool.Pool
 			LastName:      "Smith",
 			Age:           25,
 			RecordingDate: 1705314700,
+			Email:         "jane.smith@example.com",
 		},
 	}
 
 	return mockUsers, int64(150), nil
 }
 
+func (m *MockGetUsersDBService) GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error) {
+	return nil, pgx.ErrNoRows // Not used in GetUsers tests
+}
+
+func (m *MockGetUsersDBService) GetUsersByIDs(ctx context.Context, pool *pgxpool.Pool, ids []string) ([]models.User, error) {
+	return []models.User{}, nil // Not used in GetUsers tests
+}
+
 func (m *MockGetUsersDBService) GetReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams) ([]models.User, int64, error) {
 	if m.shouldFail {
 		return nil, 0, fmt.Errorf("database error")
@@ -508,6 +1208,10 @@ func (m *MockGetUsersDBService) GetReports(ctx context.Context, pool *pgxpool.Po
 	return mockUsers, int64(150), nil
 }
 
+func (m *MockGetUsersDBService) StreamReports(ctx context.Context, pool *pgxpool.Pool, params types.GetReportsParams, fn func(models.User) error) error {
+	return nil // Not used in GetUsers tests
+}
+
 // ===== NFR-P1 PERFORMANCE BENCHMARKS =====
 
 // BenchmarkGetUsers tests performance compliance with NFR-P1 <200ms requirement
@@ -639,8 +1343,8 @@ func TestPerformance_NFRP1Compliance(t *testing.T) {
 		},
 		{
 			name:        "large_dataset_pagination",
-			url:         "/users?limit=100&offset=50000",
-			description: "Large offset with max limit",
+			url:         "/users?limit=100&offset=10000",
+			description: "Deepest allowed offset (MAX_OFFSET) with max limit",
 		},
 		{
 			name:        "expensive_sort",
@@ -757,3 +1461,427 @@ func generateMockUsers(count int) []models.User {
 
 	return users
 }
+
+func TestCreateUserPayloadTooLarge(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	mockDB := &MockDBService{}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	limited := middleware.MaxBodySize(64)(http.HandlerFunc(handler.CreateUser))
+
+	body := fmt.Sprintf(`{"first_name":"%s","last_name":"Doe","age":30}`, strings.Repeat("a", 128))
+
+	testCases := []struct {
+		name          string
+		contentLength int64
+	}{
+		{name: "declared content length", contentLength: int64(len(body))},
+		{name: "chunked body", contentLength: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.ContentLength = tc.contentLength
+			w := httptest.NewRecorder()
+
+			limited.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "PAYLOAD_TOO_LARGE", response["code"])
+		})
+	}
+	assert.Empty(t, mockDB.createdUsers)
+}
+
+// gzipBody compresses body for requests sent with Content-Encoding: gzip
+func gzipBody(t *testing.T, body []byte) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return &buf
+}
+
+func TestCreateUserGzipRequestBody(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	t.Run("compressed valid payload", func(t *testing.T) {
+		mockDB := &MockDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+		decompressed := middleware.MaxBodySize(1024)(middleware.DecompressRequest([]string{"gzip"}, 1024)(http.HandlerFunc(handler.CreateUser)))
+
+		req := httptest.NewRequest("POST", "/users", gzipBody(t, []byte(`{"first_name":"John","last_name":"Doe","age":30}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		w := httptest.NewRecorder()
+
+		decompressed.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.Len(t, mockDB.createdUsers, 1)
+		assert.Equal(t, "John", mockDB.createdUsers[0].FirstName)
+	})
+
+	t.Run("decompression bomb", func(t *testing.T) {
+		mockDB := &MockDBService{}
+		handler := NewUserHandler(logger, nil, mockDB)
+		decompressed := middleware.MaxBodySize(1024)(middleware.DecompressRequest([]string{"gzip"}, 1024)(http.HandlerFunc(handler.CreateUser)))
+
+		// Well under 1 KiB on the wire, 256 KiB once decompressed
+		body := fmt.Sprintf(`{"first_name":"%s","last_name":"Doe","age":30}`, strings.Repeat("a", 1<<18))
+		compressed := gzipBody(t, []byte(body))
+		require.Less(t, compressed.Len(), 1024)
+
+		req := httptest.NewRequest("POST", "/users", compressed)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		w := httptest.NewRecorder()
+
+		decompressed.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "PAYLOAD_TOO_LARGE", response.Code)
+		assert.Empty(t, mockDB.createdUsers)
+	})
+}
+
+func TestGetUserByIDConditionalGet(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	mockDB := &MockDBService{usersByID: map[string]*models.User{
+		userID: {ID: userID, FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+	}}
+	handler := NewUserHandler(logger, nil, mockDB)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID, nil)
+		req.SetPathValue("id", userID)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.GetUserByID(w, req)
+		return w
+	}
+
+	// First request returns the user with an ETag
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var user models.User
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &user))
+	assert.Equal(t, userID, user.ID)
+
+	// Revalidation with the same ETag returns 304 without a body
+	second := get(etag)
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+	assert.Empty(t, second.Body.Bytes())
+
+	// Changing the user invalidates the ETag
+	mockDB.usersByID[userID].Age = 31
+	third := get(etag)
+	assert.Equal(t, http.StatusOK, third.Code)
+	assert.NotEqual(t, etag, third.Header().Get("ETag"))
+}
+
+func TestHeadRequestsShareGetPath(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+
+	t.Run("users list", func(t *testing.T) {
+		handler := NewUserHandler(logger, nil, &MockGetUsersDBService{})
+
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, httptest.NewRequest(http.MethodHead, "/users", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "150", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.NotEmpty(t, w.Header().Get("Link"))
+		assert.Empty(t, w.Body.Bytes())
+	})
+
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	handler := NewUserHandler(logger, nil, &MockDBService{usersByID: map[string]*models.User{
+		userID: {ID: userID, FirstName: "John", LastName: "Doe", Age: 30, RecordingDate: 1705314600},
+	}})
+	head := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodHead, "/users/"+id, nil)
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+		handler.GetUserByID(w, req)
+		return w
+	}
+
+	t.Run("existing user", func(t *testing.T) {
+		w := head(userID)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.Empty(t, w.Body.Bytes())
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		w := head("550e8400-e29b-41d4-a716-446655440099")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Body.Bytes())
+	})
+}
+
+func TestGetUserByIDErrors(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	handler := NewUserHandler(logger, nil, &MockDBService{})
+
+	tests := []struct {
+		name         string
+		id           string
+		expectedCode int
+		errorCode    string
+	}{
+		{"invalid uuid", "not-a-uuid", http.StatusBadRequest, "INVALID_USER_ID"},
+		{"unknown user", "550e8400-e29b-41d4-a716-446655440099", http.StatusNotFound, errors.ErrCodeUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.GetUserByID(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.errorCode, response.Code)
+		})
+	}
+}
+
+func TestGetUsersLocalizedValidationMessage(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	for acceptLanguage, want := range map[string]string{
+		"en": "Invalid sort_order parameter. Must be 'asc' or 'desc'",
+		"ru": "Недопустимый параметр sort_order. Допустимо 'asc' или 'desc'",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/users?sort_order=up", nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "INVALID_SORT_ORDER", response.Code)
+		assert.Equal(t, want, response.Error, "Accept-Language: %s", acceptLanguage)
+	}
+}
+
+func TestGetUsersFieldSelection(t *testing.T) {
+	handler := NewUserHandler(logging.NewStructuredLogger("info", "goUserAPI", "test"), nil, &MockGetUsersDBService{})
+
+	t.Run("subset", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users?fields=id,first_name", nil)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Users      []map[string]any `json:"users"`
+			Pagination PaginationInfo   `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Users)
+		for _, user := range response.Users {
+			assert.Len(t, user, 2)
+			assert.Contains(t, user, "id")
+			assert.Contains(t, user, "first_name")
+		}
+		assert.Equal(t, int64(150), response.Pagination.TotalCount)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users?fields=id,email", nil)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Users []map[string]any `json:"users"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Users)
+		assert.Equal(t, "john.doe@example.com", response.Users[0]["email"])
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users?fields=id,password", nil)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "INVALID_FIELD_SELECTION", response.Code)
+	})
+
+	t.Run("all fields when omitted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		w := httptest.NewRecorder()
+		handler.GetUsers(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Users []map[string]any `json:"users"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Users)
+		for _, user := range response.Users {
+			assert.ElementsMatch(t, userFieldNames, mapKeys(user))
+		}
+	})
+}
+
+// mapKeys returns the keys of a decoded JSON object
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// tenantDBService keeps users per tenant and, like the tenant-scoped SQL, only ever reads the rows
+// of the tenant in the request context
+type tenantDBService struct {
+	MockDBService
+	mu      sync.Mutex
+	users   map[string][]models.User
+	created int
+}
+
+func (m *tenantDBService) tenantUsers(ctx context.Context) ([]models.User, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, database.ErrTenantRequired
+	}
+	return m.users[id], nil
+}
+
+func (m *tenantDBService) CreateUser(ctx context.Context, pool *pgxpool.Pool, user *models.User) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, database.ErrTenantRequired
+	}
+	m.created++
+	created := models.User{
+		ID:            fmt.Sprintf("550e8400-e29b-41d4-a716-%012d", m.created),
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Age:           user.Age,
+		RecordingDate: time.Now().Unix(),
+	}
+	m.users[id] = append(m.users[id], created)
+	return &created, nil
+}
+
+func (m *tenantDBService) GetUsers(ctx context.Context, pool *pgxpool.Pool, params types.GetUsersParams) ([]models.User, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	users, err := m.tenantUsers(ctx)
+	return users, int64(len(users)), err
+}
+
+func (m *tenantDBService) GetUserByID(ctx context.Context, pool *pgxpool.Pool, id string) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	users, err := m.tenantUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.ID == id {
+			return &user, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to get user by ID %s: %w", id, pgx.ErrNoRows)
+}
+
+func TestMultiTenantUsersAreIsolated(t *testing.T) {
+	logger := logging.NewStructuredLogger("info", "goUserAPI", "test")
+	db := &tenantDBService{users: make(map[string][]models.User)}
+	handler := NewUserHandler(logger, nil, db)
+
+	requireTenant := middleware.RequireTenant(true)
+	mux := http.NewServeMux()
+	mux.Handle("GET /users", requireTenant(http.HandlerFunc(handler.GetUsers)))
+	mux.Handle("POST /users", requireTenant(http.HandlerFunc(handler.CreateUser)))
+	mux.Handle("GET /users/{id}", requireTenant(http.HandlerFunc(handler.GetUserByID)))
+
+	serve := func(method, target, tenantID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if tenantID != "" {
+			req.Header.Set(tenant.Header, tenantID)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	created := serve("POST", "/users", "acme", `{"first_name":"John","last_name":"Doe","age":30}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var user UserResponse
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &user))
+	require.Len(t, db.users["acme"], 1, "the user is stored under the creating tenant")
+
+	t.Run("owner tenant sees the user", func(t *testing.T) {
+		w := serve("GET", "/users/"+user.ID, "acme", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve("GET", "/users", "acme", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var page GetUsersResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Users, 1)
+	})
+
+	t.Run("other tenant cannot read the user", func(t *testing.T) {
+		w := serve("GET", "/users/"+user.ID, "globex", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serve("GET", "/users", "globex", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var page GetUsersResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Empty(t, page.Users)
+		assert.Equal(t, int64(0), page.Pagination.TotalCount)
+	})
+
+	t.Run("missing tenant header is rejected", func(t *testing.T) {
+		for _, w := range []*httptest.ResponseRecorder{
+			serve("GET", "/users", "", ""),
+			serve("GET", "/users/"+user.ID, "", ""),
+			serve("POST", "/users", "", `{"first_name":"Jane","last_name":"Roe","age":25}`),
+		} {
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "TENANT_ID_REQUIRED")
+		}
+		assert.Equal(t, 1, db.created, "rejected requests never reach the database")
+	})
+}
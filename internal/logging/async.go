@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultAsyncQueueSize is the number of messages an async file writer buffers when LOG_ASYNC_QUEUE_SIZE is unset
+const DefaultAsyncQueueSize = 1024
+
+// Async queue overflow policies for LOG_ASYNC_POLICY
+const (
+	AsyncPolicyBlock = "block" // Writers wait for room in the queue; nothing is lost
+	AsyncPolicyDrop  = "drop"  // Writes to a full queue are discarded and counted
+)
+
+// asyncWriter queues writes for a dedicated goroutine that performs them on the underlying writer
+// With AsyncPolicyDrop a write to a full queue is discarded and counted instead of waiting.
+// Close stops accepting writes and returns once every queued write has been performed.
+type asyncWriter struct {
+	out   io.Writer
+	drop  bool
+	queue chan []byte
+	done  chan struct{}
+
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+// newAsyncWriter starts the goroutine writing to out through a queue of queueSize messages
+func newAsyncWriter(out io.Writer, queueSize int, policy string) *asyncWriter {
+	w := &asyncWriter{
+		out:   out,
+		drop:  policy == AsyncPolicyDrop,
+		queue: make(chan []byte, queueSize),
+		done:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run performs queued writes in order until the queue is closed and drained
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for p := range w.queue {
+		w.out.Write(p)
+	}
+}
+
+// Write queues a copy of p, since callers such as log.Logger reuse their buffer
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	message := append([]byte(nil), p...)
+	if !w.drop {
+		w.queue <- message
+		return len(p), nil
+	}
+
+	select {
+	case w.queue <- message:
+	default:
+		w.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Dropped returns how many writes were discarded because the queue was full
+func (w *asyncWriter) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Close stops accepting writes and waits until every queued write has been performed
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return nil
+}
@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+)
+
+// syncBuffer is a bytes.Buffer safe for the async writer goroutine and the test to share
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// gatedWriter blocks every write until release is closed
+type gatedWriter struct {
+	release chan struct{}
+	out     syncBuffer
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	<-g.release
+	return g.out.Write(p)
+}
+
+func TestAsyncWriterEventuallyFlushes(t *testing.T) {
+	out := &syncBuffer{}
+	w := newAsyncWriter(out, 16, AsyncPolicyBlock)
+	defer w.Close()
+
+	buf := []byte("first\n")
+	w.Write(buf)
+	copy(buf, "reused") // The caller may reuse its buffer once Write returns
+	w.Write([]byte("second\n"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for out.String() != "first\nsecond\n" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected queued messages to be written in order, got %q", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAsyncWriterCloseDrainsQueue(t *testing.T) {
+	gate := &gatedWriter{release: make(chan struct{})}
+	w := newAsyncWriter(gate, 100, AsyncPolicyBlock)
+
+	var want strings.Builder
+	for i := 0; i < 50; i++ {
+		line := fmt.Sprintf("message %d\n", i)
+		want.WriteString(line)
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		w.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected Close to wait for queued messages")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(gate.release)
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Close to return once the queue is drained")
+	}
+	if gate.out.String() != want.String() {
+		t.Errorf("Expected every queued message after Close, got %q", gate.out.String())
+	}
+
+	if _, err := w.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected os.ErrClosed writing after Close, got %v", err)
+	}
+}
+
+func TestAsyncWriterDropPolicyCountsDroppedMessages(t *testing.T) {
+	gate := &gatedWriter{release: make(chan struct{})}
+	w := newAsyncWriter(gate, 2, AsyncPolicyDrop)
+
+	// One message is taken by the blocked writer goroutine, two fill the queue
+	w.Write([]byte("held\n"))
+	deadline := time.Now().Add(2 * time.Second)
+	for len(w.queue) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the writer goroutine to take the first message")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 5; i++ {
+		if n, err := w.Write([]byte("queued\n")); err != nil || n != len("queued\n") {
+			t.Fatalf("Expected a dropped write to report success, got %d, %v", n, err)
+		}
+	}
+
+	if dropped := w.Dropped(); dropped != 3 {
+		t.Errorf("Expected 3 dropped messages, got %d", dropped)
+	}
+
+	close(gate.release)
+	w.Close()
+	if got := strings.Count(gate.out.String(), "\n"); got != 3 {
+		t.Errorf("Expected 3 messages written, got %d", got)
+	}
+}
+
+func TestFileLoggerAsyncCloseFlushesFiles(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewFileLogger(&config.LoggingConfig{Level: "info", Dir: dir, Async: true, AsyncQueueSize: 4096, AsyncPolicy: AsyncPolicyBlock})
+	if err != nil {
+		t.Fatalf("NewFileLogger failed: %v", err)
+	}
+	name := logger.logFile.Name()
+
+	for i := 0; i < 1000; i++ {
+		logger.infoLogger.Log(slog.LevelInfo, fmt.Sprintf("message %d", i))
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if got := strings.Count(string(data), "\n"); got != 1000 {
+		t.Errorf("Expected 1000 lines after Close, got %d", got)
+	}
+	if logger.Dropped() != 0 {
+		t.Errorf("Expected no dropped messages with the block policy, got %d", logger.Dropped())
+	}
+}
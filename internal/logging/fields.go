@@ -12,6 +12,8 @@ const (
 	FieldLevel        = "level"
 	FieldMessage      = "msg"
 	FieldRequestID    = "req_id"
+	FieldCorrelation  = "correlation_id"
+	FieldClientIP     = "client_ip"
 	FieldHTTPMethod   = "method"
 	FieldHTTPPath     = "path"
 	FieldHTTPStatus   = "status"
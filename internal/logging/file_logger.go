@@ -3,52 +3,57 @@ package logging
 import (
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/config"
 )
 
 // FileLogger provides file-based logging functionality
 type FileLogger struct {
-	infoLogger   *log.Logger
-	errorLogger  *log.Logger
-	debugLogger  *log.Logger
-	logFile      *os.File
-	errorLogFile *os.File
-	debugLogFile *os.File
+	infoLogger   fileSink
+	errorLogger  fileSink
+	debugLogger  fileSink
+	logFile      *rotatingFile
+	errorLogFile *rotatingFile
+	debugLogFile *rotatingFile
 	config       *config.LoggingConfig
 	multiWriter  io.Writer
+	dir          string
+
+	// asyncWriters queue file writes when LOG_ASYNC is enabled; Close drains them before closing the files
+	asyncWriters []*asyncWriter
 }
 
 // NewFileLogger creates a new file logger instance
+// Each file is rotated once it reaches LOG_MAX_SIZE_MB, keeping at most LOG_MAX_BACKUPS older files per kind.
 func NewFileLogger(cfg *config.LoggingConfig) (*FileLogger, error) {
+	// Create log directory if it doesn't exist
+	logDir := cfg.Dir
+	if logDir == "" {
+		logDir = DefaultLogDir
+	}
 	logger := &FileLogger{
 		config: cfg,
+		dir:    logDir,
 	}
 
-	// Create log directory if it doesn't exist
-	logDir := "/app/logs"
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	// Open log files
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	maxSize := int64(cfg.MaxSizeMB) * 1024 * 1024
 
 	// Main log file
-	logFileName := filepath.Join(logDir, fmt.Sprintf("app_%s.log", timestamp))
-	logFile, err := os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	logFile, err := newRotatingFile(logDir, "app", maxSize, cfg.MaxBackups)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, err
 	}
 	logger.logFile = logFile
 
 	// Error log file
-	errorLogFileName := filepath.Join(logDir, fmt.Sprintf("error_%s.log", timestamp))
-	errorLogFile, err := os.OpenFile(errorLogFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	errorLogFile, err := newRotatingFile(logDir, "error", maxSize, cfg.MaxBackups)
 	if err != nil {
 		logFile.Close()
 		return nil, fmt.Errorf("failed to open error log file: %w", err)
@@ -56,10 +61,9 @@ func NewFileLogger(cfg *config.LoggingConfig) (*FileLogger, error) {
 	logger.errorLogFile = errorLogFile
 
 	// Debug log file (only in debug mode)
-	var debugLogFile *os.File
+	var debugLogFile *rotatingFile
 	if cfg.Level == "debug" {
-		debugLogFileName := filepath.Join(logDir, fmt.Sprintf("debug_%s.log", timestamp))
-		debugFile, err := os.OpenFile(debugLogFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		debugFile, err := newRotatingFile(logDir, "debug", maxSize, cfg.MaxBackups)
 		if err != nil {
 			logFile.Close()
 			errorLogFile.Close()
@@ -69,24 +73,54 @@ func NewFileLogger(cfg *config.LoggingConfig) (*FileLogger, error) {
 		logger.debugLogFile = debugLogFile
 	}
 
-	// Create loggers with different destinations
-	logger.infoLogger = log.New(logFile, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
-	logger.errorLogger = log.New(io.MultiWriter(errorLogFile, os.Stderr), "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
+	// Create loggers with different destinations, as slog JSON objects when LOG_FORMAT=json
+	infoOut := logger.fileWriter(logFile)
+	logger.infoLogger = newFileSink(infoOut, cfg.Format, "INFO: ")
+	logger.errorLogger = newFileSink(io.MultiWriter(logger.fileWriter(errorLogFile), os.Stderr), cfg.Format, "ERROR: ")
 
 	if debugLogFile != nil {
-		logger.debugLogger = log.New(debugLogFile, "DEBUG: ", log.Ldate|log.Ltime|log.Lshortfile)
+		logger.debugLogger = newFileSink(logger.fileWriter(debugLogFile), cfg.Format, "DEBUG: ")
 	}
 
 	// Create multi-writer for both file and stdout output (for Docker logging)
-	logger.multiWriter = io.MultiWriter(logFile, os.Stdout)
+	logger.multiWriter = io.MultiWriter(infoOut, os.Stdout)
 
 	return logger, nil
 }
 
+// fileWriter returns file itself, or an asynchronous writer in front of it when LOG_ASYNC is enabled
+func (l *FileLogger) fileWriter(file *rotatingFile) io.Writer {
+	if !l.config.Async {
+		return file
+	}
+	queueSize := l.config.AsyncQueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultAsyncQueueSize
+	}
+	w := newAsyncWriter(file, queueSize, l.config.AsyncPolicy)
+	l.asyncWriters = append(l.asyncWriters, w)
+	return w
+}
+
+// Writer returns a writer to the main log file (with rotation and LOG_ASYNC queuing) and stdout, so the
+// slog-based Logger can share the file: NewStructuredLoggerWithWriter(fl.Writer(), level, service, version)
+func (l *FileLogger) Writer() io.Writer {
+	return l.multiWriter
+}
+
+// Dropped returns how many messages were discarded because an async queue was full (LOG_ASYNC_POLICY=drop)
+func (l *FileLogger) Dropped() int64 {
+	var dropped int64
+	for _, w := range l.asyncWriters {
+		dropped += w.Dropped()
+	}
+	return dropped
+}
+
 // LogInfo logs an info message
 func (l *FileLogger) LogInfo(format string, v ...interface{}) {
 	if l.infoLogger != nil {
-		l.infoLogger.Printf(format, v...)
+		l.infoLogger.Log(slog.LevelInfo, fmt.Sprintf(format, v...))
 	}
 	// Also log to stdout for Docker
 	fmt.Printf("INFO: "+format+"\n", v...)
@@ -95,14 +129,14 @@ func (l *FileLogger) LogInfo(format string, v ...interface{}) {
 // LogError logs an error message
 func (l *FileLogger) LogError(format string, v ...interface{}) {
 	if l.errorLogger != nil {
-		l.errorLogger.Printf(format, v...)
+		l.errorLogger.Log(slog.LevelError, fmt.Sprintf(format, v...))
 	}
 }
 
 // LogDebug logs a debug message (only if debug level is enabled)
 func (l *FileLogger) LogDebug(format string, v ...interface{}) {
 	if l.config.Level == "debug" && l.debugLogger != nil {
-		l.debugLogger.Printf(format, v...)
+		l.debugLogger.Log(slog.LevelDebug, fmt.Sprintf(format, v...))
 	}
 	// Also log debug to stdout for Docker when debug enabled
 	if l.config.Level == "debug" {
@@ -113,16 +147,21 @@ func (l *FileLogger) LogDebug(format string, v ...interface{}) {
 // LogWarning logs a warning message
 func (l *FileLogger) LogWarning(format string, v ...interface{}) {
 	if l.infoLogger != nil {
-		l.infoLogger.Printf("WARNING: "+format, v...)
+		l.infoLogger.Log(slog.LevelWarn, fmt.Sprintf(format, v...))
 	}
 	// Also log to stdout for Docker
 	fmt.Printf("WARNING: "+format+"\n", v...)
 }
 
-// Close closes all log files
+// Close flushes queued messages and closes all log files
 func (l *FileLogger) Close() error {
 	var errs []error
 
+	// Flush queued messages before their files are closed
+	for _, w := range l.asyncWriters {
+		w.Close()
+	}
+
 	if l.logFile != nil {
 		if err := l.logFile.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("failed to close log file: %w", err))
@@ -150,8 +189,7 @@ func (l *FileLogger) Close() error {
 
 // GetLogFiles returns list of current log files
 func (l *FileLogger) GetLogFiles() ([]string, error) {
-	logDir := "/app/logs"
-	files, err := os.ReadDir(logDir)
+	files, err := os.ReadDir(l.dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read log directory: %w", err)
 	}
@@ -159,42 +197,22 @@ func (l *FileLogger) GetLogFiles() ([]string, error) {
 	var logFiles []string
 	for _, file := range files {
 		if !file.IsDir() {
-			logFiles = append(logFiles, filepath.Join(logDir, file.Name()))
+			logFiles = append(logFiles, filepath.Join(l.dir, file.Name()))
 		}
 	}
 
 	return logFiles, nil
 }
 
-// CleanupOldLogs removes log files older than specified days
-func (l *FileLogger) CleanupOldLogs(days int) error {
-	logDir := "/app/logs"
-	files, err := os.ReadDir(logDir)
+// CleanupOldLogs removes log files older than specified days and returns how many were removed
+func (l *FileLogger) CleanupOldLogs(days int) (int, error) {
+	removed, err := LogDir(l.dir).CleanupOldLogs(days)
 	if err != nil {
-		return fmt.Errorf("failed to read log directory: %w", err)
+		// Log the error but report the files that were removed
+		l.LogWarning("Failed to remove old log files: %v", err)
 	}
-
-	cutoffTime := time.Now().AddDate(0, 0, -days)
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		filePath := filepath.Join(logDir, file.Name())
-		fileInfo, err := file.Info()
-		if err != nil {
-			continue // Skip files we can't get info for
-		}
-
-		if fileInfo.ModTime().Before(cutoffTime) {
-			if err := os.Remove(filePath); err != nil {
-				// Log the error but don't fail the operation
-				l.LogWarning("Failed to remove old log file %s: %v", filePath, err)
-			} else {
-				l.LogInfo("Removed old log file: %s", filePath)
-			}
-		}
+	if removed > 0 {
+		l.LogInfo("Removed %d old log files from %s", removed, l.dir)
 	}
-
-	return nil
+	return removed, err
 }
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+)
+
+// readLogLines returns the non-empty lines of path
+func readLogLines(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestFileLoggerJSONFormat(t *testing.T) {
+	logger, err := NewFileLogger(&config.LoggingConfig{Level: "debug", Format: "json", Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewFileLogger failed: %v", err)
+	}
+	appFile, errorFile, debugFile := logger.logFile.Name(), logger.errorLogFile.Name(), logger.debugLogFile.Name()
+
+	logger.LogInfo("user %s created", "42")
+	logger.LogWarning("slow query: %dms", 1500)
+	logger.LogError("database unavailable")
+	logger.LogDebug("cache miss for %q", "users")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	tests := []struct {
+		path  string
+		level string
+		msg   string
+	}{
+		{appFile, "INFO", "user 42 created"},
+		{appFile, "WARN", "slow query: 1500ms"},
+		{errorFile, "ERROR", "database unavailable"},
+		{debugFile, "DEBUG", `cache miss for "users"`},
+	}
+
+	lines := map[string][]string{}
+	for _, path := range []string{appFile, errorFile, debugFile} {
+		lines[path] = readLogLines(t, path)
+	}
+	for _, tt := range tests {
+		if len(lines[tt.path]) == 0 {
+			t.Fatalf("Expected a %s line in %s", tt.level, tt.path)
+		}
+		line := lines[tt.path][0]
+		lines[tt.path] = lines[tt.path][1:]
+
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected a JSON line, got %q: %v", line, err)
+		}
+		if entry["level"] != tt.level || entry["msg"] != tt.msg {
+			t.Errorf("Expected level %s and msg %q, got %v", tt.level, tt.msg, entry)
+		}
+		timestamp, ok := entry["time"].(string)
+		if !ok {
+			t.Fatalf("Expected a time field, got %v", entry)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+			t.Errorf("Expected an RFC 3339 time, got %q", timestamp)
+		}
+	}
+}
+
+func TestFileLoggerTextFormat(t *testing.T) {
+	logger, err := NewFileLogger(&config.LoggingConfig{Level: "info", Format: "text", Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewFileLogger failed: %v", err)
+	}
+	appFile := logger.logFile.Name()
+
+	logger.LogInfo("user %s created", "42")
+	logger.LogWarning("slow query")
+	logger.Close()
+
+	lines := readLogLines(t, appFile)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "INFO: ") || !strings.HasSuffix(lines[0], ": user 42 created") {
+		t.Errorf("Expected an INFO text line, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "file_logger_test.go:") {
+		t.Errorf("Expected the caller's file:line, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ": WARNING: slow query") {
+		t.Errorf("Expected a WARNING text line, got %q", lines[1])
+	}
+}
+
+func TestFileLoggerWriterSharesFileWithStructuredLogger(t *testing.T) {
+	fileLogger, err := NewFileLogger(&config.LoggingConfig{Level: "info", Format: "json", Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewFileLogger failed: %v", err)
+	}
+	appFile := fileLogger.logFile.Name()
+
+	logger := NewStructuredLoggerWithWriter(fileLogger.Writer(), "info", "goUserAPI", "test")
+	logger.Info("from slog", "user_id", "42")
+	fileLogger.LogInfo("from file logger")
+	fileLogger.Close()
+
+	lines := readLogLines(t, appFile)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", lines)
+	}
+	for i, want := range []string{"from slog", "from file logger"} {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+			t.Fatalf("Expected a JSON line, got %q: %v", lines[i], err)
+		}
+		if entry["msg"] != want || entry["level"] != "INFO" {
+			t.Errorf("Expected INFO %q, got %v", want, entry)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+)
+
+// fileSink writes one FileLogger message at a level to a log file
+type fileSink interface {
+	Log(level slog.Level, msg string)
+}
+
+// newFileSink returns a sink writing to w in the given LOG_FORMAT: slog JSON for "json" and
+// log.Printf-style lines starting with prefix otherwise
+func newFileSink(w io.Writer, format, prefix string) fileSink {
+	if format == "json" {
+		// FileLogger applies LOG_LEVEL itself, so the handler accepts every level
+		return jsonSink{slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+	}
+	return textSink{log.New(w, prefix, log.Ldate|log.Ltime|log.Lshortfile)}
+}
+
+// textSink writes "PREFIX: date time file:line message" lines
+type textSink struct {
+	logger *log.Logger
+}
+
+func (s textSink) Log(level slog.Level, msg string) {
+	if level == slog.LevelWarn {
+		msg = "WARNING: " + msg
+	}
+	// Skip Log and the FileLogger method so file:line points at the code that logged
+	s.logger.Output(3, msg)
+}
+
+// jsonSink writes {"time","level","msg"} objects in the same shape as the slog-based Logger
+type jsonSink struct {
+	logger *slog.Logger
+}
+
+func (s jsonSink) Log(level slog.Level, msg string) {
+	s.logger.Log(context.Background(), level, msg)
+}
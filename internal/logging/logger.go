@@ -2,6 +2,7 @@
 package logging
 
 import (
+	"io"
 	"log/slog"
 	"os"
 )
@@ -9,44 +10,107 @@ import (
 // Logger wraps slog.Logger with additional application-specific functionality
 type Logger struct {
 	*slog.Logger
-	service string
-	version string
+	level    *slog.LevelVar // shared by derived loggers so SetLevel applies everywhere
+	redactor *Redactor
+	service  string
+	version  string
 }
 
 // NewStructuredLogger creates a new structured logger with JSON output
 func NewStructuredLogger(level string, service, version string) *Logger {
-	var logLevel slog.Level
-	switch level {
-	case "debug":
-		logLevel = slog.LevelDebug
-	case "warn":
-		logLevel = slog.LevelWarn
-	case "error":
-		logLevel = slog.LevelError
-	default:
-		logLevel = slog.LevelInfo
-	}
+	return NewStructuredLoggerWithWriter(os.Stdout, level, service, version)
+}
+
+// NewStructuredLoggerWithWriter creates a new structured logger writing JSON output to w
+func NewStructuredLoggerWithWriter(w io.Writer, level string, service, version string) *Logger {
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(parseLevel(level))
 
 	// Create JSON handler for structured logging
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
 		Level: logLevel,
 	})
 
 	logger := slog.New(handler)
 
 	return &Logger{
-		Logger:  logger,
-		service: service,
-		version: version,
+		Logger:   logger,
+		level:    logLevel,
+		redactor: NewRedactor(DefaultRedactFields),
+		service:  service,
+		version:  version,
+	}
+}
+
+// parseLevel maps a LOG_LEVEL value to a slog level, defaulting to info
+func parseLevel(level string) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SetLevel atomically changes the minimum level of this logger and every logger derived from it
+func (l *Logger) SetLevel(level string) {
+	if l.level == nil {
+		return
+	}
+	l.level.Set(parseLevel(level))
+}
+
+// Level returns the current minimum level
+func (l *Logger) Level() slog.Level {
+	if l.level == nil {
+		return slog.LevelInfo
 	}
+	return l.level.Level()
+}
+
+// SetRedactFields replaces the keys whose values are masked in log arguments
+// It must be called during startup before the logger is shared or derived from
+func (l *Logger) SetRedactFields(fields []string) {
+	l.redactor = NewRedactor(fields)
 }
 
 // WithRequestID adds request ID to the logger
 func (l *Logger) WithRequestID(reqID string) *Logger {
 	return &Logger{
-		Logger:  l.Logger.With(slog.String("req_id", reqID)),
-		service: l.service,
-		version: l.version,
+		Logger:   l.Logger.With(slog.String("req_id", reqID)),
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
+	}
+}
+
+// WithCorrelationID adds the cross-service correlation ID to the logger; an empty ID adds nothing
+func (l *Logger) WithCorrelationID(id string) *Logger {
+	if id == "" {
+		return l
+	}
+	return &Logger{
+		Logger:   l.Logger.With(slog.String(FieldCorrelation, id)),
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
+	}
+}
+
+// WithClientIP adds the resolved client IP address to the logger
+func (l *Logger) WithClientIP(ip string) *Logger {
+	return &Logger{
+		Logger:   l.Logger.With(slog.String(FieldClientIP, ip)),
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
 	}
 }
 
@@ -59,8 +123,10 @@ func (l *Logger) WithHTTPRequest(method, path string, statusCode int, latencyMs
 			slog.Int("status", statusCode),
 			slog.Int64("latency_ms", latencyMs),
 		),
-		service: l.service,
-		version: l.version,
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
 	}
 }
 
@@ -70,9 +136,11 @@ func (l *Logger) WithError(err error) *Logger {
 		return l
 	}
 	return &Logger{
-		Logger:  l.Logger.With(slog.String("error", err.Error())),
-		service: l.service,
-		version: l.version,
+		Logger:   l.Logger.With(slog.String("error", err.Error())),
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
 	}
 }
 
@@ -83,29 +151,31 @@ func (l *Logger) WithServiceContext() *Logger {
 			slog.String("service", l.service),
 			slog.String("version", l.version),
 		),
-		service: l.service,
-		version: l.version,
+		level:    l.level,
+		redactor: l.redactor,
+		service:  l.service,
+		version:  l.version,
 	}
 }
 
 // Info logs an info message with structured context
 func (l *Logger) Info(msg string, args ...any) {
-	l.Logger.Info(msg, args...)
+	l.Logger.Info(msg, l.redactor.Redact(args)...)
 }
 
 // Error logs an error message with structured context
 func (l *Logger) Error(msg string, args ...any) {
-	l.Logger.Error(msg, args...)
+	l.Logger.Error(msg, l.redactor.Redact(args)...)
 }
 
 // Warn logs a warning message with structured context
 func (l *Logger) Warn(msg string, args ...any) {
-	l.Logger.Warn(msg, args...)
+	l.Logger.Warn(msg, l.redactor.Redact(args)...)
 }
 
 // Debug logs a debug message with structured context
 func (l *Logger) Debug(msg string, args ...any) {
-	l.Logger.Debug(msg, args...)
+	l.Logger.Debug(msg, l.redactor.Redact(args)...)
 }
 
 // Startup logs application startup information
@@ -113,16 +183,16 @@ func (l *Logger) Startup(msg string, args ...any) {
 	l.WithServiceContext().Info(msg, args...)
 }
 
-// Request logs HTTP request completion
-func (l *Logger) Request(reqID, method, path string, statusCode int, latencyMs int64) {
+// Request logs HTTP request completion, including the number of response body bytes sent
+func (l *Logger) Request(reqID, method, path string, statusCode int, latencyMs, bytes int64) {
 	l.WithRequestID(reqID).
 		WithHTTPRequest(method, path, statusCode, latencyMs).
-		Info("HTTP request completed")
+		Info("HTTP request completed", "bytes", bytes)
 }
 
 // Database logs database-related operations
 func (l *Logger) Database(msg string, args ...any) {
-	l.Logger.Info("database: "+msg, args...)
+	l.Info("database: "+msg, args...)
 }
 
 // DatabaseError logs database errors
@@ -132,5 +202,5 @@ func (l *Logger) DatabaseError(msg string, err error) {
 
 // HealthCheck logs health check operations
 func (l *Logger) HealthCheck(msg string, args ...any) {
-	l.Logger.Info("healthcheck: "+msg, args...)
+	l.Info("healthcheck: "+msg, args...)
 }
@@ -93,6 +93,34 @@ func TestLoggerWithRequestID(t *testing.T) {
 	}
 }
 
+func TestLoggerWithCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(&buf, LevelInfo, "test-service", "1.0.0")
+
+	logger.WithCorrelationID("flow-42").Info("correlated")
+	logger.WithCorrelationID("").Info("uncorrelated")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d", len(lines))
+	}
+
+	var correlated, uncorrelated map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &correlated); err != nil {
+		t.Fatalf("Failed to unmarshal log entry: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &uncorrelated); err != nil {
+		t.Fatalf("Failed to unmarshal log entry: %v", err)
+	}
+
+	if correlated[FieldCorrelation] != "flow-42" {
+		t.Errorf("Expected correlation ID flow-42, got %v", correlated[FieldCorrelation])
+	}
+	if _, ok := uncorrelated[FieldCorrelation]; ok {
+		t.Errorf("Expected no %s field for an empty ID, got %v", FieldCorrelation, uncorrelated[FieldCorrelation])
+	}
+}
+
 func TestLoggerWithHTTPRequest(t *testing.T) {
 	var buf bytes.Buffer
 	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
@@ -139,7 +167,7 @@ func TestLoggerRequest(t *testing.T) {
 		version: "1.0.0",
 	}
 
-	logger.Request("req-123", "POST", "/api/users", 201, 250)
+	logger.Request("req-123", "POST", "/api/users", 201, 250, 512)
 
 	var logEntry map[string]interface{}
 	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
@@ -155,6 +183,9 @@ func TestLoggerRequest(t *testing.T) {
 	if logEntry["method"] != "POST" {
 		t.Errorf("Expected method POST, got %v", logEntry["method"])
 	}
+	if logEntry["bytes"] != float64(512) {
+		t.Errorf("Expected bytes 512, got %v", logEntry["bytes"])
+	}
 }
 
 func TestLoggerDatabase(t *testing.T) {
@@ -201,3 +232,45 @@ func TestLoggerHealthCheck(t *testing.T) {
 		t.Errorf("Expected response time 25, got %v", logEntry["response_time_ms"])
 	}
 }
+
+func TestNewStructuredLoggerWithWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(&buf, "warn", "test-service", "1.0.0")
+
+	logger.Info("suppressed message")
+	logger.Warn("visible message")
+
+	output := buf.String()
+	if strings.Contains(output, "suppressed message") {
+		t.Error("Expected info message to be filtered at warn level")
+	}
+	if !strings.Contains(output, "visible message") {
+		t.Error("Expected warn message to be written to the provided writer")
+	}
+}
+
+func TestLoggerSetLevelAtRuntime(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+	derived := logger.WithRequestID("req-1").WithServiceContext()
+
+	derived.Debug("debug before")
+	if strings.Contains(buf.String(), "debug before") {
+		t.Error("Expected debug message to be filtered at info level")
+	}
+
+	logger.SetLevel("debug")
+	if logger.Level() != slog.LevelDebug {
+		t.Errorf("Expected level debug, got %v", logger.Level())
+	}
+	derived.Debug("debug enabled")
+	if !strings.Contains(buf.String(), "debug enabled") {
+		t.Error("Expected debug message after raising verbosity on the parent logger")
+	}
+
+	logger.SetLevel("info")
+	derived.Debug("debug after")
+	if strings.Contains(buf.String(), "debug after") {
+		t.Error("Expected debug message to be filtered again after lowering verbosity")
+	}
+}
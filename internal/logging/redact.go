@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue replaces the value of redacted log fields
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactFields are the log keys masked when LOG_REDACT_FIELDS is not set
+var DefaultRedactFields = []string{"password", "email"}
+
+// Redactor masks the values of configured keys in structured log arguments
+type Redactor struct {
+	keys map[string]bool
+}
+
+// NewRedactor creates a redactor for the given keys, matched case-insensitively
+func NewRedactor(keys []string) *Redactor {
+	r := &Redactor{keys: make(map[string]bool, len(keys))}
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			r.keys[key] = true
+		}
+	}
+	return r
+}
+
+// Redact returns args with values of matching keys replaced by RedactedValue
+// args follows the slog convention of alternating keys and values, optionally mixed with slog.Attr
+// The input slice is never modified
+func (r *Redactor) Redact(args []any) []any {
+	if r == nil || len(r.keys) == 0 || len(args) == 0 {
+		return args
+	}
+
+	var out []any
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case slog.Attr:
+			if r.matches(arg.Key) {
+				out = r.set(out, args, i, slog.String(arg.Key, RedactedValue))
+			}
+		case string:
+			if i+1 < len(args) && r.matches(arg) {
+				out = r.set(out, args, i+1, RedactedValue)
+			}
+			i++ // skip the value
+		}
+	}
+
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// matches reports whether key is configured for redaction
+func (r *Redactor) matches(key string) bool {
+	return r.keys[strings.ToLower(key)]
+}
+
+// set copies args on first use and replaces the element at i
+func (r *Redactor) set(out, args []any, i int, value any) []any {
+	if out == nil {
+		out = make([]any, len(args))
+		copy(out, args)
+	}
+	out[i] = value
+	return out
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestRedactorRedact(t *testing.T) {
+	redactor := NewRedactor([]string{"token", " SSN "})
+
+	args := []any{"Token", "secret-token", "user_id", "42", slog.String("ssn", "123-45-6789"), "count", 3}
+	got := redactor.Redact(args)
+
+	want := []any{"Token", RedactedValue, "user_id", "42", slog.String("ssn", RedactedValue), "count", 3}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d args, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if gotAttr, ok := got[i].(slog.Attr); ok {
+			if !gotAttr.Equal(want[i].(slog.Attr)) {
+				t.Errorf("arg %d: expected %v, got %v", i, want[i], got[i])
+			}
+			continue
+		}
+		if got[i] != want[i] {
+			t.Errorf("arg %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	if args[1] != "secret-token" {
+		t.Error("Expected input args to be left unmodified")
+	}
+}
+
+func TestRedactorNoMatchesReturnsInput(t *testing.T) {
+	redactor := NewRedactor([]string{"password"})
+	args := []any{"user_id", "42"}
+
+	got := redactor.Redact(args)
+	if &got[0] != &args[0] {
+		t.Error("Expected args without matches to be returned without copying")
+	}
+}
+
+func TestLoggerRedactsConfiguredFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(&buf, "debug", "test-service", "1.0.0")
+	logger.SetRedactFields([]string{"api_key", "password"})
+	logger = logger.WithRequestID("req-1")
+
+	logger.Info("info message", "api_key", "abc123", "user_id", "42")
+	logger.Warn("warn message", "password", "hunter2")
+	logger.Error("error message", "email", "john@example.com")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 log lines, got %d", len(lines))
+	}
+
+	entries := make([]map[string]any, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal(line, &entries[i]); err != nil {
+			t.Fatalf("Failed to parse log line %d: %v", i, err)
+		}
+	}
+
+	if entries[0]["api_key"] != RedactedValue {
+		t.Errorf("Expected api_key to be redacted, got %v", entries[0]["api_key"])
+	}
+	if entries[0]["user_id"] != "42" {
+		t.Errorf("Expected user_id to pass through, got %v", entries[0]["user_id"])
+	}
+	if entries[1]["password"] != RedactedValue {
+		t.Errorf("Expected password to be redacted, got %v", entries[1]["password"])
+	}
+	// email is a default field, but the custom list replaces the defaults
+	if entries[2]["email"] != "john@example.com" {
+		t.Errorf("Expected email to pass through with a custom list, got %v", entries[2]["email"])
+	}
+}
+
+func TestLoggerRedactsDefaultFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+
+	logger.Info("login attempt", "email", "john@example.com")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log line: %v", err)
+	}
+	if entry["email"] != RedactedValue {
+		t.Errorf("Expected email to be redacted by default, got %v", entry["email"])
+	}
+}
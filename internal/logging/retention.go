@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultLogDir is where FileLogger writes when LOG_DIR is not set
+const DefaultLogDir = "/app/logs"
+
+// LogCleanupInterval is how often RunLogCleanup applies LOG_RETENTION_DAYS
+const LogCleanupInterval = 24 * time.Hour
+
+// LogDir is a directory of log files that can be pruned by age
+type LogDir string
+
+// CleanupOldLogs removes files in the directory last modified more than days ago and returns how many were removed
+// Files that cannot be removed are skipped and reported in the returned error.
+func (d LogDir) CleanupOldLogs(days int) (int, error) {
+	return d.removeModifiedBefore(time.Now().AddDate(0, 0, -days))
+}
+
+// removeModifiedBefore removes regular files in the directory whose modification time is before cutoff
+func (d LogDir) removeModifiedBefore(cutoff time.Time) (int, error) {
+	files, err := os.ReadDir(string(d))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil // Nothing has been logged to the directory yet
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	removed := 0
+	var errs []error
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		fileInfo, err := file.Info()
+		if err != nil {
+			continue // Skip files removed or renamed since the directory was read
+		}
+		if !fileInfo.ModTime().Before(cutoff) {
+			continue
+		}
+
+		filePath := filepath.Join(string(d), file.Name())
+		if err := os.Remove(filePath); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove old log file %s: %w", filePath, err))
+			continue
+		}
+		removed++
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// RunLogCleanup removes log files older than days from dir immediately and then once per interval until ctx is done
+func RunLogCleanup(ctx context.Context, dir LogDir, days int, interval time.Duration, logger *Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := dir.CleanupOldLogs(days)
+		if err != nil {
+			logger.Warn("Log cleanup failed", FieldError, err, "dir", string(dir), "removed", removed)
+		} else if removed > 0 {
+			logger.Info("Removed old log files", "dir", string(dir), "removed", removed, "retention_days", days)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
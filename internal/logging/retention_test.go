@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeLogFile creates name in dir with its modification time set age ago
+func writeLogFile(t *testing.T, dir, name string, age time.Duration) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("INFO: test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set modification time of %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLogDirCleanupOldLogsRemovesFilesByAge(t *testing.T) {
+	dir := t.TempDir()
+	day := 24 * time.Hour
+	old := writeLogFile(t, dir, "app_2026-01-01_00-00-00.log", 10*day)
+	older := writeLogFile(t, dir, "error_2026-01-01_00-00-00.log", 30*day)
+	recent := writeLogFile(t, dir, "app_2026-10-15_00-00-00.log", day)
+	if err := os.Mkdir(filepath.Join(dir, "archive"), 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	removed, err := LogDir(dir).CleanupOldLogs(7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 files removed, got %d", removed)
+	}
+	for _, path := range []string{old, older} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", filepath.Base(path))
+		}
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("Expected recent log file to be kept, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "archive")); err != nil {
+		t.Errorf("Expected subdirectory to be kept, got %v", err)
+	}
+}
+
+func TestLogDirCleanupOldLogsMissingDirectory(t *testing.T) {
+	removed, err := LogDir(filepath.Join(t.TempDir(), "missing")).CleanupOldLogs(7)
+	if err != nil || removed != 0 {
+		t.Errorf("Expected nothing to clean in a missing directory, got %d, %v", removed, err)
+	}
+}
+
+func TestRunLogCleanupRunsImmediatelyAndStops(t *testing.T) {
+	dir := t.TempDir()
+	old := writeLogFile(t, dir, "app_2026-01-01_00-00-00.log", 30*24*time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		RunLogCleanup(ctx, LogDir(dir), 7, time.Hour, NewStructuredLogger("error", "goUserAPI", "test"))
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(old); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the first cleanup to run without waiting for the interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected RunLogCleanup to return after the context is cancelled")
+	}
+}
@@ -0,0 +1,161 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logFileTimestamp names log files by the time they were opened
+const logFileTimestamp = "2006-01-02_15-04-05"
+
+// rotatingFile is an io.Writer appending to <dir>/<prefix>_<timestamp>.log
+// Once a write would take the active file past maxSize bytes it is closed and a new timestamped file is
+// opened, after which only the newest maxBackups closed files with the same prefix are kept.
+// A maxSize of 0 never rotates and a maxBackups of 0 keeps every closed file. It is safe for concurrent use.
+type rotatingFile struct {
+	dir        string
+	prefix     string
+	maxSize    int64
+	maxBackups int
+	now        func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// newRotatingFile opens a new log file for prefix in dir
+func newRotatingFile(dir, prefix string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		dir:        dir,
+		prefix:     prefix,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Name returns the path of the active log file
+func (f *rotatingFile) Name() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Name()
+}
+
+// Write appends p to the active file, rotating first when p would not fit
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the active file; later writes fail with os.ErrClosed
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// rotate closes the active file, opens its successor and prunes old backups; f.mu must be held
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file for rotation: %w", err)
+	}
+	f.file = nil
+	if err := f.open(); err != nil {
+		return err
+	}
+	// Pruning is best effort: a backup that cannot be removed must not stop logging
+	f.pruneBackups()
+	return nil
+}
+
+// open creates a file named after the current time, adding a counter when that name is already taken
+func (f *rotatingFile) open() error {
+	base := fmt.Sprintf("%s_%s", f.prefix, f.now().Format(logFileTimestamp))
+	name := filepath.Join(f.dir, base+".log")
+	for i := 1; ; i++ {
+		file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0644)
+		if err == nil {
+			f.file = file
+			f.size = 0
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		name = filepath.Join(f.dir, fmt.Sprintf("%s_%d.log", base, i))
+	}
+}
+
+// pruneBackups removes the oldest closed files with the same prefix beyond maxBackups
+func (f *rotatingFile) pruneBackups() {
+	if f.maxBackups <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	active := filepath.Base(f.file.Name())
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == active || !strings.HasPrefix(name, f.prefix+"_") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since the directory was read
+		}
+		backups = append(backups, backup{path: filepath.Join(f.dir, name), modTime: info.ModTime()})
+	}
+	if len(backups) <= f.maxBackups {
+		return
+	}
+
+	// Newest first; names break ties between files closed within the filesystem's timestamp resolution
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].modTime.Equal(backups[j].modTime) {
+			return backups[i].modTime.After(backups[j].modTime)
+		}
+		return backups[i].path > backups[j].path
+	})
+
+	for _, old := range backups[f.maxBackups:] {
+		os.Remove(old.path)
+	}
+}
@@ -0,0 +1,204 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/config"
+)
+
+// logFilesWithPrefix lists the files in dir whose names start with prefix, sorted by name
+func logFilesWithPrefix(t *testing.T, dir, prefix string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read log directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix+"_") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newTestRotatingFile returns a rotatingFile whose clock advances one second per opened file
+func newTestRotatingFile(t *testing.T, dir string, maxSize int64, maxBackups int) *rotatingFile {
+	t.Helper()
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	f := &rotatingFile{dir: dir, prefix: "app", maxSize: maxSize, maxBackups: maxBackups, now: func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}}
+	if err := f.open(); err != nil {
+		t.Fatalf("Failed to open rotating file: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestRotatingFileRotatesPastMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	f := newTestRotatingFile(t, dir, 100, 0)
+	first := f.Name()
+
+	line := []byte(strings.Repeat("x", 39) + "\n")
+	for i := 0; i < 2; i++ {
+		if _, err := f.Write(line); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if f.Name() != first {
+		t.Fatalf("Expected no rotation below the size limit, got %s", f.Name())
+	}
+
+	if _, err := f.Write(line); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if f.Name() == first {
+		t.Fatal("Expected a new file once a write would exceed the size limit")
+	}
+
+	files := logFilesWithPrefix(t, dir, "app")
+	if len(files) != 2 {
+		t.Fatalf("Expected the old and the new file, got %v", files)
+	}
+	old, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatalf("Failed to read rotated file: %v", err)
+	}
+	if !bytes.Equal(old, bytes.Repeat(line, 2)) {
+		t.Errorf("Expected the rotated file to keep the first two lines, got %q", old)
+	}
+	current, _ := os.ReadFile(f.Name())
+	if !bytes.Equal(current, line) {
+		t.Errorf("Expected the new file to hold the third line, got %q", current)
+	}
+}
+
+func TestRotatingFilePrunesOldBackups(t *testing.T) {
+	dir := t.TempDir()
+	// Files of another kind are never pruned
+	other := filepath.Join(dir, "error_2026-01-01_00-00-00.log")
+	if err := os.WriteFile(other, []byte("ERROR: test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", other, err)
+	}
+
+	f := newTestRotatingFile(t, dir, 10, 2)
+	var names []string
+	for i := 0; i < 6; i++ {
+		if _, err := f.Write([]byte("0123456789")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		names = append(names, f.Name())
+	}
+
+	files := logFilesWithPrefix(t, dir, "app")
+	want := []string{filepath.Base(names[3]), filepath.Base(names[4]), filepath.Base(names[5])}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected the active file and the 2 newest backups %v, got %v", want, files)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("Expected files with another prefix to be kept, got %v", err)
+	}
+}
+
+func TestRotatingFileWithoutMaxSizeNeverRotates(t *testing.T) {
+	dir := t.TempDir()
+	f := newTestRotatingFile(t, dir, 0, 1)
+
+	for i := 0; i < 100; i++ {
+		if _, err := f.Write([]byte("0123456789\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if files := logFilesWithPrefix(t, dir, "app"); len(files) != 1 {
+		t.Errorf("Expected a single file, got %v", files)
+	}
+}
+
+func TestRotatingFileConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	f := newTestRotatingFile(t, dir, 1000, 0)
+
+	line := []byte(strings.Repeat("y", 99) + "\n")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				if _, err := f.Write(line); err != nil {
+					t.Errorf("Write failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	f.Close()
+
+	var total int
+	for _, name := range logFilesWithPrefix(t, dir, "app") {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if len(data) > 1000 {
+			t.Errorf("Expected %s to stay within the size limit, got %d bytes", name, len(data))
+		}
+		if len(data)%len(line) != 0 {
+			t.Errorf("Expected %s to hold whole lines, got %d bytes", name, len(data))
+		}
+		total += len(data)
+	}
+	if total != 8*25*len(line) {
+		t.Errorf("Expected every line to be written once, got %d bytes", total)
+	}
+}
+
+func TestRotatingFileSameSecondNames(t *testing.T) {
+	dir := t.TempDir()
+	f, err := newRotatingFile(dir, "app", 5, 0)
+	if err != nil {
+		t.Fatalf("Failed to open rotating file: %v", err)
+	}
+	defer f.Close()
+	f.now = func() time.Time { return time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC) }
+
+	for i := 0; i < 3; i++ {
+		if _, err := f.Write([]byte("12345")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if files := logFilesWithPrefix(t, dir, "app"); len(files) != 3 {
+		t.Errorf("Expected 3 distinct files when rotating within one second, got %v", files)
+	}
+}
+
+func TestFileLoggerRotatesAtMaxSizeMB(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewFileLogger(&config.LoggingConfig{Level: "info", Dir: dir, MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewFileLogger failed: %v", err)
+	}
+	defer logger.Close()
+
+	message := strings.Repeat("z", 64*1024)
+	for i := 0; i < 40; i++ {
+		logger.infoLogger.Log(slog.LevelInfo, message)
+	}
+
+	if files := logFilesWithPrefix(t, dir, "app"); len(files) != 2 {
+		t.Errorf("Expected the active file and one backup after writing 2.5 MB, got %v", files)
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+// APIKeyHeader carries the caller's API key
+const APIKeyHeader = "X-API-Key"
+
+// RequireAPIKey creates a middleware that rejects requests whose X-API-Key header does not match key
+// The comparison is constant-time so response timing does not reveal how much of the key matched.
+// An empty key rejects every request rather than accepting requests without a header.
+func RequireAPIKey(key string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasAPIKey(r, key) {
+				writeUnauthorizedResponse(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HasAPIKey reports whether the request's X-API-Key header matches key, compared in constant time
+// An empty key never matches.
+func HasAPIKey(r *http.Request, key string) bool {
+	provided := r.Header.Get(APIKeyHeader)
+	return key != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(key)) == 1
+}
+
+// writeUnauthorizedResponse writes a 401 Unauthorized error response
+func writeUnauthorizedResponse(w http.ResponseWriter) {
+	response := map[string]string{
+		"error": "Authentication required",
+		"code":  "UNAUTHORIZED",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// MaxBodySize creates a middleware that limits request bodies to maxBytes
+// Requests declaring a larger Content-Length are rejected immediately; bodies without a
+// declared length are wrapped with http.MaxBytesReader so handlers see *http.MaxBytesError
+func MaxBodySize(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				WritePayloadTooLargeResponse(w)
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WritePayloadTooLargeResponse writes a 413 Payload Too Large error response
+func WritePayloadTooLargeResponse(w http.ResponseWriter) {
+	response := map[string]string{
+		"error": "Request body too large",
+		"code":  "PAYLOAD_TOO_LARGE",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+	json.NewEncoder(w).Encode(response)
+}
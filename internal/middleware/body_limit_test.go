@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySizeRejectsDeclaredOversizedBody(t *testing.T) {
+	called := false
+	handler := MaxBodySize(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(strings.Repeat("x", 32)))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if called {
+		t.Error("Handler should not be called for an oversized body")
+	}
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	var response map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response["code"] != "PAYLOAD_TOO_LARGE" {
+		t.Errorf("Expected code PAYLOAD_TOO_LARGE, got %s", response["code"])
+	}
+}
+
+func TestMaxBodySizeLimitsUndeclaredBody(t *testing.T) {
+	var readErr error
+	handler := MaxBodySize(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(strings.Repeat("x", 32)))
+	req.ContentLength = -1 // Simulate chunked transfer without a declared length
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(readErr, &maxBytesErr) {
+		t.Errorf("Expected *http.MaxBytesError reading an oversized body, got %v", readErr)
+	}
+}
+
+func TestMaxBodySizeAllowsBodyWithinLimit(t *testing.T) {
+	var body []byte
+	handler := MaxBodySize(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader("small"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if string(body) != "small" {
+		t.Errorf("Expected body 'small', got '%s'", string(body))
+	}
+}
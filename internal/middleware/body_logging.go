@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+)
+
+// MaxLoggedBodyBytes caps how much of a request or response body is captured for debug logging
+const MaxLoggedBodyBytes = 4096
+
+// RedactedValue replaces the value of sensitive fields in logged bodies
+const RedactedValue = "[REDACTED]"
+
+// redactedBodyFields lists JSON field names whose values are never logged
+var redactedBodyFields = map[string]bool{
+	"password": true,
+	"email":    true,
+}
+
+// redactedFieldPattern matches sensitive string fields in bodies that are not valid JSON (e.g. truncated)
+var redactedFieldPattern = regexp.MustCompile(`(?i)("(?:password|email)"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// LogBodies logs size-capped, redacted request and response bodies at debug level
+// It must sit inside DecompressRequest and Gzip so that plaintext bodies are captured, not
+// wire bytes; the wire size of the response is reported by the access log.
+func LogBodies(logger *logging.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Capture bodies only when debug logging is enabled to keep the hot path cheap
+			if !logger.Enabled(r.Context(), slog.LevelDebug) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestBody := newCappedBuffer(MaxLoggedBodyBytes)
+			responseBody := newCappedBuffer(MaxLoggedBodyBytes)
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &teeReadCloser{Reader: io.TeeReader(r.Body, requestBody), closer: r.Body}
+			}
+
+			next.ServeHTTP(&bodyCaptureWriter{ResponseWriter: w, capture: responseBody}, r)
+
+			logger.WithRequestID(GetRequestID(r.Context())).WithCorrelationID(GetCorrelationID(r.Context())).Debug("HTTP request bodies",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_body", redactBody(requestBody.Bytes()),
+				"request_body_truncated", requestBody.truncated,
+				"response_body", redactBody(responseBody.Bytes()),
+				"response_body_truncated", responseBody.truncated,
+			)
+		})
+	}
+}
+
+// cappedBuffer stores up to limit bytes and silently discards the rest
+type cappedBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func newCappedBuffer(limit int) *cappedBuffer {
+	return &cappedBuffer{limit: limit}
+}
+
+// Write implements io.Writer and never fails so it is safe to use with io.TeeReader
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	remaining := c.limit - len(c.buf)
+	if remaining > 0 {
+		if len(p) > remaining {
+			c.buf = append(c.buf, p[:remaining]...)
+			c.truncated = true
+		} else {
+			c.buf = append(c.buf, p...)
+		}
+	} else if len(p) > 0 {
+		c.truncated = true
+	}
+	return len(p), nil
+}
+
+func (c *cappedBuffer) Bytes() []byte {
+	return c.buf
+}
+
+// teeReadCloser tees reads into a capture buffer while keeping the original Close
+type teeReadCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (t *teeReadCloser) Close() error {
+	return t.closer.Close()
+}
+
+// bodyCaptureWriter captures the response body while passing it through unchanged
+type bodyCaptureWriter struct {
+	http.ResponseWriter
+	capture *cappedBuffer
+}
+
+func (bw *bodyCaptureWriter) Write(data []byte) (int, error) {
+	n, err := bw.ResponseWriter.Write(data)
+	if n > 0 {
+		bw.capture.Write(data[:n])
+	}
+	return n, err
+}
+
+// Unwrap exposes the underlying writer so streaming handlers can still flush
+func (bw *bodyCaptureWriter) Unwrap() http.ResponseWriter {
+	return bw.ResponseWriter
+}
+
+// redactBody masks sensitive fields in a captured body before it is logged
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		if redacted, err := json.Marshal(redactValue(parsed)); err == nil {
+			return string(redacted)
+		}
+	}
+
+	// Not valid JSON (or truncated) - fall back to pattern-based masking
+	return redactedFieldPattern.ReplaceAllString(string(body), `${1}"`+RedactedValue+`"`)
+}
+
+// redactValue walks decoded JSON and replaces values of sensitive keys
+func redactValue(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if redactedBodyFields[strings.ToLower(key)] {
+				typed[key] = RedactedValue
+				continue
+			}
+			typed[key] = redactValue(value)
+		}
+		return typed
+	case []interface{}:
+		for i, value := range typed {
+			typed[i] = redactValue(value)
+		}
+		return typed
+	default:
+		return v
+	}
+}
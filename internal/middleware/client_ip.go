@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies is the set of proxy networks allowed to report the client IP via X-Forwarded-For
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses a list of CIDR ranges or bare IP addresses
+func ParseTrustedProxies(entries []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: must be an IP address or CIDR range", entry)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return proxies, nil
+}
+
+// Contains reports whether addr belongs to a trusted proxy network
+func (t TrustedProxies) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range t {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// trustedProxies is used by the rate limiter and request logging to resolve client IPs
+var trustedProxies TrustedProxies
+
+// SetTrustedProxies configures the proxies whose X-Forwarded-For entries are believed
+// It must be called during startup before any requests are served
+func SetTrustedProxies(proxies TrustedProxies) {
+	trustedProxies = proxies
+}
+
+// ClientIP returns the IP address of the client that sent r
+// X-Forwarded-For is only consulted when RemoteAddr is a trusted proxy; it is then walked from the
+// right, skipping trusted hops, so entries forged by the client (on the left) are never used.
+// It returns "" when RemoteAddr is not an IP address.
+func ClientIP(r *http.Request, trusted TrustedProxies) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	remote, err := netip.ParseAddr(host)
+	if err != nil {
+		return ""
+	}
+	remote = remote.Unmap()
+
+	if !trusted.Contains(remote) {
+		return remote.String()
+	}
+
+	client := remote
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// A malformed entry cannot be attributed; keep the last hop our proxies vouched for
+			break
+		}
+		client = hop.Unmap()
+		if !trusted.Contains(client) {
+			break
+		}
+	}
+	return client.String()
+}
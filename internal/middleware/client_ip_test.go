@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.5"})
+	if err != nil {
+		t.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		expected   string
+	}{
+		{"no proxy", "203.0.113.7:5000", nil, "203.0.113.7"},
+		{"forged header from untrusted client", "203.0.113.7:5000", []string{"1.2.3.4"}, "203.0.113.7"},
+		{"trusted proxy without header", "10.1.2.3:5000", nil, "10.1.2.3"},
+		{"trusted proxy appends client", "10.1.2.3:5000", []string{"198.51.100.9"}, "198.51.100.9"},
+		{"client-forged left entries are skipped", "10.1.2.3:5000", []string{"1.2.3.4, 198.51.100.9"}, "198.51.100.9"},
+		{"chain of trusted proxies", "10.1.2.3:5000", []string{"198.51.100.9, 192.168.1.5, 10.9.9.9"}, "198.51.100.9"},
+		{"multiple header lines", "10.1.2.3:5000", []string{"1.2.3.4", "198.51.100.9"}, "198.51.100.9"},
+		{"malformed hop stops the walk", "10.1.2.3:5000", []string{"198.51.100.9, garbage"}, "10.1.2.3"},
+		{"all hops trusted", "10.1.2.3:5000", []string{"10.4.4.4"}, "10.4.4.4"},
+		{"ipv6 client", "[2001:db8::1]:5000", nil, "2001:db8::1"},
+		{"non-ip remote address", "localhost:8080", nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, value := range tc.xff {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := ClientIP(req, trusted); got != tc.expected {
+				t.Errorf("Expected client IP %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsInvalidEntries(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/8", "not-an-ip"}); err == nil {
+		t.Error("Expected error for invalid trusted proxy entry")
+	}
+}
+
+func TestRateLimitIgnoresForgedForwardedForFromUntrustedClient(t *testing.T) {
+	SetTrustedProxies(nil)
+	defer SetTrustedProxies(nil)
+
+	handler := SecurityRateLimit(1.0, 1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Rotating X-Forwarded-For must not give the same client a fresh bucket
+	codes := make([]int, 0, 2)
+	for _, forged := range []string{"1.1.1.1", "2.2.2.2"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "203.0.113.7:5000"
+		req.Header.Set("X-Forwarded-For", forged)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected [200 429], got %v", codes)
+	}
+}
+
+func TestRateLimitUsesForwardedForFromTrustedProxy(t *testing.T) {
+	trusted, _ := ParseTrustedProxies([]string{"10.0.0.1"})
+	SetTrustedProxies(trusted)
+	defer SetTrustedProxies(nil)
+
+	handler := SecurityRateLimit(1.0, 1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Different clients behind the same proxy get separate buckets
+	for _, client := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		req.Header.Set("X-Forwarded-For", client)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Client %s: expected status 200, got %d", client, w.Code)
+		}
+	}
+}
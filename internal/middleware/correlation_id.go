@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// correlationIDKey is the context key for the correlation ID
+type correlationIDKey struct{}
+
+// CorrelationIDHeader is the HTTP header carrying an ID shared by every request of a multi-service flow
+// Unlike X-Request-ID it is never generated here: it is only logged and echoed when a client sends one.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// GetCorrelationID extracts the correlation ID from context, or "" when the request had none
+func GetCorrelationID(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// SetCorrelationID adds the correlation ID to context
+func SetCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDMiddleware stores a well-formed incoming X-Correlation-ID in the request context and echoes it
+// in the response. IDs follow the same format rules as request IDs; malformed ones are dropped.
+func CorrelationIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(CorrelationIDHeader); IsValidRequestID(id) {
+			w.Header().Set(CorrelationIDHeader, id)
+			r = r.WithContext(SetCorrelationID(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
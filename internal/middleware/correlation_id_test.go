@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
+)
+
+// correlatedHandler runs a handler that logs through the request-scoped logger behind the
+// request ID, correlation ID, access log and body log middleware, as the server chain does
+func correlatedHandler(logger *logging.Logger) http.Handler {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.WithRequestID(GetRequestID(r.Context())).
+			WithCorrelationID(GetCorrelationID(r.Context())).
+			Info("handler log line")
+		w.WriteHeader(http.StatusOK)
+	})
+	return RequestIDMiddleware(CorrelationIDMiddleware(NewLoggingMiddleware(logger, LogBodies(logger)(handler))))
+}
+
+func TestCorrelationIDInEveryLogLine(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, logging.LevelDebug, "test-service", "1.0.0")
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{"first_name":"Ada"}`))
+	req.Header.Set(CorrelationIDHeader, "checkout-flow-7")
+	w := httptest.NewRecorder()
+	correlatedHandler(logger).ServeHTTP(w, req)
+
+	if got := w.Header().Get(CorrelationIDHeader); got != "checkout-flow-7" {
+		t.Errorf("Expected %s to be echoed, got %q", CorrelationIDHeader, got)
+	}
+	if w.Header().Get(RequestIDHeader) == "checkout-flow-7" {
+		t.Error("Expected the request ID to stay distinct from the correlation ID")
+	}
+
+	entries := decodeLogLines(t, &buf)
+	if len(entries) < 3 {
+		t.Fatalf("Expected handler, body and access log lines, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry[logging.FieldCorrelation] != "checkout-flow-7" {
+			t.Errorf("Expected correlation_id on %q, got %v", entry["msg"], entry[logging.FieldCorrelation])
+		}
+	}
+}
+
+func TestCorrelationIDAbsentWithoutHeader(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, logging.LevelDebug, "test-service", "1.0.0")
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	w := httptest.NewRecorder()
+	correlatedHandler(logger).ServeHTTP(w, req)
+
+	if got := w.Header().Get(CorrelationIDHeader); got != "" {
+		t.Errorf("Expected no %s header, got %q", CorrelationIDHeader, got)
+	}
+
+	entries := decodeLogLines(t, &buf)
+	if len(entries) == 0 {
+		t.Fatal("Expected log lines")
+	}
+	for _, entry := range entries {
+		if _, ok := entry[logging.FieldCorrelation]; ok {
+			t.Errorf("Expected no correlation_id on %q, got %v", entry["msg"], entry[logging.FieldCorrelation])
+		}
+	}
+}
+
+func TestCorrelationIDMalformedIsDropped(t *testing.T) {
+	var seen string
+	handler := CorrelationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = GetCorrelationID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set(CorrelationIDHeader, "bad id\nwith newline")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if seen != "" {
+		t.Errorf("Expected a malformed correlation ID to be dropped, got %q", seen)
+	}
+	if got := w.Header().Get(CorrelationIDHeader); got != "" {
+		t.Errorf("Expected no %s header for a malformed ID, got %q", CorrelationIDHeader, got)
+	}
+}
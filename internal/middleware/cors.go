@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+)
+
+// corsExposedHeaders lists the response headers browser clients may read from cross-origin responses
+// X-Total-Count and Link carry list pagination, which data-grid frontends need to page through results.
+var corsExposedHeaders = strings.Join([]string{
+	"X-Total-Count",
+	"Link",
+	"Location",
+	RequestIDHeader,
+	CorrelationIDHeader,
+	"X-Response-Time",
+	"Deprecation",
+	"Sunset",
+	"Warning",
+}, ", ")
+
+// corsAllowedHeaders lists the request headers a cross-origin caller may send
+var corsAllowedHeaders = strings.Join([]string{
+	"Content-Type",
+	"Content-Encoding",
+	"Accept",
+	RequestIDHeader,
+	CorrelationIDHeader,
+	APIKeyHeader,
+	tenant.Header,
+}, ", ")
+
+// corsAllowedMethods lists the methods a cross-origin caller may use
+const corsAllowedMethods = "GET, HEAD, POST, OPTIONS"
+
+// CORS creates a middleware that allows cross-origin requests from the given origins
+// An entry of "*" allows any origin. With no origins the middleware passes requests through unchanged,
+// so CORS stays disabled unless it is configured.
+// Preflight requests from an allowed origin are answered with 204 and never reach the router.
+func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
+	allowAny := false
+	allowed := make(map[string]struct{}, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[origin] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(allowedOrigins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := allowed[origin]; !ok && !allowAny {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				header.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+				header.Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+				header.Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newCORSTestHandler(origins []string) http.Handler {
+	return CORS(origins)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "42")
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestCORSExposesTotalCountToAllowedOrigin(t *testing.T) {
+	handler := newCORSTestHandler([]string{"https://admin.example.com"})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://admin.example.com" {
+		t.Errorf("Expected Access-Control-Allow-Origin to echo the origin, got %q", got)
+	}
+	exposed := w.Header().Get("Access-Control-Expose-Headers")
+	for _, name := range []string{"X-Total-Count", "Link"} {
+		if !strings.Contains(exposed, name) {
+			t.Errorf("Expected Access-Control-Expose-Headers to include %s, got %q", name, exposed)
+		}
+	}
+}
+
+func TestCORSIgnoresUnlistedOrigin(t *testing.T) {
+	handler := newCORSTestHandler([]string{"https://admin.example.com"})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no Access-Control-Allow-Origin, got %q", got)
+	}
+}
+
+func TestCORSWildcardAllowsAnyOrigin(t *testing.T) {
+	handler := newCORSTestHandler([]string{"*"})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Origin", "https://grid.example.org")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://grid.example.org" {
+		t.Errorf("Expected Access-Control-Allow-Origin to echo the origin, got %q", got)
+	}
+}
+
+func TestCORSPreflightShortCircuits(t *testing.T) {
+	handler := newCORSTestHandler([]string{"https://admin.example.com"})
+
+	req := httptest.NewRequest("OPTIONS", "/users", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, "POST") {
+		t.Errorf("Expected Access-Control-Allow-Methods to include POST, got %q", got)
+	}
+	if got := w.Header().Get("X-Total-Count"); got != "" {
+		t.Errorf("Expected preflight not to reach the handler, got X-Total-Count %q", got)
+	}
+}
+
+func TestCORSDisabledWithoutOrigins(t *testing.T) {
+	handler := newCORSTestHandler(nil)
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected CORS to be disabled, got Access-Control-Allow-Origin %q", got)
+	}
+	if got := w.Header().Get("Vary"); got != "" {
+		t.Errorf("Expected no Vary header when CORS is disabled, got %q", got)
+	}
+}
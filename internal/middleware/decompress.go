@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DecompressRequest creates a middleware that transparently decodes request bodies sent with a
+// Content-Encoding listed in encodings; gzip is the only supported coding
+// The decoded body is capped at maxBytes so a small compressed payload cannot expand without bound:
+// reading past the cap fails with *http.MaxBytesError, as with MaxBodySize. Bodies in any other
+// encoding are rejected with 415 Unsupported Media Type.
+func DecompressRequest(encodings []string, maxBytes int64) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(encodings))
+	for _, encoding := range encodings {
+		allowed[strings.ToLower(strings.TrimSpace(encoding))] = true
+	}
+	acceptEncoding := strings.Join(encodings, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+			if encoding == "" || encoding == "identity" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if encoding != "gzip" || !allowed[encoding] {
+				// RFC 7694: tell the client which codings it may use instead
+				if acceptEncoding != "" {
+					w.Header().Set("Accept-Encoding", acceptEncoding)
+				}
+				writeContentEncodingError(w, http.StatusUnsupportedMediaType, "UNSUPPORTED_CONTENT_ENCODING",
+					"Unsupported Content-Encoding: "+encoding)
+				return
+			}
+
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				writeContentEncodingError(w, http.StatusBadRequest, "INVALID_CONTENT_ENCODING",
+					"Request body is not valid gzip")
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, &gzipRequestBody{Reader: zr, compressed: r.Body}, maxBytes)
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// gzipRequestBody reads decompressed bytes and closes both the gzip reader and the original body
+type gzipRequestBody struct {
+	*gzip.Reader
+	compressed io.ReadCloser
+}
+
+func (b *gzipRequestBody) Close() error {
+	b.Reader.Close()
+	return b.compressed.Close()
+}
+
+// writeContentEncodingError writes an error response for a request body that cannot be decoded
+func writeContentEncodingError(w http.ResponseWriter, status int, code, message string) {
+	response := map[string]string{
+		"error": message,
+		"code":  code,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressRequestDecodesGzipBody(t *testing.T) {
+	var body []byte
+	var encoding string
+	handler := DecompressRequest([]string{"gzip"}, 1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		encoding = r.Header.Get("Content-Encoding")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", bytes.NewReader(gzipBytes(t, []byte(`{"first_name":"John"}`))))
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if string(body) != `{"first_name":"John"}` {
+		t.Errorf("Expected decompressed body, got %q", body)
+	}
+	if encoding != "" {
+		t.Errorf("Expected Content-Encoding to be removed after decoding, got %q", encoding)
+	}
+}
+
+func TestDecompressRequestPassesIdentityBodyThrough(t *testing.T) {
+	var body []byte
+	handler := DecompressRequest([]string{"gzip"}, 1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{}`))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if string(body) != `{}` {
+		t.Errorf("Expected body to be passed through, got %q", body)
+	}
+}
+
+func TestDecompressRequestCapsDecompressedSize(t *testing.T) {
+	// 1 MiB of spaces compresses to about 1 KiB
+	bomb := gzipBytes(t, bytes.Repeat([]byte(" "), 1<<20))
+
+	var readErr error
+	var read int
+	handler := DecompressRequest([]string{"gzip"}, 4096)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data []byte
+		data, readErr = io.ReadAll(r.Body)
+		read = len(data)
+	}))
+
+	req := httptest.NewRequest("POST", "/users", bytes.NewReader(bomb))
+	req.Header.Set("Content-Encoding", "gzip")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(readErr, &maxBytesErr) {
+		t.Errorf("Expected *http.MaxBytesError reading a decompression bomb, got %v", readErr)
+	}
+	if read > 4096 {
+		t.Errorf("Expected at most 4096 decompressed bytes, read %d", read)
+	}
+}
+
+func TestDecompressRequestRejectsUnsupportedEncoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		encodings []string
+		encoding  string
+	}{
+		{"unknown coding", []string{"gzip"}, "br"},
+		{"stacked codings", []string{"gzip"}, "gzip, deflate"},
+		{"gzip not enabled", nil, "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := DecompressRequest(tt.encodings, 1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest("POST", "/users", strings.NewReader("data"))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if called {
+				t.Error("Handler should not be called for an unsupported encoding")
+			}
+			if w.Code != http.StatusUnsupportedMediaType {
+				t.Errorf("Expected status %d, got %d", http.StatusUnsupportedMediaType, w.Code)
+			}
+
+			var response map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response["code"] != "UNSUPPORTED_CONTENT_ENCODING" {
+				t.Errorf("Expected code UNSUPPORTED_CONTENT_ENCODING, got %s", response["code"])
+			}
+		})
+	}
+}
+
+func TestDecompressRequestRejectsInvalidGzip(t *testing.T) {
+	handler := DecompressRequest([]string{"gzip"}, 1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called for a body that is not gzip")
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"first_name":"John"}`))
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
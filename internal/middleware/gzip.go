@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultGzipContentTypes are the response media types compressed when no allowlist is configured
+var DefaultGzipContentTypes = []string{"application/json", "text/csv"}
+
+// Gzip creates a middleware that gzip-compresses responses for clients sending Accept-Encoding: gzip
+// Only responses whose media type is in contentTypes and whose body reaches minSize bytes are compressed;
+// smaller bodies and other types (already-compressed downloads, for example) are passed through unchanged.
+// The first minSize bytes are buffered to make that decision, so streamed responses are flushed as they go
+// once it has been made.
+func Gzip(minSize int, contentTypes []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		allowed[strings.ToLower(strings.TrimSpace(contentType))] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gw := &gzipResponseWriter{
+				ResponseWriter: w,
+				minSize:        minSize,
+				allowed:        allowed,
+				status:         http.StatusOK,
+			}
+			defer gw.close()
+
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding lists gzip with a non-zero quality
+func acceptsGzip(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept-Encoding") {
+		for _, entry := range strings.Split(value, ",") {
+			coding, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+			if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+				continue
+			}
+			if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				q, err := strconv.ParseFloat(value, 64)
+				return err == nil && q > 0
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter buffers the start of a response until it can decide whether to compress it
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minSize int
+	allowed map[string]bool
+
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+// WriteHeader records the status; it is sent once the compression decision is made
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	if gw.decided {
+		gw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	gw.status = code
+}
+
+func (gw *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !gw.decided {
+		gw.buf = append(gw.buf, data...)
+		if len(gw.buf) == 0 || len(gw.buf) < gw.minSize {
+			return len(data), nil
+		}
+		if err := gw.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if gw.gz != nil {
+		return gw.gz.Write(data)
+	}
+	return gw.ResponseWriter.Write(data)
+}
+
+// Flush commits to a decision with the bytes buffered so far and flushes them to the client
+func (gw *gzipResponseWriter) Flush() {
+	if !gw.decided {
+		if err := gw.decide(); err != nil {
+			return
+		}
+	}
+	if gw.gz != nil {
+		gw.gz.Flush()
+	}
+	http.NewResponseController(gw.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer so http.ResponseController can reach deadlines
+func (gw *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
+
+// decide sends the headers and buffered bytes, compressed when the response qualifies
+func (gw *gzipResponseWriter) decide() error {
+	gw.decided = true
+
+	buf := gw.buf
+	gw.buf = nil
+
+	if gw.shouldCompress(len(buf)) {
+		header := gw.ResponseWriter.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		gw.ResponseWriter.WriteHeader(gw.status)
+		gw.gz = gzip.NewWriter(gw.ResponseWriter)
+		_, err := gw.gz.Write(buf)
+		return err
+	}
+
+	gw.ResponseWriter.WriteHeader(gw.status)
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := gw.ResponseWriter.Write(buf)
+	return err
+}
+
+// shouldCompress reports whether a response with size buffered bytes qualifies for compression
+func (gw *gzipResponseWriter) shouldCompress(size int) bool {
+	if size == 0 || size < gw.minSize {
+		return false
+	}
+	if gw.status < http.StatusOK || gw.status == http.StatusNoContent || gw.status == http.StatusNotModified {
+		return false
+	}
+
+	header := gw.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return gw.allowed[mediaType]
+}
+
+// close finishes the response: small bodies are written uncompressed, compressed ones get their trailer
+func (gw *gzipResponseWriter) close() {
+	if !gw.decided {
+		if err := gw.decide(); err != nil {
+			return
+		}
+	}
+	if gw.gz != nil {
+		gw.gz.Close()
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serveGzip runs a handler writing body with contentType through Gzip(1024, DefaultGzipContentTypes)
+func serveGzip(t *testing.T, contentType, body, acceptEncoding string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := Gzip(1024, DefaultGzipContentTypes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, body)
+	}))
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestGzipSkipsBodyBelowMinSize(t *testing.T) {
+	body := `{"a":"bc"}`
+	w := serveGzip(t, "application/json", body, "gzip")
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+		t.Errorf("Expected no Content-Encoding for a %d-byte body, got %q", len(body), encoding)
+	}
+	if w.Body.String() != body {
+		t.Errorf("Expected body %q, got %q", body, w.Body.String())
+	}
+}
+
+func TestGzipCompressesLargeCSV(t *testing.T) {
+	body := strings.Repeat("id,first_name,last_name,age\n", 200)
+	w := serveGzip(t, "text/csv; charset=utf-8", body, "deflate, gzip")
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Fatalf("Expected Content-Encoding gzip, got %q", encoding)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+		t.Errorf("Expected Vary Accept-Encoding, got %q", vary)
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress body: %v", err)
+	}
+	if string(decoded) != body {
+		t.Error("Decompressed body does not match the original")
+	}
+}
+
+func TestGzipSkipsContentTypesOutsideAllowlist(t *testing.T) {
+	body := strings.Repeat("x", 4096)
+	w := serveGzip(t, "application/zip", body, "gzip")
+
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+		t.Errorf("Expected no Content-Encoding for application/zip, got %q", encoding)
+	}
+	if w.Body.Len() != len(body) {
+		t.Errorf("Expected %d uncompressed bytes, got %d", len(body), w.Body.Len())
+	}
+}
+
+func TestGzipRequiresAcceptEncoding(t *testing.T) {
+	body := strings.Repeat("x", 4096)
+
+	for _, acceptEncoding := range []string{"", "br", "gzip;q=0"} {
+		w := serveGzip(t, "application/json", body, acceptEncoding)
+		if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+			t.Errorf("Accept-Encoding %q: expected no Content-Encoding, got %q", acceptEncoding, encoding)
+		}
+	}
+}
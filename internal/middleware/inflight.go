@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// InFlightTracker is a gauge of requests currently being served
+// It gives graceful shutdown visibility into how many requests are still draining
+type InFlightTracker struct {
+	count atomic.Int64
+}
+
+// NewInFlightTracker creates a tracker with no requests in flight
+func NewInFlightTracker() *InFlightTracker {
+	return &InFlightTracker{}
+}
+
+// Middleware increments the gauge when a request enters and decrements it when the handler returns
+func (t *InFlightTracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.count.Add(1)
+		defer t.count.Add(-1)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Count returns the number of requests currently in flight
+func (t *InFlightTracker) Count() int64 {
+	return t.count.Load()
+}
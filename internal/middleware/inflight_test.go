@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightTrackerCountsActiveRequests(t *testing.T) {
+	tracker := NewInFlightTracker()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := tracker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+		close(done)
+	}()
+
+	<-entered
+	assert.Equal(t, int64(1), tracker.Count())
+
+	close(release)
+	<-done
+	assert.Equal(t, int64(0), tracker.Count())
+}
@@ -1,7 +1,11 @@
 package middleware
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/logging"
@@ -13,7 +17,37 @@ type LoggingMiddleware struct {
 	logger *logging.Logger
 }
 
+// Access log formats accepted by SetAccessLogFormat
+const (
+	AccessLogJSON = "json" // structured "HTTP request completed" entries through the application logger
+	AccessLogCLF  = "clf"  // Common Log Format lines for pipelines that do not parse JSON
+)
+
+// clfTimeLayout is the Common Log Format timestamp, e.g. 10/Oct/2000:13:55:36 -0700
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+var (
+	accessLogFormat           = AccessLogJSON
+	accessLogWriter io.Writer = os.Stdout
+)
+
+// SetAccessLogFormat selects how request completion is logged; with AccessLogCLF one line per request is written to w
+// Application logs, including slow request warnings and debug bodies, stay structured either way.
+func SetAccessLogFormat(format string, w io.Writer) {
+	accessLogFormat = format
+	accessLogWriter = w
+}
+
+// slowRequestThreshold is the duration above which a completed request is also logged as a warning (0 disables)
+var slowRequestThreshold time.Duration
+
+// SetSlowRequestThreshold configures when request logging flags a request with slow_request=true
+func SetSlowRequestThreshold(threshold time.Duration) {
+	slowRequestThreshold = threshold
+}
+
 // NewLoggingMiddleware creates a new structured logging middleware
+// Debug body logging is done separately by LogBodies, inside the compression layers
 func NewLoggingMiddleware(logger *logging.Logger, next http.Handler) *LoggingMiddleware {
 	return &LoggingMiddleware{
 		next:   next,
@@ -42,12 +76,42 @@ func (lm *LoggingMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Calculate duration
 	duration := time.Since(start)
 
-	// Log request completion using structured logging
-	lm.logger.Request(
-		reqID,
-		r.Method,
-		r.URL.Path,
-		wrapped.StatusCode(),
-		duration.Milliseconds(),
-	)
+	// Log request completion, attributed to the client rather than a proxy
+	clientIP := ClientIP(r, trustedProxies)
+	requestLogger := lm.logger.WithClientIP(clientIP).WithCorrelationID(GetCorrelationID(r.Context()))
+	if accessLogFormat == AccessLogCLF {
+		writeCLFLine(accessLogWriter, clientIP, start, r, wrapped.StatusCode(), wrapped.BytesWritten())
+	} else {
+		requestLogger.Request(
+			reqID,
+			r.Method,
+			r.URL.Path,
+			wrapped.StatusCode(),
+			duration.Milliseconds(),
+			wrapped.BytesWritten(),
+		)
+	}
+
+	if slowRequestThreshold > 0 && duration > slowRequestThreshold {
+		requestLogger.WithRequestID(reqID).
+			WithHTTPRequest(r.Method, r.URL.Path, wrapped.StatusCode(), duration.Milliseconds()).
+			Warn("Slow request",
+				"slow_request", true,
+				"threshold_ms", slowRequestThreshold.Milliseconds(),
+			)
+	}
+}
+
+// writeCLFLine writes one Common Log Format access line: ip - - [time] "METHOD path proto" status bytes
+// An empty body is logged as "-" like other CLF producers.
+func writeCLFLine(w io.Writer, clientIP string, start time.Time, r *http.Request, status int, bytes int64) {
+	if clientIP == "" {
+		clientIP = "-"
+	}
+	size := "-"
+	if bytes > 0 {
+		size = strconv.FormatInt(bytes, 10)
+	}
+	fmt.Fprintf(w, "%s - - [%s] \"%s %s %s\" %d %s\n",
+		clientIP, start.Format(clfTimeLayout), r.Method, r.URL.Path, r.Proto, status, size)
 }
@@ -1,9 +1,16 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/chybatronik/goUserAPI/internal/logging"
 )
@@ -46,3 +53,319 @@ func TestNewLoggingMiddleware(t *testing.T) {
 		t.Error("Expected logger to be set")
 	}
 }
+
+// decodeLogLines parses newline-delimited JSON log output
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to decode log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLogBodiesRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "debug", "test-service", "1.0.0")
+
+	var handlerSaw string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		handlerSaw = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1","email":"jane@example.com","first_name":"Jane"}`))
+	})
+
+	requestBody := `{"first_name":"Jane","password":"s3cret","email":"jane@example.com"}`
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(requestBody))
+	w := httptest.NewRecorder()
+
+	LogBodies(logger)(handler).ServeHTTP(w, req)
+
+	if handlerSaw != requestBody {
+		t.Errorf("Handler should receive the full body, got %q", handlerSaw)
+	}
+	if !strings.Contains(w.Body.String(), "jane@example.com") {
+		t.Errorf("Response body should be passed through unchanged, got %q", w.Body.String())
+	}
+
+	var bodyEntry map[string]interface{}
+	for _, entry := range decodeLogLines(t, &buf) {
+		if entry["msg"] == "HTTP request bodies" {
+			bodyEntry = entry
+		}
+	}
+	if bodyEntry == nil {
+		t.Fatal("Expected a debug body log entry")
+	}
+
+	loggedRequest, _ := bodyEntry["request_body"].(string)
+	loggedResponse, _ := bodyEntry["response_body"].(string)
+	for _, secret := range []string{"s3cret", "jane@example.com"} {
+		if strings.Contains(loggedRequest, secret) || strings.Contains(loggedResponse, secret) {
+			t.Errorf("Sensitive value %q leaked into logs", secret)
+		}
+	}
+	if !strings.Contains(loggedRequest, RedactedValue) || !strings.Contains(loggedResponse, RedactedValue) {
+		t.Errorf("Expected redaction marker in logged bodies, got request=%q response=%q", loggedRequest, loggedResponse)
+	}
+	if !strings.Contains(loggedRequest, "Jane") {
+		t.Errorf("Non-sensitive fields should be logged, got %q", loggedRequest)
+	}
+}
+
+func TestLogBodiesCapped(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "debug", "test-service", "1.0.0")
+
+	large := strings.Repeat("a", MaxLoggedBodyBytes*2)
+	var received int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(large))
+	LogBodies(logger)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	if received != len(large) {
+		t.Errorf("Handler should receive %d bytes, got %d", len(large), received)
+	}
+	for _, entry := range decodeLogLines(t, &buf) {
+		if entry["msg"] == "HTTP request bodies" {
+			if logged, _ := entry["request_body"].(string); len(logged) > MaxLoggedBodyBytes {
+				t.Errorf("Logged body should be capped at %d bytes, got %d", MaxLoggedBodyBytes, len(logged))
+			}
+			if entry["request_body_truncated"] != true {
+				t.Error("Expected request_body_truncated to be true")
+			}
+		}
+	}
+}
+
+func TestLoggingMiddlewareNoBodiesAboveDebug(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Write([]byte(`{"ok":true}`))
+	})
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"password":"s3cret"}`))
+	NewLoggingMiddleware(logger, LogBodies(logger)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := decodeLogLines(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("Expected only the request completion entry, got %d entries", len(entries))
+	}
+	if entries[0]["msg"] != "HTTP request completed" {
+		t.Errorf("Unexpected log entry: %v", entries[0])
+	}
+	if strings.Contains(buf.String(), "request_body") {
+		t.Error("Bodies must not be logged above debug level")
+	}
+}
+
+func TestLogBodiesInsideCompressionLogsPlaintext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "debug", "test-service", "1.0.0")
+
+	responseBody := `{"first_name":"` + strings.Repeat("a", 2048) + `"}`
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, responseBody)
+	})
+	// Same nesting as cmd/server: Gzip -> DecompressRequest -> LogBodies -> handler
+	handler = LogBodies(logger)(handler)
+	handler = DecompressRequest([]string{"gzip"}, 1024)(handler)
+	handler = Gzip(1024, DefaultGzipContentTypes)(handler)
+
+	req := httptest.NewRequest("POST", "/users", bytes.NewReader(gzipBytes(t, []byte(`{"first_name":"John"}`))))
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected a gzip response, got Content-Encoding %q", w.Header().Get("Content-Encoding"))
+	}
+
+	var bodyEntry map[string]interface{}
+	for _, entry := range decodeLogLines(t, &buf) {
+		if entry["msg"] == "HTTP request bodies" {
+			bodyEntry = entry
+		}
+	}
+	if bodyEntry == nil {
+		t.Fatal("Expected a debug body log entry")
+	}
+	if bodyEntry["request_body"] != `{"first_name":"John"}` {
+		t.Errorf("Expected the decompressed request body to be logged, got %q", bodyEntry["request_body"])
+	}
+	if bodyEntry["response_body"] != responseBody {
+		t.Errorf("Expected the uncompressed response body to be logged, got %.40q", bodyEntry["response_body"])
+	}
+}
+
+func TestRedactBodyTruncatedJSON(t *testing.T) {
+	redacted := redactBody([]byte(`{"email":"jane@example.com","first_name":"Ja`))
+	if strings.Contains(redacted, "jane@example.com") {
+		t.Errorf("Expected email to be redacted from truncated body, got %q", redacted)
+	}
+}
+
+func TestLoggingMiddlewareLogsClientIPBehindTrustedProxy(t *testing.T) {
+	trusted, _ := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	SetTrustedProxies(trusted)
+	defer SetTrustedProxies(nil)
+
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.RemoteAddr = "10.0.0.2:5000"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 198.51.100.9")
+	NewLoggingMiddleware(logger, handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := decodeLogLines(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("Expected one log entry, got %d", len(entries))
+	}
+	if entries[0]["client_ip"] != "198.51.100.9" {
+		t.Errorf("Expected client_ip 198.51.100.9, got %v", entries[0]["client_ip"])
+	}
+}
+
+func TestLoggingMiddlewareWarnsOnSlowRequest(t *testing.T) {
+	SetSlowRequestThreshold(10 * time.Millisecond)
+	defer SetSlowRequestThreshold(0)
+
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+
+	handler := func(sleep time.Duration) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(sleep)
+			w.WriteHeader(http.StatusAccepted)
+		})
+	}
+
+	slowWarnings := func() []map[string]interface{} {
+		var warnings []map[string]interface{}
+		for _, entry := range decodeLogLines(t, &buf) {
+			if entry["slow_request"] == true {
+				warnings = append(warnings, entry)
+			}
+		}
+		return warnings
+	}
+
+	NewLoggingMiddleware(logger, handler(0)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fast", nil))
+	if warnings := slowWarnings(); len(warnings) != 0 {
+		t.Fatalf("Expected no slow request warning for a fast request, got %v", warnings)
+	}
+
+	NewLoggingMiddleware(logger, handler(30*time.Millisecond)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/slow", nil))
+	warnings := slowWarnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected one slow request warning, got %d", len(warnings))
+	}
+
+	warning := warnings[0]
+	if warning["level"] != "WARN" {
+		t.Errorf("Expected WARN level, got %v", warning["level"])
+	}
+	if warning["method"] != "POST" || warning["path"] != "/slow" {
+		t.Errorf("Expected POST /slow, got %v %v", warning["method"], warning["path"])
+	}
+	if warning["status"] != float64(http.StatusAccepted) {
+		t.Errorf("Expected status %d, got %v", http.StatusAccepted, warning["status"])
+	}
+	if latency, _ := warning["latency_ms"].(float64); latency < 30 {
+		t.Errorf("Expected latency_ms >= 30, got %v", warning["latency_ms"])
+	}
+}
+
+func TestLoggingMiddlewareCommonLogFormat(t *testing.T) {
+	var accessLog, appLog bytes.Buffer
+	SetAccessLogFormat(AccessLogCLF, &accessLog)
+	defer SetAccessLogFormat(AccessLogJSON, os.Stdout)
+
+	logger := logging.NewStructuredLoggerWithWriter(&appLog, "info", "test-service", "1.0.0")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+
+	req := httptest.NewRequest("POST", "/users?pretty=true", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	NewLoggingMiddleware(logger, handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	clfLine := regexp.MustCompile(`^203\.0\.113\.7 - - \[\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "POST /users HTTP/1\.1" 201 10\n$`)
+	if !clfLine.MatchString(accessLog.String()) {
+		t.Errorf("Access log line %q does not match Common Log Format", accessLog.String())
+	}
+	if strings.Contains(appLog.String(), "HTTP request completed") {
+		t.Errorf("Expected no JSON completion entry in CLF mode, got %q", appLog.String())
+	}
+}
+
+func TestLoggingMiddlewareLogsResponseBytes(t *testing.T) {
+	body := strings.Repeat(`{"first_name":"Jane","last_name":"Doe"}`, 100)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+	}{
+		{"plain", ""},
+		{"gzip", "gzip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+
+			// Same order as the server: compression runs inside logging
+			chain := NewLoggingMiddleware(logger, Gzip(1024, DefaultGzipContentTypes)(handler))
+			req := httptest.NewRequest("GET", "/users", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			chain.ServeHTTP(w, req)
+
+			var logged interface{}
+			for _, entry := range decodeLogLines(t, &buf) {
+				if entry["msg"] == "HTTP request completed" {
+					logged = entry["bytes"]
+				}
+			}
+			if logged != float64(w.Body.Len()) {
+				t.Errorf("Expected logged bytes %d (bytes sent), got %v", w.Body.Len(), logged)
+			}
+			if tc.acceptEncoding == "gzip" && w.Body.Len() >= len(body) {
+				t.Errorf("Expected a compressed response smaller than %d bytes, got %d", len(body), w.Body.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// maintenanceExemptPrefix marks routes that keep accepting writes in maintenance mode,
+// so operators can still switch it off through the admin endpoint
+const maintenanceExemptPrefix = "/admin/"
+
+// MaintenanceMode is a runtime switch that blocks mutating requests while reads continue
+// It is safe to toggle from an admin handler while requests are being served.
+type MaintenanceMode struct {
+	enabled atomic.Bool
+}
+
+// NewMaintenanceMode creates a switch in the given initial state
+func NewMaintenanceMode(enabled bool) *MaintenanceMode {
+	m := &MaintenanceMode{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether mutating requests are currently rejected
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled turns maintenance mode on or off
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Middleware answers POST, PUT, PATCH and DELETE with 503 MAINTENANCE while maintenance mode is on
+// GET, HEAD and OPTIONS are always passed through, as are /admin/ routes.
+func (m *MaintenanceMode) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.Enabled() && isMutatingMethod(r.Method) && !strings.HasPrefix(r.URL.Path, maintenanceExemptPrefix) {
+			writeMaintenanceResponse(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isMutatingMethod reports whether method changes server state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeMaintenanceResponse writes a 503 Service Unavailable error response for blocked writes
+func writeMaintenanceResponse(w http.ResponseWriter) {
+	response := map[string]string{
+		"error": "Service is in maintenance mode, writes are temporarily disabled",
+		"code":  "MAINTENANCE",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", "60")
+	w.WriteHeader(http.StatusServiceUnavailable)
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMaintenanceTestHandler(mode *MaintenanceMode) http.Handler {
+	return mode.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestMaintenanceModeBlocksPost(t *testing.T) {
+	handler := newMaintenanceTestHandler(NewMaintenanceMode(true))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	var response map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response["code"] != "MAINTENANCE" {
+		t.Errorf("Expected code MAINTENANCE, got %q", response["code"])
+	}
+}
+
+func TestMaintenanceModeAllowsReads(t *testing.T) {
+	handler := newMaintenanceTestHandler(NewMaintenanceMode(true))
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, "/users", nil))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected %s to pass through with status %d, got %d", method, http.StatusOK, w.Code)
+		}
+	}
+}
+
+func TestMaintenanceModeExemptsAdminRoutes(t *testing.T) {
+	handler := newMaintenanceTestHandler(NewMaintenanceMode(true))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/maintenance", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected admin route to pass through, got %d", w.Code)
+	}
+}
+
+func TestMaintenanceModeToggle(t *testing.T) {
+	mode := NewMaintenanceMode(false)
+	handler := newMaintenanceTestHandler(mode)
+
+	for _, tt := range []struct {
+		enabled bool
+		want    int
+	}{
+		{false, http.StatusOK},
+		{true, http.StatusServiceUnavailable},
+		{false, http.StatusOK},
+	} {
+		mode.SetEnabled(tt.enabled)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/users/1", nil))
+
+		if w.Code != tt.want {
+			t.Errorf("Expected DELETE with maintenance=%v to return %d, got %d", tt.enabled, tt.want, w.Code)
+		}
+	}
+}
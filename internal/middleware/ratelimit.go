@@ -3,15 +3,26 @@ package middleware
 import (
 	"encoding/json"
 	"log"
-	"net"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/chybatronik/goUserAPI/internal/logging"
 	"golang.org/x/time/rate"
 )
 
+// rateLimitLogInterval is the minimum time between two rate-limit warnings for the same client
+const rateLimitLogInterval = time.Second
+
+// rateLimitLogger receives rate-limit warnings; nil falls back to the standard logger
+var rateLimitLogger *logging.Logger
+
+// SetRateLimitLogger sends rate-limit warnings to logger as structured entries
+// It must be called during startup before any requests are served
+func SetRateLimitLogger(logger *logging.Logger) {
+	rateLimitLogger = logger
+}
+
 // RateLimiter implements IP-based rate limiting for security
 type RateLimiter struct {
 	visitors map[string]*Visitor
@@ -24,6 +35,16 @@ type RateLimiter struct {
 type Visitor struct {
 	limiter  *rate.Limiter
 	lastSeen time.Time
+
+	// lastLogged and suppressed sample rate-limit warnings to one per rateLimitLogInterval
+	lastLogged time.Time
+	suppressed int
+}
+
+// rateLimitBlock describes a rejected request for logging
+type rateLimitBlock struct {
+	tokens     float64 // tokens left in the client's bucket
+	suppressed int     // blocks not logged since the previous warning
 }
 
 // SecurityRateLimit creates a rate limiting middleware for security
@@ -41,7 +62,7 @@ func SecurityRateLimit(requestsPerSecond float64, burst int) func(http.Handler)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := extractIP(r)
+			ip := ClientIP(r, trustedProxies)
 			if ip == "" {
 				// If we can't extract IP, allow request but log
 				log.Printf("Rate limiting: unable to extract IP from %s", r.RemoteAddr)
@@ -50,7 +71,9 @@ func SecurityRateLimit(requestsPerSecond float64, burst int) func(http.Handler)
 			}
 
 			if !limiter.Allow(ip) {
-				log.Printf("Rate limit exceeded for IP: %s", ip)
+				if block, ok := limiter.sampleBlock(ip); ok {
+					limiter.logBlock(r, ip, block)
+				}
 				writeRateLimitErrorResponse(w)
 				return
 			}
@@ -74,7 +97,7 @@ func (rl *RateLimiter) Allow(ip string) bool {
 	if !exists {
 		// Create new limiter for this IP
 		limiter := rate.NewLimiter(rl.rate, rl.burst)
-		rl.visitors[ip] = &Visitor{limiter, time.Now()}
+		rl.visitors[ip] = &Visitor{limiter: limiter, lastSeen: time.Now()}
 		return limiter.Allow()
 	}
 
@@ -83,6 +106,48 @@ func (rl *RateLimiter) Allow(ip string) bool {
 	return visitor.limiter.Allow()
 }
 
+// sampleBlock records a rejected request for ip and reports whether it should be logged
+// At most one block per client is logged per rateLimitLogInterval; the rest are counted and
+// reported with the next logged block.
+func (rl *RateLimiter) sampleBlock(ip string) (rateLimitBlock, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	visitor, exists := rl.visitors[ip]
+	if !exists {
+		return rateLimitBlock{}, true
+	}
+
+	now := time.Now()
+	if now.Sub(visitor.lastLogged) < rateLimitLogInterval {
+		visitor.suppressed++
+		return rateLimitBlock{}, false
+	}
+
+	block := rateLimitBlock{tokens: visitor.limiter.TokensAt(now), suppressed: visitor.suppressed}
+	visitor.lastLogged = now
+	visitor.suppressed = 0
+	return block, true
+}
+
+// logBlock writes a warning for a rejected request
+func (rl *RateLimiter) logBlock(r *http.Request, ip string, block rateLimitBlock) {
+	if rateLimitLogger == nil {
+		log.Printf("Rate limit exceeded for IP: %s", ip)
+		return
+	}
+
+	rateLimitLogger.Warn("rate limit exceeded",
+		logging.FieldClientIP, ip,
+		logging.FieldHTTPMethod, r.Method,
+		logging.FieldHTTPPath, r.URL.Path,
+		"tokens", block.tokens,
+		"rate_per_second", float64(rl.rate),
+		"burst", rl.burst,
+		"suppressed", block.suppressed,
+	)
+}
+
 // cleanupVisitors removes old visitors to prevent memory leaks
 func (rl *RateLimiter) cleanupVisitors() {
 	ticker := time.NewTicker(5 * time.Minute)
@@ -100,47 +165,6 @@ func (rl *RateLimiter) cleanupVisitors() {
 	}
 }
 
-// extractIP extracts the real client IP from request
-func extractIP(r *http.Request) string {
-	// Try X-Forwarded-For header first (for proxies/load balancers)
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		// X-Forwarded-For can contain multiple IPs, take the first one
-		ips := strings.Split(xff, ",")
-		ip := strings.TrimSpace(ips[0])
-		if isValidIP(ip) {
-			return ip
-		}
-	}
-
-	// Try X-Real-IP header
-	xri := r.Header.Get("X-Real-IP")
-	if xri != "" && isValidIP(xri) {
-		return xri
-	}
-
-	// Fall back to RemoteAddr
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		// If SplitHostPort fails, try using RemoteAddr directly
-		if isValidIP(r.RemoteAddr) {
-			return r.RemoteAddr
-		}
-		return ""
-	}
-
-	if isValidIP(host) {
-		return host
-	}
-
-	return ""
-}
-
-// isValidIP checks if the string is a valid IP address
-func isValidIP(ip string) bool {
-	return net.ParseIP(ip) != nil
-}
-
 // writeRateLimitErrorResponse writes a rate limit error response
 func writeRateLimitErrorResponse(w http.ResponseWriter) {
 	response := map[string]string{
@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
 )
 
 func TestSecurityRateLimit(t *testing.T) {
@@ -234,3 +237,42 @@ func TestRateLimitHeaderParsing(t *testing.T) {
 		}
 	}
 }
+
+func TestRateLimitLogsBlocksOncePerSecond(t *testing.T) {
+	var buf bytes.Buffer
+	SetRateLimitLogger(logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0"))
+	defer SetRateLimitLogger(nil)
+
+	handler := SecurityRateLimit(0.001, 1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	blocked := 0
+	for i := 0; i < 20; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/reports", nil)
+		req.RemoteAddr = "192.168.1.50:12345"
+		handler.ServeHTTP(w, req)
+		if w.Code == http.StatusTooManyRequests {
+			blocked++
+		}
+	}
+	if blocked != 19 {
+		t.Fatalf("Expected 19 blocked requests, got %d", blocked)
+	}
+
+	entries := decodeLogLines(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("Expected a single rate limit warning, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["msg"] != "rate limit exceeded" || entry["level"] != "WARN" {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+	if entry["client_ip"] != "192.168.1.50" || entry["path"] != "/reports" {
+		t.Errorf("Expected client key and path in log entry, got %v", entry)
+	}
+	if _, ok := entry["tokens"]; !ok {
+		t.Errorf("Expected bucket state in log entry, got %v", entry)
+	}
+}
@@ -2,11 +2,16 @@
 package middleware
 
 import (
+	"container/list"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
 )
 
 // RequestIDKey is the context key for request ID
@@ -17,8 +22,18 @@ const (
 	RequestIDContextKey RequestIDKey = "req_id"
 	// RequestIDHeader is the HTTP header name for request ID
 	RequestIDHeader = "X-Request-ID"
+	// MaxRequestIDLength bounds client-supplied request IDs so they cannot bloat logs
+	MaxRequestIDLength = 128
 )
 
+// requestIDPattern accepts UUIDs, hex IDs and similar opaque tokens from upstream services
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// IsValidRequestID reports whether a client-supplied request ID can be reused as-is
+func IsValidRequestID(reqID string) bool {
+	return len(reqID) > 0 && len(reqID) <= MaxRequestIDLength && requestIDPattern.MatchString(reqID)
+}
+
 // GenerateRequestID generates a unique request ID using crypto/rand
 func GenerateRequestID() string {
 	b := make([]byte, 16)
@@ -49,13 +64,76 @@ func SetRequestID(ctx context.Context, reqID string) context.Context {
 	return context.WithValue(ctx, RequestIDContextKey, reqID)
 }
 
+// recentRequestIDs is a fixed-size LRU of request IDs seen recently
+type recentRequestIDs struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // most recently seen at the front
+	entries map[string]*list.Element
+}
+
+// newRecentRequestIDs creates an LRU remembering the last size request IDs
+func newRecentRequestIDs(size int) *recentRequestIDs {
+	return &recentRequestIDs{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// observe records reqID and reports whether it was already among the remembered IDs
+func (c *recentRequestIDs) observe(reqID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[reqID]; ok {
+		c.order.MoveToFront(element)
+		return true
+	}
+
+	c.entries[reqID] = c.order.PushFront(reqID)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(string))
+	}
+	return false
+}
+
+// duplicateRequestIDs and duplicateRequestIDLogger are set by SetDuplicateRequestIDDetection
+var (
+	duplicateRequestIDs      *recentRequestIDs
+	duplicateRequestIDLogger *logging.Logger
+)
+
+// SetDuplicateRequestIDDetection makes RequestIDMiddleware warn when an incoming X-Request-ID repeats
+// one of the last window IDs it accepted, which usually means a client retried with the same ID.
+// Duplicates are only logged, never rejected. A window of 0 disables detection.
+// It must be called during startup before any requests are served.
+func SetDuplicateRequestIDDetection(logger *logging.Logger, window int) {
+	if window <= 0 || logger == nil {
+		duplicateRequestIDs, duplicateRequestIDLogger = nil, nil
+		return
+	}
+	duplicateRequestIDs, duplicateRequestIDLogger = newRecentRequestIDs(window), logger
+}
+
 // RequestIDMiddleware ensures request ID is present and adds it to context
 func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Check if request ID exists in header
+		// Reuse a well-formed incoming request ID for cross-service correlation
 		reqID := r.Header.Get(RequestIDHeader)
-		if reqID == "" {
-			// Generate new request ID if not present
+		if IsValidRequestID(reqID) {
+			// Generated IDs are random, so only client-supplied ones can repeat
+			if duplicateRequestIDs != nil && duplicateRequestIDs.observe(reqID) {
+				duplicateRequestIDLogger.Warn("duplicate request id observed",
+					logging.FieldRequestID, reqID,
+					logging.FieldHTTPMethod, r.Method,
+					logging.FieldHTTPPath, r.URL.Path,
+				)
+			}
+		} else {
+			// Generate new request ID if absent or malformed
 			reqID = GenerateRequestID()
 			// Debug: log generated request ID
 			fmt.Printf("[DEBUG] Generated request ID: %s for path: %s\n", reqID, r.URL.Path)
@@ -2,10 +2,14 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/logging"
 )
 
 func TestGenerateRequestID(t *testing.T) {
@@ -120,3 +124,101 @@ func TestRequestIDMiddleware(t *testing.T) {
 		t.Errorf("Expected request ID header %s, got %s", existingReqID, responseReqID)
 	}
 }
+
+func TestRequestIDMiddlewareIncomingHeader(t *testing.T) {
+	testCases := []struct {
+		name      string
+		incoming  string
+		preserved bool
+	}{
+		{"valid UUID is preserved", "550e8400-e29b-41d4-a716-446655440000", true},
+		{"valid token is preserved", "gateway.trace:42_a", true},
+		{"missing ID is generated", "", false},
+		{"ID with spaces is replaced", "bad id", false},
+		{"ID with control characters is replaced", "abc\r\ninjected", false},
+		{"overlong ID is replaced", strings.Repeat("a", MaxRequestIDLength+1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var contextReqID string
+			handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contextReqID = GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.incoming != "" {
+				// Assign directly so malformed values reach the middleware unchanged
+				req.Header[http.CanonicalHeaderKey(RequestIDHeader)] = []string{tc.incoming}
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			responseReqID := w.Header().Get(RequestIDHeader)
+			if responseReqID != contextReqID {
+				t.Errorf("Response header request ID %s doesn't match context request ID %s", responseReqID, contextReqID)
+			}
+			if tc.preserved && responseReqID != tc.incoming {
+				t.Errorf("Expected incoming request ID %q to be preserved, got %q", tc.incoming, responseReqID)
+			}
+			if !tc.preserved {
+				if responseReqID == tc.incoming {
+					t.Errorf("Expected request ID %q to be replaced", tc.incoming)
+				}
+				if !IsValidRequestID(responseReqID) {
+					t.Errorf("Generated request ID %q is not valid", responseReqID)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddlewareLogsDuplicateOnce(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(&buf, "info", "test-service", "1.0.0")
+	SetDuplicateRequestIDDetection(logger, 10)
+	defer SetDuplicateRequestIDDetection(nil, 0)
+
+	calls := 0
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	for _, reqID := range []string{"retry-1", "retry-1", "other-2"} {
+		req := httptest.NewRequest("GET", "/users", nil)
+		req.Header.Set(RequestIDHeader, reqID)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected every request to reach the handler, got %d calls", calls)
+	}
+
+	warnings := 0
+	for _, entry := range decodeLogLines(t, &buf) {
+		if entry["msg"] == "duplicate request id observed" {
+			warnings++
+			if entry["req_id"] != "retry-1" {
+				t.Errorf("Expected req_id retry-1, got %v", entry["req_id"])
+			}
+		}
+	}
+	if warnings != 1 {
+		t.Errorf("Expected one duplicate request id warning, got %d", warnings)
+	}
+}
+
+func TestRecentRequestIDsEvictsOldest(t *testing.T) {
+	recent := newRecentRequestIDs(2)
+
+	recent.observe("a")
+	recent.observe("b")
+	recent.observe("c") // evicts "a"
+
+	if recent.observe("a") {
+		t.Error("Expected evicted ID to be reported as new")
+	}
+	if !recent.observe("c") {
+		t.Error("Expected remembered ID to be reported as a duplicate")
+	}
+}
@@ -9,7 +9,7 @@ import (
 type ResponseWriter struct {
 	http.ResponseWriter
 	statusCode int32
-	written    int32
+	written    int64
 }
 
 func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
@@ -28,7 +28,7 @@ func (rw *ResponseWriter) WriteHeader(code int) {
 func (rw *ResponseWriter) Write(data []byte) (int, error) {
 	n, err := rw.ResponseWriter.Write(data)
 	if n > 0 {
-		atomic.AddInt32(&rw.written, int32(n))
+		atomic.AddInt64(&rw.written, int64(n))
 	}
 	return n, err
 }
@@ -37,10 +37,17 @@ func (rw *ResponseWriter) StatusCode() int {
 	return int(atomic.LoadInt32(&rw.statusCode))
 }
 
-func (rw *ResponseWriter) BytesWritten() int {
-	return int(atomic.LoadInt32(&rw.written))
+// BytesWritten returns the number of body bytes passed to the underlying writer
+// Inside the logging middleware that is the size sent on the wire, after any compression.
+func (rw *ResponseWriter) BytesWritten() int64 {
+	return atomic.LoadInt64(&rw.written)
 }
 
 func (rw *ResponseWriter) HasBody() bool {
-	return atomic.LoadInt32(&rw.written) > 0
+	return atomic.LoadInt64(&rw.written) > 0
+}
+
+// Unwrap exposes the underlying writer so http.ResponseController can reach Flush
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
 }
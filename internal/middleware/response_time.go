@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseTimeHeader reports how long the server took to produce the response, in milliseconds
+const ResponseTimeHeader = "X-Response-Time"
+
+// ResponseTime sets X-Response-Time on every response
+// Headers cannot change once written, so the duration is measured when the handler first writes the
+// status or body rather than when it returns; a handler that writes nothing gets it on return.
+func ResponseTime(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &responseTimeWriter{ResponseWriter: w, start: time.Now()}
+		next.ServeHTTP(tw, r)
+		tw.setHeader()
+	})
+}
+
+// responseTimeWriter stamps X-Response-Time just before the status line is sent
+type responseTimeWriter struct {
+	http.ResponseWriter
+	start time.Time
+	set   bool
+}
+
+// setHeader records the elapsed time once
+func (tw *responseTimeWriter) setHeader() {
+	if tw.set {
+		return
+	}
+	tw.set = true
+	elapsed := float64(time.Since(tw.start).Microseconds()) / 1000
+	tw.ResponseWriter.Header().Set(ResponseTimeHeader, strconv.FormatFloat(elapsed, 'f', 3, 64))
+}
+
+func (tw *responseTimeWriter) WriteHeader(code int) {
+	tw.setHeader()
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *responseTimeWriter) Write(data []byte) (int, error) {
+	tw.setHeader()
+	return tw.ResponseWriter.Write(data)
+}
+
+// Unwrap exposes the underlying writer so http.ResponseController can reach Flush
+func (tw *responseTimeWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
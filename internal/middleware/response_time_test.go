@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResponseTimeHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"explicit status", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+		}},
+		{"body only", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+			w.Write([]byte("ok"))
+		}},
+		{"no output", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(5 * time.Millisecond)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ResponseTime(tt.handler).ServeHTTP(w, httptest.NewRequest("GET", "/users", nil))
+
+			value := w.Result().Header.Get(ResponseTimeHeader)
+			if value == "" {
+				t.Fatalf("Expected %s header to be set", ResponseTimeHeader)
+			}
+			ms, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatalf("Expected %s to parse as a number, got %q: %v", ResponseTimeHeader, value, err)
+			}
+			if ms < 5 {
+				t.Errorf("Expected at least 5ms, got %v", ms)
+			}
+		})
+	}
+}
+
+func TestResponseTimeMeasuredAtFirstWrite(t *testing.T) {
+	handler := ResponseTime(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		// Work after the header is sent cannot be reported
+		time.Sleep(20 * time.Millisecond)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/users", nil))
+
+	ms, err := strconv.ParseFloat(w.Result().Header.Get(ResponseTimeHeader), 64)
+	if err != nil {
+		t.Fatalf("Expected numeric %s: %v", ResponseTimeHeader, err)
+	}
+	if ms >= 20 {
+		t.Errorf("Expected duration measured when the header was written, got %vms", ms)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+)
+
+// RequireTenant creates a middleware that puts the X-Tenant-ID header into the request context for
+// tenant-scoped queries, rejecting requests without the header with 400 TENANT_ID_REQUIRED and malformed
+// IDs with 400 INVALID_TENANT_ID
+// When enabled is false (MULTI_TENANT unset) every request passes through untouched.
+func RequireTenant(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(tenant.Header)
+			if id == "" {
+				writeTenantErrorResponse(w, "Header "+tenant.Header+" is required", "TENANT_ID_REQUIRED")
+				return
+			}
+			if !tenant.IsValidID(id) {
+				writeTenantErrorResponse(w, "Invalid "+tenant.Header+" header", "INVALID_TENANT_ID")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), id)))
+		})
+	}
+}
+
+// writeTenantErrorResponse writes a 400 Bad Request error response for a missing or malformed tenant
+func writeTenantErrorResponse(w http.ResponseWriter, message, code string) {
+	response := map[string]string{
+		"error": message,
+		"code":  code,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	json.NewEncoder(w).Encode(response)
+}
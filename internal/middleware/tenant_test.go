@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chybatronik/goUserAPI/internal/tenant"
+)
+
+func TestRequireTenantRejectsMissingOrInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantCode string
+	}{
+		{"missing header", "", "TENANT_ID_REQUIRED"},
+		{"space in ID", "acme corp", "INVALID_TENANT_ID"},
+		{"SQL in ID", "acme' OR '1'='1", "INVALID_TENANT_ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := RequireTenant(true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			if tt.value != "" {
+				req.Header.Set(tenant.Header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if called {
+				t.Error("Handler should not be called without a valid tenant")
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			var response map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response["code"] != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, response["code"])
+			}
+		})
+	}
+}
+
+func TestRequireTenantInjectsTenantIntoContext(t *testing.T) {
+	var got string
+	handler := RequireTenant(true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = tenant.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set(tenant.Header, "acme")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got != "acme" {
+		t.Errorf("Expected tenant acme in context, got %q", got)
+	}
+}
+
+func TestRequireTenantDisabledPassesThrough(t *testing.T) {
+	called := false
+	handler := RequireTenant(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if _, ok := tenant.FromContext(r.Context()); ok {
+			t.Error("No tenant should be injected when multi-tenancy is disabled")
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set(tenant.Header, "acme")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !called {
+		t.Error("Handler should be called when multi-tenancy is disabled")
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies spans created by the HTTP tracing middleware
+const TracerName = "github.com/chybatronik/goUserAPI/internal/middleware"
+
+// Tracing creates a middleware that starts a server span per request
+// It must run after RequestIDMiddleware so the request ID can be attached to the span
+func Tracing(provider trace.TracerProvider) func(http.Handler) http.Handler {
+	tracer := provider.Tracer(TracerName)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Continue an upstream trace when the caller propagated one
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			ctx, span := tracer.Start(ctx, "HTTP "+r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("request_id", GetRequestID(r.Context())),
+				),
+			)
+			defer span.End()
+
+			wrapped := NewResponseWriter(w)
+			next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+			status := wrapped.StatusCode()
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
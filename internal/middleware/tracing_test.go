@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddlewareRecordsSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var handlerSpan trace.SpanContext
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusCreated)
+	})
+	handler := RequestIDMiddleware(Tracing(provider)(next))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set(RequestIDHeader, "trace-test-id")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "HTTP POST", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.True(t, handlerSpan.IsValid(), "handler context must carry the server span")
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "trace-test-id", attrs["request_id"].AsString())
+	assert.Equal(t, "/users", attrs["url.path"].AsString())
+	assert.Equal(t, int64(http.StatusCreated), attrs["http.response.status_code"].AsInt64())
+}
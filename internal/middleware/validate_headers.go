@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/chybatronik/goUserAPI/internal/validation"
+)
+
+// ValidateHeaders creates a middleware that rejects requests whose values for the named headers contain
+// control characters such as CR or LF, or fail validation.ValidateUnicodeSecurity (format characters,
+// homographs), with 400 INVALID_HEADER
+// Forged values would otherwise reach logs and downstream systems verbatim. Headers not present on the
+// request are not required; with no names the middleware passes every request through.
+func ValidateHeaders(names []string) func(http.Handler) http.Handler {
+	canonical := make([]string, 0, len(names))
+	for _, name := range names {
+		canonical = append(canonical, http.CanonicalHeaderKey(strings.TrimSpace(name)))
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(canonical) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range canonical {
+				for _, value := range r.Header.Values(name) {
+					if !validHeaderValue(value) {
+						writeInvalidHeaderResponse(w, name)
+						return
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validHeaderValue reports whether value is free of control characters and passes Unicode security validation
+func validHeaderValue(value string) bool {
+	if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+		return false
+	}
+	return validation.ValidateUnicodeSecurity(value) == nil
+}
+
+// writeInvalidHeaderResponse writes a 400 Bad Request error response naming the rejected header
+func writeInvalidHeaderResponse(w http.ResponseWriter, name string) {
+	response := map[string]string{
+		"error": "Invalid characters in header " + name,
+		"code":  "INVALID_HEADER",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateHeadersRejectsUnsafeValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"CRLF injection", "X-Actor", "admin\r\nX-Injected: true"},
+		{"bare line feed", "X-Actor", "admin\nfake log entry"},
+		{"Cyrillic homograph", "X-Tenant", "p\u0430ypal"},
+		{"zero-width space", "X-Tenant", "acme\u200Bcorp"},
+		{"lowercase configured name", "x-actor", "admin\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := ValidateHeaders([]string{"x-actor", "X-Tenant"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			req.Header[http.CanonicalHeaderKey(tt.header)] = []string{tt.value}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if called {
+				t.Error("Handler should not be called for an unsafe header value")
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			var response map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response["code"] != "INVALID_HEADER" {
+				t.Errorf("Expected code INVALID_HEADER, got %s", response["code"])
+			}
+		})
+	}
+}
+
+func TestValidateHeadersAllowsSafeAndUnlistedHeaders(t *testing.T) {
+	called := false
+	handler := ValidateHeaders([]string{"X-Actor", "X-Tenant"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Actor", "Иван Петров")
+	req.Header.Set("X-Other", "p\u0430ypal")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if !called {
+		t.Errorf("Expected the request to pass, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestValidateHeadersWithoutNamesPassesThrough(t *testing.T) {
+	called := false
+	handler := ValidateHeaders(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header["X-Actor"] = []string{"admin\r\n"}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !called {
+		t.Error("Expected every request to pass when no headers are configured")
+	}
+}
@@ -10,6 +10,7 @@ type User struct {
 	LastName      string `json:"last_name" db:"last_name" validate:"required,max=100"`   // Max 100 characters
 	Age           int    `json:"age" db:"age" validate:"required,gte=1,lte=120"`         // 1-120 years validation
 	RecordingDate int64  `json:"recording_date" db:"recording_date" validate:"required"` // Unix timestamp
+	Email         string `json:"email,omitempty" db:"email" validate:"omitempty,email"`  // Optional natural key for upserts; NULL when empty
 }
 
 // UserReport represents a user report in the system
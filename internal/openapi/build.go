@@ -0,0 +1,553 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/chybatronik/goUserAPI/internal/database"
+)
+
+// ErrorCodes lists the documented error codes returned in ErrorResponse.code
+var ErrorCodes = []string{
+	"INVALID_CONTENT_TYPE",
+	"INVALID_CONTENT_ENCODING",
+	"UNSUPPORTED_CONTENT_ENCODING",
+	"INVALID_HEADER",
+	"TENANT_ID_REQUIRED",
+	"INVALID_TENANT_ID",
+	"EMPTY_REQUEST_BODY",
+	"INVALID_JSON",
+	"INVALID_JSON_STRUCTURE",
+	"INVALID_FIELD_TYPE",
+	"UNKNOWN_FIELD",
+	"INVALID_REQUEST_BODY",
+	"MISSING_REQUIRED_FIELD",
+	"EMPTY_FIELD_AFTER_TRIM",
+	"INVALID_FIELD_LENGTH",
+	"INVALID_AGE_RANGE",
+	"INVALID_RECORDING_DATE",
+	"INVALID_EMAIL",
+	"UNICODE_SECURITY_VIOLATION",
+	"UNICODE_FORMAT_CHAR",
+	"DISALLOWED_NAME_SCRIPT",
+	"UNSECURE_UNICODE_INPUT",
+	"INVALID_PARAMETER_FORMAT",
+	"INVALID_QUERY_PARAMETERS",
+	"INVALID_LIMIT_PARAMETER",
+	"INVALID_OFFSET_PARAMETER",
+	"OFFSET_TOO_LARGE",
+	"INVALID_SORT_FIELD",
+	"INVALID_SORT_ORDER",
+	"INVALID_FORMAT_PARAMETER",
+	"INVALID_DATE_FORMAT_PARAMETER",
+	"INVALID_FIELD_SELECTION",
+	"TOO_MANY_PARAMETERS",
+	"INVALID_START_DATE_PARAMETER",
+	"INVALID_END_DATE_PARAMETER",
+	"INVALID_LAST_PARAMETER",
+	"CONFLICTING_DATE_PARAMETERS",
+	"INVALID_MIN_AGE_PARAMETER",
+	"INVALID_MAX_AGE_PARAMETER",
+	"INVALID_AGE_PARAMETER",
+	"CONFLICTING_AGE_PARAMETERS",
+	"VALIDATION_ERROR",
+	"VALIDATION_FAILED",
+	"SCHEMA_VALIDATION_FAILED",
+	"FORBIDDEN",
+	"METHOD_NOT_ALLOWED",
+	"NOT_FOUND",
+	"USER_NOT_FOUND",
+	"USER_DUPLICATE_EMAIL",
+	"USER_ALREADY_EXISTS",
+	"INVALID_USER_ID",
+	"INVALID_IDS_PARAMETER",
+	"TOO_MANY_IDS",
+	"INVALID_IDEMPOTENCY_KEY",
+	"IDEMPOTENCY_KEY_REUSED",
+	"IDEMPOTENCY_KEY_IN_PROGRESS",
+	"EXPORT_NOT_FOUND",
+	"EXPORT_QUEUE_FULL",
+	"RATE_LIMIT_EXCEEDED",
+	"SERVICE_UNAVAILABLE",
+	"MAINTENANCE",
+	"QUERY_TIMEOUT",
+	"POOL_EXHAUSTED",
+	"DATABASE_ERROR",
+	"USER_DATABASE_ERROR",
+}
+
+// Build returns the OpenAPI document describing the public API surface
+func Build() *Spec {
+	spec := &Spec{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "goUserAPI",
+			Description: "RESTful API for managing users and generating user reports",
+			Version:     "1.0.0",
+		},
+		Paths: map[string]PathItem{
+			"/health":  {Get: healthOperation("Service health check", "getHealth")},
+			"/healthz": {Get: healthOperation("Service health check (alias of /health)", "getHealthz")},
+			"/livez": {
+				Get: &Operation{
+					Summary:     "Liveness probe",
+					OperationID: "getLivez",
+					Tags:        []string{"health"},
+					Responses: map[string]Response{
+						"200": jsonResponse("Process is running", ref("ProbeResponse")),
+					},
+				},
+			},
+			"/readyz": {
+				Get: &Operation{
+					Summary:     "Readiness probe",
+					OperationID: "getReadyz",
+					Tags:        []string{"health"},
+					Responses: map[string]Response{
+						"200": jsonResponse("Service accepts traffic", ref("ProbeResponse")),
+						"503": jsonResponse("Service is shutting down or a readiness check such as pending migrations failed", ref("ProbeResponse")),
+					},
+				},
+			},
+			"/metrics/db": {
+				Get: &Operation{
+					Summary:     "Recent database operation latency",
+					OperationID: "getDBMetrics",
+					Tags:        []string{"health"},
+					Responses: map[string]Response{
+						"200": jsonResponse("Latency percentiles per database operation", ref("DBMetricsResponse")),
+					},
+				},
+			},
+			"/users": {
+				Get: &Operation{
+					Summary:     "List users with pagination and sorting, or look up a batch of users by ID",
+					OperationID: "getUsers",
+					Tags:        []string{"users"},
+					Parameters: append(append(paginationParameters(), sortParameters()...), dateFormatParameter(), formatParameter(), tenantParameter(),
+						Parameter{Name: "ids", In: "query", Description: "Comma-separated user IDs (at most 100); switches the response to GetUsersByIDsResponse and ignores pagination and sorting", Schema: &Schema{Type: "string"}},
+						Parameter{Name: "fields", In: "query", Description: "Comma-separated User fields to return per user in the JSON page (id, first_name, last_name, age, recording_date, email); default all", Schema: &Schema{Type: "string"}},
+					),
+					Responses: map[string]Response{
+						"200": {
+							Description: "Page of users, or the users found for ids; csv and ndjson render the page's users only",
+							Headers:     totalCountHeaders(),
+							Content: map[string]MediaType{
+								"application/json":     {Schema: &Schema{OneOf: []*Schema{ref("GetUsersResponse"), ref("GetUsersByIDsResponse")}}},
+								"text/csv":             {Schema: &Schema{Type: "string"}},
+								"application/x-ndjson": {Schema: ref("User")},
+							},
+						},
+						"400": errorResponse("Invalid query parameters"),
+						"429": errorResponse("Rate limit exceeded"),
+						"500": errorResponse("Database operation failed"),
+						"503": errorResponse("Service temporarily unavailable"),
+					},
+				},
+				Post: &Operation{
+					Summary:     "Create a user",
+					OperationID: "createUser",
+					Tags:        []string{"users"},
+					Parameters: []Parameter{
+						{Name: "Idempotency-Key", In: "header", Description: "Client-chosen key; a retry with the same key and body replays the original 201 response", Schema: &Schema{Type: "string", MaxLength: intPtr(255)}},
+						tenantParameter(),
+					},
+					RequestBody: &RequestBody{
+						Required: true,
+						Content:  map[string]MediaType{"application/json": {Schema: ref("CreateUserRequest")}},
+					},
+					Responses: map[string]Response{
+						"201": withLocation(jsonResponse("User created", ref("User")), "URL of the created user, /users/{id}"),
+						"400": errorResponse("Invalid request body or failed validation"),
+						"403": errorResponse("recording_date sent without the import API key"),
+						"409": errorResponse("Idempotency-Key reused with a different body or still in progress, the email is taken, or the name is taken while REJECT_DUPLICATE_NAMES is enabled"),
+						"415": errorResponse("Unsupported Content-Encoding on the request body"),
+						"429": errorResponse("Rate limit exceeded"),
+						"500": errorResponse("Database operation failed"),
+						"503": errorResponse("Service temporarily unavailable"),
+					},
+				},
+				Put: &Operation{
+					Summary:     "Create or update a user by email",
+					OperationID: "upsertUser",
+					Tags:        []string{"users"},
+					Parameters:  []Parameter{tenantParameter()},
+					RequestBody: &RequestBody{
+						Required: true,
+						Content:  map[string]MediaType{"application/json": {Schema: ref("CreateUserRequest")}},
+					},
+					Responses: map[string]Response{
+						"200": withLocation(jsonResponse("Existing user with this email updated", ref("User")), "URL of the user, /users/{id}"),
+						"201": withLocation(jsonResponse("User created", ref("User")), "URL of the created user, /users/{id}"),
+						"400": errorResponse("Invalid request body, failed validation or missing email"),
+						"403": errorResponse("recording_date sent without the import API key"),
+						"415": errorResponse("Unsupported Content-Encoding on the request body"),
+						"429": errorResponse("Rate limit exceeded"),
+						"500": errorResponse("Database operation failed"),
+						"503": errorResponse("Service temporarily unavailable"),
+					},
+				},
+			},
+			"/users/{id}": {
+				Get: &Operation{
+					Summary:     "Get a user by ID",
+					OperationID: "getUserByID",
+					Tags:        []string{"users"},
+					Parameters: []Parameter{
+						{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Format: "uuid"}},
+						{Name: "If-None-Match", In: "header", Description: "ETag from a previous response for conditional retrieval", Schema: &Schema{Type: "string"}},
+						dateFormatParameter(),
+						tenantParameter(),
+					},
+					Responses: map[string]Response{
+						"200": jsonResponse("User found (response carries an ETag header)", ref("User")),
+						"304": {Description: "User unchanged since the ETag supplied in If-None-Match"},
+						"400": errorResponse("Invalid user ID, or missing or invalid X-Tenant-ID while MULTI_TENANT is enabled"),
+						"404": errorResponse("User not found"),
+						"500": errorResponse("Database operation failed"),
+					},
+				},
+			},
+			"/reports": {
+				Get: &Operation{
+					Summary:     "Generate a filtered user report",
+					OperationID: "getReports",
+					Tags:        []string{"reports"},
+					Parameters: append(append(append(paginationParameters(), sortParameters()...), reportFilterParameters()...),
+						dateFormatParameter(), formatParameter(), tenantParameter(),
+					),
+					Responses: map[string]Response{
+						"200": {
+							Description: "Report page, a CSV page, or an NDJSON stream of users that, without limit, returns every matching row",
+							Headers:     totalCountHeaders(),
+							Content: map[string]MediaType{
+								"application/json":     {Schema: ref("GetReportsResponse")},
+								"text/csv":             {Schema: &Schema{Type: "string"}},
+								"application/x-ndjson": {Schema: ref("User")},
+							},
+						},
+						"400": errorResponse("Invalid query parameters"),
+						"429": errorResponse("Rate limit exceeded"),
+						"500": errorResponse("Database operation failed"),
+						"503": errorResponse("Service temporarily unavailable"),
+					},
+				},
+			},
+			"/reports/export": {
+				Post: &Operation{
+					Summary:     "Queue a CSV export of a filtered user report",
+					OperationID: "createReportExport",
+					Tags:        []string{"reports"},
+					Parameters:  append(append(sortParameters(), reportFilterParameters()...), dateFormatParameter(), tenantParameter()),
+					Responses: map[string]Response{
+						"202": jsonResponse("Export queued; poll the Location header URL", ref("ExportJob")),
+						"400": errorResponse("Invalid query parameters"),
+						"429": errorResponse("Rate limit exceeded"),
+						"503": errorResponse("Export queue is full"),
+					},
+				},
+			},
+			"/reports/export/{job_id}": {
+				Get: &Operation{
+					Summary:     "Poll a report export and download it once done",
+					OperationID: "getReportExport",
+					Tags:        []string{"reports"},
+					Parameters: []Parameter{
+						{Name: "job_id", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+						tenantParameter(),
+					},
+					Responses: map[string]Response{
+						"200": {
+							Description: "Job status while pending, running or failed; the CSV file once done",
+							Content: map[string]MediaType{
+								"application/json": {Schema: ref("ExportJob")},
+								"text/csv":         {Schema: &Schema{Type: "string"}},
+							},
+						},
+						"400": errorResponse("Missing or invalid X-Tenant-ID while MULTI_TENANT is enabled"),
+						"404": errorResponse("Export not found or expired"),
+					},
+				},
+			},
+		},
+		Components: Components{
+			Schemas: map[string]*Schema{
+				"User": {
+					Type:     "object",
+					Required: []string{"id", "first_name", "last_name", "age", "recording_date"},
+					Properties: map[string]*Schema{
+						"id":             {Type: "string", Format: "uuid"},
+						"first_name":     {Type: "string", MaxLength: intPtr(100)},
+						"last_name":      {Type: "string", MaxLength: intPtr(100)},
+						"age":            {Type: "integer", Minimum: intPtr(1), Maximum: intPtr(120)},
+						"recording_date": {Type: "integer", Format: "int64", Description: "Unix timestamp; an RFC3339 UTC string when date_format=rfc3339"},
+						"email":          {Type: "string", Format: "email", MaxLength: intPtr(254), Description: "Omitted when the user has no email"},
+					},
+				},
+				"CreateUserRequest": {
+					Type:     "object",
+					Required: []string{"first_name", "last_name", "age"},
+					Properties: map[string]*Schema{
+						"first_name":     {Type: "string", MaxLength: intPtr(100)},
+						"last_name":      {Type: "string", MaxLength: intPtr(100)},
+						"age":            {Type: "integer", Minimum: intPtr(1), Maximum: intPtr(120)},
+						"recording_date": {Type: "integer", Format: "int64", Description: "Original Unix timestamp for imports; requires X-API-Key equal to IMPORT_API_KEY, must be between 2000-01-01 and now"},
+						"email":          {Type: "string", Format: "email", MaxLength: intPtr(254), Description: "Stored lowercased and unique per tenant; required by PUT /users"},
+					},
+				},
+				"PaginationInfo": {
+					Type:     "object",
+					Required: []string{"total_count", "limit", "offset", "has_more", "page", "total_pages"},
+					Properties: map[string]*Schema{
+						"total_count": {Type: "integer", Format: "int64"},
+						"limit":       {Type: "integer"},
+						"offset":      {Type: "integer"},
+						"has_more":    {Type: "boolean"},
+						"page":        {Type: "integer", Minimum: intPtr(1), Description: "1-based page containing the first returned row (offset / limit + 1)"},
+						"total_pages": {Type: "integer", Format: "int64", Description: "ceil(total_count / limit); 0 when nothing matches"},
+					},
+				},
+				"GetUsersResponse": {
+					Type:     "object",
+					Required: []string{"users", "pagination"},
+					Properties: map[string]*Schema{
+						"users":      {Type: "array", Items: ref("User")},
+						"pagination": ref("PaginationInfo"),
+					},
+				},
+				"GetReportsResponse": {
+					Type:     "object",
+					Required: []string{"count", "users", "pagination"},
+					Properties: map[string]*Schema{
+						"count":      {Type: "integer", Format: "int64"},
+						"users":      {Type: "array", Items: ref("User")},
+						"pagination": ref("PaginationInfo"),
+					},
+				},
+				"GetUsersByIDsResponse": {
+					Type:     "object",
+					Required: []string{"users", "not_found"},
+					Properties: map[string]*Schema{
+						"users":     {Type: "array", Items: ref("User")},
+						"not_found": {Type: "array", Items: &Schema{Type: "string", Format: "uuid"}},
+					},
+				},
+				"ExportJob": {
+					Type:     "object",
+					Required: []string{"job_id", "status", "row_count"},
+					Properties: map[string]*Schema{
+						"job_id":     {Type: "string"},
+						"status":     {Type: "string", Enum: []string{"pending", "running", "done", "failed"}},
+						"row_count":  {Type: "integer"},
+						"error":      {Type: "string"},
+						"error_code": {Type: "string"},
+					},
+				},
+				"HealthCheck": {
+					Type:     "object",
+					Required: []string{"status", "response_time_ms"},
+					Properties: map[string]*Schema{
+						"status":           {Type: "string", Enum: []string{"healthy", "unhealthy"}},
+						"response_time_ms": {Type: "integer", Format: "int64"},
+						"error":            {Type: "string"},
+						"details": {
+							Type:        "object",
+							Description: "Component-specific values; the database check reports connection pool stats",
+							Properties: map[string]*Schema{
+								"acquired_conns": {Type: "integer"},
+								"idle_conns":     {Type: "integer"},
+								"total_conns":    {Type: "integer"},
+								"max_conns":      {Type: "integer"},
+							},
+						},
+					},
+				},
+				"DBMetricsResponse": {
+					Type:     "object",
+					Required: []string{"window_size", "operations"},
+					Properties: map[string]*Schema{
+						"window_size": {Type: "integer", Description: "Recent samples per operation used for the percentiles"},
+						"operations":  {Type: "object", Description: "LatencySummary keyed by database operation name"},
+					},
+				},
+				"LatencySummary": {
+					Type:     "object",
+					Required: []string{"count", "p50_ms", "p95_ms", "p99_ms", "max_ms"},
+					Properties: map[string]*Schema{
+						"count":  {Type: "integer", Format: "int64", Description: "Operations recorded since startup"},
+						"p50_ms": {Type: "number"},
+						"p95_ms": {Type: "number"},
+						"p99_ms": {Type: "number"},
+						"max_ms": {Type: "number"},
+					},
+				},
+				"ProbeResponse": {
+					Type:     "object",
+					Required: []string{"status"},
+					Properties: map[string]*Schema{
+						"status": {Type: "string", Enum: []string{"ok", "ready", "not_ready", "shutting_down"}},
+						"checks": {Type: "object", Description: "Readiness checker results keyed by checker name; present only when checkers are enabled"},
+					},
+				},
+				"HealthCheckResponse": {
+					Type:     "object",
+					Required: []string{"status", "timestamp", "service", "version", "uptime_seconds", "checks"},
+					Properties: map[string]*Schema{
+						"status":         {Type: "string", Enum: []string{"healthy", "unhealthy"}},
+						"timestamp":      {Type: "integer", Format: "int64"},
+						"service":        {Type: "string"},
+						"version":        {Type: "string"},
+						"uptime_seconds": {Type: "integer", Format: "int64"},
+						"checks":         {Type: "object", Description: "Per-checker results keyed by checker name"},
+					},
+				},
+				"ErrorResponse": {
+					Type:     "object",
+					Required: []string{"error", "code"},
+					Properties: map[string]*Schema{
+						"error":      {Type: "string", Description: "Human-readable error message"},
+						"code":       {Type: "string", Enum: ErrorCodes},
+						"details":    {Type: "string"},
+						"fields":     {Type: "array", Description: "Every failing field (VALIDATION_FAILED only)", Items: ref("FieldError")},
+						"violations": {Type: "array", Description: "Every JSON Schema violation (SCHEMA_VALIDATION_FAILED only)", Items: ref("SchemaViolation")},
+					},
+				},
+				"SchemaViolation": {
+					Type:     "object",
+					Required: []string{"field", "reason"},
+					Properties: map[string]*Schema{
+						"field":  {Type: "string", Description: "JSON pointer to the failing value"},
+						"reason": {Type: "string"},
+					},
+				},
+				"FieldError": {
+					Type:     "object",
+					Required: []string{"field", "code", "message"},
+					Properties: map[string]*Schema{
+						"field":   {Type: "string"},
+						"code":    {Type: "string"},
+						"message": {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+
+	addHeadOperations(spec)
+	return spec
+}
+
+// headPaths lists the paths whose GET handler also serves HEAD
+var headPaths = []string{"/users", "/users/{id}", "/reports"}
+
+// addHeadOperations documents HEAD on every path in headPaths as its GET operation without response bodies
+func addHeadOperations(spec *Spec) {
+	for _, path := range headPaths {
+		item := spec.Paths[path]
+		item.Head = headOperation(item.Get)
+		spec.Paths[path] = item
+	}
+}
+
+// headOperation derives the HEAD operation from get: same parameters, statuses and headers, no content
+func headOperation(get *Operation) *Operation {
+	head := *get
+	head.Summary = get.Summary + " (headers only)"
+	head.OperationID = "head" + strings.ToUpper(get.OperationID[:1]) + get.OperationID[1:]
+	head.Responses = make(map[string]Response, len(get.Responses))
+	for status, response := range get.Responses {
+		head.Responses[status] = Response{Description: response.Description, Headers: response.Headers}
+	}
+	return &head
+}
+
+// paginationParameters returns the limit/offset query parameters shared by list endpoints
+func paginationParameters() []Parameter {
+	return []Parameter{
+		{Name: "limit", In: "query", Description: "Page size (default 20)", Schema: &Schema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(100)}},
+		{Name: "offset", In: "query", Description: "Number of records to skip (default 0, at most MAX_OFFSET, 10000 by default)", Schema: &Schema{Type: "integer", Minimum: intPtr(0)}},
+	}
+}
+
+// jsonResponse builds a response with an application/json body
+func jsonResponse(description string, schema *Schema) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// withLocation documents the Location header on a response
+func withLocation(response Response, description string) Response {
+	response.Headers = map[string]Header{"Location": {Description: description, Schema: &Schema{Type: "string"}}}
+	return response
+}
+
+// totalCountHeaders documents the X-Total-Count header sent with list responses
+func totalCountHeaders() map[string]Header {
+	return map[string]Header{"X-Total-Count": {Description: "Number of rows matching the request across all pages", Schema: &Schema{Type: "integer"}}}
+}
+
+// errorResponse builds a response documented with the shared ErrorResponse schema
+func errorResponse(description string) Response {
+	return jsonResponse(description, ref("ErrorResponse"))
+}
+
+// healthOperation returns the GET operation served by /health and its /healthz alias
+func healthOperation(summary, operationID string) *Operation {
+	return &Operation{
+		Summary:     summary,
+		OperationID: operationID,
+		Tags:        []string{"health"},
+		Parameters: []Parameter{
+			{Name: "ping", In: "query", Description: "Return a lightweight ping/pong response when true", Schema: &Schema{Type: "boolean"}},
+		},
+		Responses: map[string]Response{
+			"200": jsonResponse("Service is healthy", ref("HealthCheckResponse")),
+			"503": jsonResponse("One or more health checks failed", ref("HealthCheckResponse")),
+		},
+	}
+}
+
+// sortParameters returns the sort_by/sort_order query parameters shared by list endpoints
+func sortParameters() []Parameter {
+	return []Parameter{
+		{Name: "sort_by", In: "query", Schema: &Schema{Type: "string", Enum: database.SortFieldNames()}},
+		{Name: "sort_order", In: "query", Schema: &Schema{Type: "string", Enum: []string{"asc", "desc"}}},
+	}
+}
+
+// reportFilterParameters returns the recording date and age filters shared by report endpoints
+func reportFilterParameters() []Parameter {
+	return []Parameter{
+		{Name: "start_date", In: "query", Description: "Lower recording_date bound (Unix timestamp)", Schema: &Schema{Type: "integer", Format: "int64"}},
+		{Name: "end_date", In: "query", Description: "Upper recording_date bound (Unix timestamp)", Schema: &Schema{Type: "integer", Format: "int64"}},
+		{Name: "last", In: "query", Description: "Relative window ending now, e.g. 24h, 7d or 30d; cannot be combined with start_date/end_date", Schema: &Schema{Type: "string"}},
+		{Name: "min_age", In: "query", Schema: &Schema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(120)}},
+		{Name: "max_age", In: "query", Schema: &Schema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(120)}},
+		{Name: "age", In: "query", Description: "Exact age, the same as equal min_age and max_age; cannot be combined with them", Schema: &Schema{Type: "integer", Minimum: intPtr(1), Maximum: intPtr(120)}},
+	}
+}
+
+// dateFormatParameter returns the date_format query parameter shared by endpoints returning users
+func dateFormatParameter() Parameter {
+	return Parameter{Name: "date_format", In: "query", Description: "Format of recording_date in the response (default unix)", Schema: &Schema{Type: "string", Enum: []string{"unix", "rfc3339"}}}
+}
+
+// formatParameter returns the format query parameter shared by list endpoints
+func formatParameter() Parameter {
+	return Parameter{Name: "format", In: "query", Description: "Response format; overrides the Accept header (application/json, text/csv, application/x-ndjson), default json", Schema: &Schema{Type: "string", Enum: []string{"json", "csv", "ndjson"}}}
+}
+
+// tenantParameter returns the X-Tenant-ID header required on user and report endpoints when MULTI_TENANT is enabled
+func tenantParameter() Parameter {
+	return Parameter{Name: "X-Tenant-ID", In: "header", Description: "Tenant the request acts for; required when MULTI_TENANT is enabled, which scopes every user to it", Schema: &Schema{Type: "string", MaxLength: intPtr(64)}}
+}
+
+// ref builds a reference to a component schema
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMarshalsToJSON(t *testing.T) {
+	data, err := json.Marshal(Build())
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+
+	assert.Equal(t, Version, raw["openapi"])
+	assert.Contains(t, raw, "paths")
+	assert.Contains(t, raw, "components")
+}
+
+func TestBuildReferencesResolve(t *testing.T) {
+	spec := Build()
+
+	var refs []string
+	var collect func(s *Schema)
+	collect = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			refs = append(refs, s.Ref)
+		}
+		collect(s.Items)
+		for _, prop := range s.Properties {
+			collect(prop)
+		}
+	}
+
+	for _, item := range spec.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil {
+				continue
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					collect(media.Schema)
+				}
+			}
+			for _, resp := range op.Responses {
+				for _, media := range resp.Content {
+					collect(media.Schema)
+				}
+			}
+		}
+	}
+	for _, schema := range spec.Components.Schemas {
+		collect(schema)
+	}
+
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		assert.Contains(t, spec.Components.Schemas, name, "unresolved reference %s", ref)
+	}
+}
@@ -0,0 +1,36 @@
+// Package tenant carries the tenant of a request when MULTI_TENANT is enabled.
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+const (
+	// Header is the HTTP header naming the tenant a request acts for
+	Header = "X-Tenant-ID"
+	// MaxIDLength bounds tenant IDs so they cannot bloat the tenant_id column or logs
+	MaxIDLength = 64
+)
+
+// idPattern accepts slugs and UUIDs; anything else is rejected before reaching SQL or logs
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// contextKey is the unexported type of the context key, so no other package can collide with it
+type contextKey struct{}
+
+// IsValidID reports whether id can be used as a tenant ID
+func IsValidID(id string) bool {
+	return len(id) > 0 && len(id) <= MaxIDLength && idPattern.MatchString(id)
+}
+
+// WithID returns a copy of ctx carrying the tenant ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ID carried by ctx and whether there is one
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
@@ -0,0 +1,46 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIsValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"acme", true},
+		{"acme-corp.eu_1", true},
+		{"3f2504e0-4f89-11d3-9a0c-0305e82c3301", true},
+		{strings.Repeat("a", MaxIDLength), true},
+		{"", false},
+		{strings.Repeat("a", MaxIDLength+1), false},
+		{"acme corp", false},
+		{"acme'; DROP TABLE users; --", false},
+		{"acme\r\nX-Injected: 1", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidID(tt.id); got != tt.want {
+			t.Errorf("IsValidID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestContextRoundTrip(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatal("background context should carry no tenant")
+	}
+
+	ctx := WithID(context.Background(), "acme")
+	id, ok := FromContext(ctx)
+	if !ok || id != "acme" {
+		t.Fatalf("FromContext = %q, %v; want acme, true", id, ok)
+	}
+
+	if _, ok := FromContext(WithID(context.Background(), "")); ok {
+		t.Error("an empty tenant ID should not count as a tenant")
+	}
+}
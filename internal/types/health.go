@@ -10,6 +10,15 @@ type HealthCheck struct {
 	Details   map[string]string `json:"details,omitempty"`
 }
 
+// ComponentHealth represents an individual component check result with timing
+// It lives here so the database package can report health without importing handlers
+type ComponentHealth struct {
+	Status         string         `json:"status"`            // healthy|unhealthy
+	ResponseTimeMs int64          `json:"response_time_ms"`  // Response time in ms
+	Error          string         `json:"error,omitempty"`   // Only present if unhealthy
+	Details        map[string]any `json:"details,omitempty"` // Optional component-specific values (e.g. pool stats)
+}
+
 // HealthChecker defines the interface for health check implementations
 type HealthChecker interface {
 	CheckHealth(ctx context.Context) HealthCheck
@@ -17,4 +17,6 @@ type GetReportsParams struct {
 	EndDate   *int64 // Epic 3 default: current timestamp if nil
 	MinAge    *int   // Epic 3 default: 1 if nil
 	MaxAge    *int   // Epic 3 default: 120 if nil
+	SortBy    string // Default: recording_date if empty
+	SortOrder string // Default: database.DefaultSortOrder(SortBy) if empty
 }
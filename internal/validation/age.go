@@ -0,0 +1,62 @@
+package validation
+
+import "fmt"
+
+// AgeBounds is the inclusive range of accepted user ages
+// A single value is shared by the handler, report and database validators so they never disagree
+type AgeBounds struct {
+	Min int
+	Max int
+}
+
+// DefaultAgeBounds matches the CHECK constraint on users.age
+var DefaultAgeBounds = AgeBounds{Min: 1, Max: 120}
+
+// CodeInvalidAgeRange is the error code reported for an age outside the accepted bounds
+const CodeInvalidAgeRange = "INVALID_AGE_RANGE"
+
+// AgeRangeError reports an age outside the inclusive range [Min, Max]
+type AgeRangeError struct {
+	Age int
+	Min int
+	Max int
+}
+
+func (e *AgeRangeError) Error() string {
+	return fmt.Sprintf("age must be between %d and %d years", e.Min, e.Max)
+}
+
+// Code returns CodeInvalidAgeRange
+func (e *AgeRangeError) Code() string {
+	return CodeInvalidAgeRange
+}
+
+// ValidateAge checks that age lies within the inclusive range [min, max]
+// It is the single age check behind user creation, report filters and database validation.
+func ValidateAge(age, min, max int) error {
+	if age < min || age > max {
+		return &AgeRangeError{Age: age, Min: min, Max: max}
+	}
+	return nil
+}
+
+// Contains reports whether age lies within the bounds
+func (b AgeBounds) Contains(age int) bool {
+	return ValidateAge(age, b.Min, b.Max) == nil
+}
+
+// Validate checks that the bounds themselves are usable
+func (b AgeBounds) Validate() error {
+	if b.Min < 1 {
+		return fmt.Errorf("minimum age must be at least 1, got %d", b.Min)
+	}
+	if b.Max < b.Min {
+		return fmt.Errorf("maximum age (%d) cannot be less than minimum age (%d)", b.Max, b.Min)
+	}
+	return nil
+}
+
+// String renders the bounds for error messages, e.g. "between 1 and 120"
+func (b AgeBounds) String() string {
+	return fmt.Sprintf("between %d and %d", b.Min, b.Max)
+}
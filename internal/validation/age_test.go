@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateAgeBoundaries(t *testing.T) {
+	tests := []struct {
+		age     int
+		wantErr bool
+	}{
+		{-1, true},
+		{0, true},
+		{1, false},
+		{2, false},
+		{119, false},
+		{120, false},
+		{121, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateAge(tt.age, DefaultAgeBounds.Min, DefaultAgeBounds.Max)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAge(%d) error = %v, wantErr %v", tt.age, err, tt.wantErr)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+
+		var rangeErr *AgeRangeError
+		if !errors.As(err, &rangeErr) {
+			t.Fatalf("ValidateAge(%d) returned %T, want *AgeRangeError", tt.age, err)
+		}
+		if rangeErr.Code() != CodeInvalidAgeRange || rangeErr.Age != tt.age || rangeErr.Min != 1 || rangeErr.Max != 120 {
+			t.Errorf("ValidateAge(%d) = %+v with code %s", tt.age, rangeErr, rangeErr.Code())
+		}
+		if err.Error() != "age must be between 1 and 120 years" {
+			t.Errorf("ValidateAge(%d) message = %q", tt.age, err.Error())
+		}
+	}
+}
+
+func TestValidateAgeSingleValueRange(t *testing.T) {
+	for age, wantErr := range map[int]bool{29: true, 30: false, 31: true} {
+		if err := ValidateAge(age, 30, 30); (err != nil) != wantErr {
+			t.Errorf("ValidateAge(%d, 30, 30) error = %v, wantErr %v", age, err, wantErr)
+		}
+	}
+}
+
+func TestAgeBoundsContains(t *testing.T) {
+	bounds := AgeBounds{Min: 18, Max: 65}
+
+	tests := []struct {
+		age  int
+		want bool
+	}{
+		{16, false},
+		{17, false},
+		{18, true},
+		{40, true},
+		{65, true},
+		{66, false},
+	}
+
+	for _, tt := range tests {
+		if got := bounds.Contains(tt.age); got != tt.want {
+			t.Errorf("Contains(%d) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestAgeBoundsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bounds  AgeBounds
+		wantErr bool
+	}{
+		{"default bounds", DefaultAgeBounds, false},
+		{"single age", AgeBounds{Min: 30, Max: 30}, false},
+		{"zero minimum", AgeBounds{Min: 0, Max: 120}, true},
+		{"inverted range", AgeBounds{Min: 65, Max: 18}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bounds.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgeBoundsString(t *testing.T) {
+	if got := DefaultAgeBounds.String(); got != "between 1 and 120" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// CodeInvalidEmail is the error code reported for an email that is not a plain address
+const CodeInvalidEmail = "INVALID_EMAIL"
+
+// MaxEmailLength is the width of the email column, the longest address SMTP allows
+const MaxEmailLength = 254
+
+// NormalizeEmail trims surrounding whitespace and lowercases email so that it can be used as a natural key
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail checks that email is a bare address such as jane@example.com, without a display name
+// or angle brackets, and fits the email column
+func ValidateEmail(email string) error {
+	if len(email) > MaxEmailLength {
+		return fmt.Errorf("email exceeds maximum length of %d characters", MaxEmailLength)
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email: %w", err)
+	}
+	if addr.Name != "" || addr.Address != email {
+		return errors.New("email must be a bare address such as jane@example.com")
+	}
+	if !strings.Contains(email[strings.LastIndex(email, "@")+1:], ".") {
+		return errors.New("email domain must contain a dot")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateEmail(t *testing.T) {
+	for _, email := range []string{"jane@example.com", "j.doe+tag@mail.example.org"} {
+		if err := ValidateEmail(email); err != nil {
+			t.Errorf("ValidateEmail(%q) unexpected error: %v", email, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"jane",
+		"jane@",
+		"@example.com",
+		"jane@localhost",
+		"Jane <jane@example.com>",
+		"<jane@example.com>",
+		"jane@example.com\r\nBcc: x@example.com",
+		strings.Repeat("a", MaxEmailLength) + "@example.com",
+	}
+	for _, email := range invalid {
+		if err := ValidateEmail(email); err == nil {
+			t.Errorf("ValidateEmail(%q) expected an error", email)
+		}
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	if got := NormalizeEmail("  Jane.Doe@Example.COM "); got != "jane.doe@example.com" {
+		t.Errorf("NormalizeEmail() = %q, want %q", got, "jane.doe@example.com")
+	}
+}
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MaxNameColumnLength is the width of the first_name and last_name columns
+const MaxNameColumnLength = 100
+
+// Limits holds the configurable size limits applied to user payloads
+// A single value is shared by the handlers so error messages always report the configured limit
+type Limits struct {
+	MaxNameLength   int   // Maximum length in bytes of first_name and last_name
+	MaxPayloadBytes int64 // Maximum size of a JSON request body
+
+	// Scripts restricts first_name and last_name to the listed Unicode scripts; the zero value allows all
+	Scripts ScriptPolicy
+}
+
+// DefaultLimits is used when no limits are configured
+var DefaultLimits = Limits{MaxNameLength: MaxNameColumnLength, MaxPayloadBytes: 1048576}
+
+// Validate checks that the limits themselves are usable
+func (l Limits) Validate() error {
+	if l.MaxNameLength < 1 || l.MaxNameLength > MaxNameColumnLength {
+		return fmt.Errorf("maximum name length must be between 1 and %d, got %d", MaxNameColumnLength, l.MaxNameLength)
+	}
+	if l.MaxPayloadBytes < 1 {
+		return fmt.Errorf("maximum payload size must be positive, got %d", l.MaxPayloadBytes)
+	}
+	return nil
+}
+
+// ValidateNameFields checks length and Unicode safety of name fields against MaxNameLength, then
+// checks fields that passed against the Scripts policy
+// Errors are *FieldValidationError values ordered by field name
+func (l Limits) ValidateNameFields(fields map[string]string) []error {
+	errs := ValidateMultipleFields(fields, l.MaxNameLength)
+	if l.Scripts.IsZero() {
+		return errs
+	}
+
+	failed := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		if fieldErr, ok := err.(*FieldValidationError); ok {
+			failed[fieldErr.Field] = true
+		}
+	}
+
+	added := false
+	for _, fieldName := range slices.Sorted(maps.Keys(fields)) {
+		if failed[fieldName] {
+			continue
+		}
+		if err := l.Scripts.Validate(fields[fieldName]); err != nil {
+			errs = append(errs, &FieldValidationError{
+				Field: fieldName,
+				Code:  CodeDisallowedScript,
+				msg:   "script policy validation failed for field " + fieldName,
+				err:   err,
+			})
+			added = true
+		}
+	}
+	if added {
+		slices.SortStableFunc(errs, compareFieldNames)
+	}
+	return errs
+}
+
+// compareFieldNames orders *FieldValidationError values by field name
+func compareFieldNames(a, b error) int {
+	var nameA, nameB string
+	if fieldErr, ok := a.(*FieldValidationError); ok {
+		nameA = fieldErr.Field
+	}
+	if fieldErr, ok := b.(*FieldValidationError); ok {
+		nameB = fieldErr.Field
+	}
+	return strings.Compare(nameA, nameB)
+}
+
+// ValidatePayload checks a request body against MaxPayloadBytes
+func (l Limits) ValidatePayload(payload []byte) error {
+	return ValidatePayloadSize(payload, l.MaxPayloadBytes)
+}
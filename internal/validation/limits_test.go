@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		wantErr bool
+	}{
+		{"default limits", DefaultLimits, false},
+		{"custom limits", Limits{MaxNameLength: 50, MaxPayloadBytes: 4096}, false},
+		{"zero name length", Limits{MaxNameLength: 0, MaxPayloadBytes: 4096}, true},
+		{"name length above column width", Limits{MaxNameLength: 101, MaxPayloadBytes: 4096}, true},
+		{"zero payload size", Limits{MaxNameLength: 50, MaxPayloadBytes: 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimitsValidateNameFields(t *testing.T) {
+	limits := Limits{MaxNameLength: 50, MaxPayloadBytes: 4096}
+
+	errs := limits.ValidateNameFields(map[string]string{
+		"first_name": strings.Repeat("a", 60),
+		"last_name":  strings.Repeat("b", 50),
+	})
+	if len(errs) != 1 {
+		t.Fatalf("Expected one error, got %v", errs)
+	}
+
+	var fieldErr *FieldValidationError
+	if !errors.As(errs[0], &fieldErr) || fieldErr.Field != "first_name" || fieldErr.Code != CodeFieldTooLong {
+		t.Errorf("Expected first_name %s error, got %v", CodeFieldTooLong, errs[0])
+	}
+}
+
+func TestLimitsValidatePayload(t *testing.T) {
+	limits := Limits{MaxNameLength: 50, MaxPayloadBytes: 4}
+
+	if err := limits.ValidatePayload([]byte("1234")); err != nil {
+		t.Errorf("Expected payload at the limit to pass, got %v", err)
+	}
+	if err := limits.ValidatePayload([]byte("12345")); err == nil {
+		t.Error("Expected payload above the limit to fail")
+	}
+}
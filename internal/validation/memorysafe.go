@@ -1,10 +1,42 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 )
 
+// Codes reported by FieldValidationError
+const (
+	// CodeFieldTooLong means the field exceeds its maximum length
+	CodeFieldTooLong = "INVALID_FIELD_LENGTH"
+	// CodeUnsafeUnicode means the field failed Unicode security validation
+	CodeUnsafeUnicode = "UNICODE_SECURITY_VIOLATION"
+	// CodeUnicodeFormatChar means the field contains a zero-width, bidi or other format (Cf) character
+	CodeUnicodeFormatChar = "UNICODE_FORMAT_CHAR"
+)
+
+// FieldValidationError identifies the field and failure code of a memory-safe validation error
+type FieldValidationError struct {
+	Field string
+	Code  string
+	msg   string
+	err   error
+}
+
+func (e *FieldValidationError) Error() string {
+	if e.err != nil {
+		return e.msg + ": " + e.err.Error()
+	}
+	return e.msg
+}
+
+func (e *FieldValidationError) Unwrap() error {
+	return e.err
+}
+
 // String buffer pool for memory-efficient string operations
 var stringBufferPool = sync.Pool{
 	New: func() interface{} {
@@ -20,7 +52,11 @@ func ValidateFieldMemorySafe(field, fieldName string, maxLen int) error {
 
 	// Bounded string processing - check length first
 	if len(field) > maxLen {
-		return fmt.Errorf("field %s exceeds maximum length of %d characters", fieldName, maxLen)
+		return &FieldValidationError{
+			Field: fieldName,
+			Code:  CodeFieldTooLong,
+			msg:   fmt.Sprintf("field %s exceeds maximum length of %d characters", fieldName, maxLen),
+		}
 	}
 
 	// Process in chunks to prevent memory exhaustion
@@ -33,7 +69,16 @@ func ValidateFieldMemorySafe(field, fieldName string, maxLen int) error {
 
 		chunk := field[i:end]
 		if err := ValidateUnicodeSecurity(chunk); err != nil {
-			return fmt.Errorf("unicode security validation failed for field %s: %w", fieldName, err)
+			code := CodeUnsafeUnicode
+			if errors.Is(err, ErrUnicodeFormatChar) {
+				code = CodeUnicodeFormatChar
+			}
+			return &FieldValidationError{
+				Field: fieldName,
+				Code:  code,
+				msg:   "unicode security validation failed for field " + fieldName,
+				err:   err,
+			}
 		}
 	}
 
@@ -54,11 +99,12 @@ func ValidatePayloadSize(payload []byte, maxSize int64) error {
 }
 
 // ValidateMultipleFields validates multiple fields with memory safety
+// Errors are *FieldValidationError values ordered by field name
 func ValidateMultipleFields(fields map[string]string, maxLen int) []error {
 	var errors []error
 
-	for fieldName, fieldValue := range fields {
-		if err := ValidateFieldMemorySafe(fieldValue, fieldName, maxLen); err != nil {
+	for _, fieldName := range slices.Sorted(maps.Keys(fields)) {
+		if err := ValidateFieldMemorySafe(fields[fieldName], fieldName, maxLen); err != nil {
 			errors = append(errors, err)
 		}
 	}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -218,3 +219,29 @@ func TestMemoryPoolEfficiency(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMultipleFieldsReportsFieldAndCode(t *testing.T) {
+	errs := ValidateMultipleFields(map[string]string{
+		"last_name":  "\u0430dmin",
+		"first_name": "This is a very long first name that exceeds the limit",
+		"nickname":   "ok",
+	}, 20)
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	expected := []struct{ field, code string }{
+		{"first_name", CodeFieldTooLong},
+		{"last_name", CodeUnsafeUnicode},
+	}
+	for i, want := range expected {
+		var fieldErr *FieldValidationError
+		if !errors.As(errs[i], &fieldErr) {
+			t.Fatalf("Expected *FieldValidationError, got %T", errs[i])
+		}
+		if fieldErr.Field != want.field || fieldErr.Code != want.code {
+			t.Errorf("Error %d: expected %s/%s, got %s/%s", i, want.field, want.code, fieldErr.Field, fieldErr.Code)
+		}
+	}
+}
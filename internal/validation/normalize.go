@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Normalizer selects how user-supplied names are rewritten before they are validated and stored
+type Normalizer string
+
+// Name normalization rules, matching the NAME_NORMALIZATION values
+const (
+	// NormalizeNone stores names exactly as submitted
+	NormalizeNone Normalizer = "none"
+	// NormalizeTrim removes leading and trailing whitespace
+	NormalizeTrim Normalizer = "trim"
+	// NormalizeTrimTitle trims and then title-cases every word, e.g. "  mary-jane  " becomes "Mary-Jane"
+	NormalizeTrimTitle Normalizer = "trim_title"
+)
+
+// DefaultNormalizer trims names, which was the only behaviour before normalization became configurable
+const DefaultNormalizer = NormalizeTrim
+
+// Normalize applies the rule to a single name
+// An unknown rule behaves like DefaultNormalizer.
+func (n Normalizer) Normalize(name string) string {
+	switch n {
+	case NormalizeNone:
+		return name
+	case NormalizeTrimTitle:
+		// A Caser keeps state between calls, so each name gets its own
+		return cases.Title(language.Und).String(strings.TrimSpace(name))
+	default:
+		return strings.TrimSpace(name)
+	}
+}
@@ -0,0 +1,26 @@
+package validation
+
+import "testing"
+
+func TestNormalizerNormalize(t *testing.T) {
+	tests := []struct {
+		normalizer Normalizer
+		input      string
+		want       string
+	}{
+		{NormalizeNone, "  john  ", "  john  "},
+		{NormalizeNone, "jOHN", "jOHN"},
+		{NormalizeTrim, "  john  ", "john"},
+		{NormalizeTrimTitle, "  john  ", "John"},
+		{NormalizeTrimTitle, "mary-jane smith", "Mary-Jane Smith"},
+		{NormalizeTrimTitle, "JOHN", "John"},
+		{NormalizeTrimTitle, "  иван  ", "Иван"},
+		{Normalizer("unknown"), "  john  ", "john"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.normalizer.Normalize(tt.input); got != tt.want {
+			t.Errorf("%s.Normalize(%q) = %q, want %q", tt.normalizer, tt.input, got, tt.want)
+		}
+	}
+}
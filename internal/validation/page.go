@@ -0,0 +1,43 @@
+package validation
+
+import "fmt"
+
+// PageSize holds the default and maximum page size for paginated endpoints
+// A single value is shared by the handler, report and database validators so they never disagree
+type PageSize struct {
+	Default   int
+	Max       int
+	MaxOffset int // Largest accepted offset; 0 disables the limit
+}
+
+// DefaultPageSize is used when no page size is configured
+var DefaultPageSize = PageSize{Default: 20, Max: 100, MaxOffset: 10000}
+
+// Contains reports whether limit is an accepted page size
+func (p PageSize) Contains(limit int) bool {
+	return limit >= 1 && limit <= p.Max
+}
+
+// AllowsOffset reports whether offset is within MaxOffset; deep offsets force PostgreSQL to scan and discard every skipped row
+func (p PageSize) AllowsOffset(offset int) bool {
+	return p.MaxOffset == 0 || offset <= p.MaxOffset
+}
+
+// Validate checks that the page size settings themselves are usable
+func (p PageSize) Validate() error {
+	if p.Default < 1 {
+		return fmt.Errorf("default page size must be at least 1, got %d", p.Default)
+	}
+	if p.Default > p.Max {
+		return fmt.Errorf("default page size (%d) cannot exceed maximum page size (%d)", p.Default, p.Max)
+	}
+	if p.MaxOffset < 0 {
+		return fmt.Errorf("maximum offset cannot be negative, got %d", p.MaxOffset)
+	}
+	return nil
+}
+
+// String renders the accepted range for error messages, e.g. "between 1 and 100"
+func (p PageSize) String() string {
+	return fmt.Sprintf("between 1 and %d", p.Max)
+}
@@ -0,0 +1,69 @@
+package validation
+
+import "testing"
+
+func TestPageSizeContains(t *testing.T) {
+	pageSize := PageSize{Default: 10, Max: 50}
+
+	tests := []struct {
+		limit int
+		want  bool
+	}{
+		{0, false},
+		{1, true},
+		{50, true},
+		{51, false},
+	}
+
+	for _, tt := range tests {
+		if got := pageSize.Contains(tt.limit); got != tt.want {
+			t.Errorf("Contains(%d) = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestPageSizeAllowsOffset(t *testing.T) {
+	pageSize := PageSize{Default: 10, Max: 50, MaxOffset: 1000}
+
+	tests := []struct {
+		offset int
+		want   bool
+	}{
+		{0, true},
+		{1000, true},
+		{1001, false},
+	}
+
+	for _, tt := range tests {
+		if got := pageSize.AllowsOffset(tt.offset); got != tt.want {
+			t.Errorf("AllowsOffset(%d) = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+
+	if !(PageSize{Default: 10, Max: 50}).AllowsOffset(1000000) {
+		t.Error("AllowsOffset() with MaxOffset 0 should not limit the offset")
+	}
+}
+
+func TestPageSizeValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize PageSize
+		wantErr  bool
+	}{
+		{"default page size", DefaultPageSize, false},
+		{"default equals max", PageSize{Default: 50, Max: 50}, false},
+		{"zero default", PageSize{Default: 0, Max: 100}, true},
+		{"default above max", PageSize{Default: 200, Max: 100}, true},
+		{"negative max offset", PageSize{Default: 20, Max: 100, MaxOffset: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pageSize.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
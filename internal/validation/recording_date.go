@@ -0,0 +1,23 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// CodeInvalidRecordingDate is the error code reported for an imported recording_date outside the plausible range
+const CodeInvalidRecordingDate = "INVALID_RECORDING_DATE"
+
+// MinRecordingDate is the earliest accepted imported recording_date, 2000-01-01T00:00:00Z
+var MinRecordingDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+// ValidateRecordingDate checks that an imported Unix timestamp is neither before MinRecordingDate nor after now
+func ValidateRecordingDate(recordingDate int64, now time.Time) error {
+	if recordingDate < MinRecordingDate {
+		return fmt.Errorf("recording_date %d is before 2000-01-01", recordingDate)
+	}
+	if recordingDate > now.Unix() {
+		return fmt.Errorf("recording_date %d is in the future", recordingDate)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateRecordingDate(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		recordingDate int64
+		wantErr       bool
+	}{
+		{"start of 2000", MinRecordingDate, false},
+		{"before 2000", MinRecordingDate - 1, true},
+		{"zero", 0, true},
+		{"past", 1638360000, false},
+		{"now", now.Unix(), false},
+		{"future", now.Unix() + 1, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateRecordingDate(tt.recordingDate, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateRecordingDate(%d) error = %v, wantErr %v", tt.name, tt.recordingDate, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CodeDisallowedScript means a name contains letters outside the configured Unicode scripts
+const CodeDisallowedScript = "DISALLOWED_NAME_SCRIPT"
+
+// ErrDisallowedScript is wrapped by ScriptPolicy.Validate for a rune outside the allowed scripts
+var ErrDisallowedScript = errors.New("ErrDisallowedScript")
+
+// ScriptPolicy restricts names to a set of Unicode scripts such as Latin or Cyrillic
+// Digits, whitespace, punctuation and combining marks are always allowed so names like "O'Brien-Smith 2nd"
+// or decomposed accents still pass. The zero value allows every script.
+type ScriptPolicy struct {
+	names   []string
+	scripts []*unicode.RangeTable
+}
+
+// ParseScriptPolicy builds a policy from Unicode script names as listed in unicode.Scripts, matched
+// case-insensitively; an empty list allows every script
+func ParseScriptPolicy(names []string) (ScriptPolicy, error) {
+	var policy ScriptPolicy
+	for _, name := range names {
+		canonical, table, ok := lookupScript(name)
+		if !ok {
+			return ScriptPolicy{}, fmt.Errorf("unknown Unicode script %q", name)
+		}
+		policy.names = append(policy.names, canonical)
+		policy.scripts = append(policy.scripts, table)
+	}
+	return policy, nil
+}
+
+// lookupScript finds the unicode.Scripts entry whose name matches name, ignoring case
+func lookupScript(name string) (string, *unicode.RangeTable, bool) {
+	if table, ok := unicode.Scripts[name]; ok {
+		return name, table, true
+	}
+	for canonical, table := range unicode.Scripts {
+		if strings.EqualFold(canonical, name) {
+			return canonical, table, true
+		}
+	}
+	return "", nil, false
+}
+
+// IsZero reports whether the policy allows every script
+func (p ScriptPolicy) IsZero() bool {
+	return len(p.scripts) == 0
+}
+
+// Allows reports whether r may appear in a name under the policy
+func (p ScriptPolicy) Allows(r rune) bool {
+	if p.IsZero() || unicode.IsDigit(r) || unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.Is(unicode.Inherited, r) {
+		return true
+	}
+	return unicode.IsOneOf(p.scripts, r)
+}
+
+// Validate returns an error wrapping ErrDisallowedScript for the first rune the policy does not allow
+func (p ScriptPolicy) Validate(input string) error {
+	for _, r := range input {
+		if !p.Allows(r) {
+			return fmt.Errorf("%w: %U is not in an allowed script (%s)", ErrDisallowedScript, r, p)
+		}
+	}
+	return nil
+}
+
+// String lists the allowed scripts in alphabetical order, e.g. "Cyrillic, Latin"
+func (p ScriptPolicy) String() string {
+	names := append([]string(nil), p.names...)
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
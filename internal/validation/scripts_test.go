@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScriptPolicyLatinOnly(t *testing.T) {
+	policy, err := ParseScriptPolicy([]string{"latin"})
+	if err != nil {
+		t.Fatalf("ParseScriptPolicy: %v", err)
+	}
+
+	for _, name := range []string{"John", "O'Brien-Smith", "Jos\u00e9", "Jose\u0301", "Louis 14th"} {
+		if err := policy.Validate(name); err != nil {
+			t.Errorf("Expected %q to be allowed under a Latin policy, got %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"Иван", "Alex-Алексей", "Γιάννης"} {
+		if err := policy.Validate(name); !errors.Is(err, ErrDisallowedScript) {
+			t.Errorf("Expected %q to be rejected with ErrDisallowedScript, got %v", name, err)
+		}
+	}
+}
+
+func TestScriptPolicyZeroValueAllowsEveryScript(t *testing.T) {
+	var policy ScriptPolicy
+	for _, name := range []string{"John", "Иван", "Γιάννης", "太郎"} {
+		if err := policy.Validate(name); err != nil {
+			t.Errorf("Expected %q to be allowed without a policy, got %v", name, err)
+		}
+	}
+}
+
+func TestParseScriptPolicyUnknownScript(t *testing.T) {
+	if _, err := ParseScriptPolicy([]string{"Latin", "Klingon"}); err == nil {
+		t.Error("Expected error for an unknown script")
+	}
+}
+
+func TestLimitsValidateNameFieldsScriptPolicy(t *testing.T) {
+	policy, err := ParseScriptPolicy([]string{"Latin"})
+	if err != nil {
+		t.Fatalf("ParseScriptPolicy: %v", err)
+	}
+	limits := Limits{MaxNameLength: 5, MaxPayloadBytes: 1024, Scripts: policy}
+
+	errs := limits.ValidateNameFields(map[string]string{"last_name": "Ив", "first_name": "Johnathan"})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+
+	want := []struct{ field, code string }{
+		{"first_name", CodeFieldTooLong},
+		{"last_name", CodeDisallowedScript},
+	}
+	for i, w := range want {
+		var fieldErr *FieldValidationError
+		if !errors.As(errs[i], &fieldErr) || fieldErr.Field != w.field || fieldErr.Code != w.code {
+			t.Errorf("Error %d = %v, want %s %s", i, errs[i], w.field, w.code)
+		}
+	}
+}
@@ -13,6 +13,9 @@ var (
 	ErrInvalidUnicodeSecurity  = fmt.Errorf("ErrInvalidUnicodeSecurity")
 	ErrHomographAttackDetected = fmt.Errorf("ErrHomographAttackDetected")
 	ErrInvalidUnicodeCategory  = fmt.Errorf("ErrInvalidUnicodeCategory")
+	// ErrUnicodeFormatChar reports an invisible format character (Cf) such as a zero-width space,
+	// a word joiner or a bidi override, which can hide or reorder text when displayed
+	ErrUnicodeFormatChar = fmt.Errorf("ErrUnicodeFormatChar")
 )
 
 // Blocked Unicode categories for security
@@ -59,6 +62,13 @@ func ValidateUnicodeSecurity(input string) error {
 		}
 	}
 
+	// Format characters are reported separately so callers can name the specific violation
+	for _, r := range input {
+		if unicode.Is(unicode.Cf, r) {
+			return ErrUnicodeFormatChar
+		}
+	}
+
 	// Unicode normalization with security check
 	normalized := norm.NFKC.String(input)
 
@@ -77,54 +87,85 @@ func ValidateUnicodeSecurity(input string) error {
 	return nil
 }
 
-// containsHomographAttacks checks for common homograph attack patterns
-// Only blocks mixed strings that use Cyrillic to impersonate Latin, not pure Cyrillic text
-func containsHomographAttacks(input string) bool {
-	// Mapping of Cyrillic homographs to their Latin lookalikes
-	cyrillicToLatin := map[rune]rune{
-		'\u0430': 'a', // Cyrillic small а → Latin a
-		'\u0435': 'e', // Cyrillic small е → Latin e
-		'\u043e': 'o', // Cyrillic small о → Latin o
-		'\u0440': 'p', // Cyrillic small р → Latin p
-		'\u0441': 'c', // Cyrillic small с → Latin c
-		'\u0445': 'x', // Cyrillic small х → Latin x
-		'\u0443': 'y', // Cyrillic small у → Latin y
-		'\u0410': 'A', // Cyrillic capital А → Latin A
-		'\u0415': 'E', // Cyrillic capital Е → Latin E
-		'\u041E': 'O', // Cyrillic capital О → Latin O
-		'\u0420': 'P', // Cyrillic capital Р → Latin P
-		'\u0421': 'C', // Cyrillic capital С → Latin C
-		'\u0425': 'X', // Cyrillic capital Х → Latin X
-		'\u0423': 'Y', // Cyrillic capital У → Latin Y
+// homographsToLatin maps Cyrillic and Greek letters to the Latin letters they are visually confusable with
+var homographsToLatin = map[rune]rune{
+	// Cyrillic
+	'\u0430': 'a', // Cyrillic small а → Latin a
+	'\u0435': 'e', // Cyrillic small е → Latin e
+	'\u043e': 'o', // Cyrillic small о → Latin o
+	'\u0440': 'p', // Cyrillic small р → Latin p
+	'\u0441': 'c', // Cyrillic small с → Latin c
+	'\u0445': 'x', // Cyrillic small х → Latin x
+	'\u0443': 'y', // Cyrillic small у → Latin y
+	'\u0410': 'A', // Cyrillic capital А → Latin A
+	'\u0415': 'E', // Cyrillic capital Е → Latin E
+	'\u041E': 'O', // Cyrillic capital О → Latin O
+	'\u0420': 'P', // Cyrillic capital Р → Latin P
+	'\u0421': 'C', // Cyrillic capital С → Latin C
+	'\u0425': 'X', // Cyrillic capital Х → Latin X
+	'\u0423': 'Y', // Cyrillic capital У → Latin Y
+
+	// Greek
+	'\u03b1': 'a', // Greek small alpha α → Latin a
+	'\u03b9': 'i', // Greek small iota ι → Latin i
+	'\u03ba': 'k', // Greek small kappa κ → Latin k
+	'\u03bd': 'v', // Greek small nu ν → Latin v
+	'\u03bf': 'o', // Greek small omicron ο → Latin o
+	'\u03c1': 'p', // Greek small rho ρ → Latin p
+	'\u03c5': 'u', // Greek small upsilon υ → Latin u
+	'\u03c7': 'x', // Greek small chi χ → Latin x
+	'\u0391': 'A', // Greek capital Alpha Α → Latin A
+	'\u0392': 'B', // Greek capital Beta Β → Latin B
+	'\u0395': 'E', // Greek capital Epsilon Ε → Latin E
+	'\u0396': 'Z', // Greek capital Zeta Ζ → Latin Z
+	'\u0397': 'H', // Greek capital Eta Η → Latin H
+	'\u0399': 'I', // Greek capital Iota Ι → Latin I
+	'\u039a': 'K', // Greek capital Kappa Κ → Latin K
+	'\u039c': 'M', // Greek capital Mu Μ → Latin M
+	'\u039d': 'N', // Greek capital Nu Ν → Latin N
+	'\u039f': 'O', // Greek capital Omicron Ο → Latin O
+	'\u03a1': 'P', // Greek capital Rho Ρ → Latin P
+	'\u03a4': 'T', // Greek capital Tau Τ → Latin T
+	'\u03a5': 'Y', // Greek capital Upsilon Υ → Latin Y
+	'\u03a7': 'X', // Greek capital Chi Χ → Latin X
+}
+
+// isHomograph reports whether r is a Cyrillic or Greek Latin lookalike or a fullwidth Latin letter
+func isHomograph(r rune) bool {
+	if _, ok := homographsToLatin[r]; ok {
+		return true
 	}
+	// Fullwidth Ａ-Ｚ and ａ-ｚ render like Latin letters in many fonts
+	return (r >= '\uff21' && r <= '\uff3a') || (r >= '\uff41' && r <= '\uff5a')
+}
+
+// isASCIILetter reports whether r is a Latin letter a-z or A-Z
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
 
+// containsHomographAttacks checks for common homograph attack patterns
+// Only blocks mixed strings that use Cyrillic, Greek or fullwidth letters to impersonate Latin,
+// not text written in a single script
+func containsHomographAttacks(input string) bool {
 	runes := []rune(input)
 
-	// Check for suspicious patterns: Cyrillic homograph directly adjacent to Latin character
+	// Check for suspicious patterns: a homograph directly adjacent to a Latin letter
+	// Separators such as hyphens, spaces, dots and underscores are not letters, so "Alex-Алексей" passes
 	for i, r := range runes {
-		// Check if character is a Cyrillic homograph
-		if _, isHomograph := cyrillicToLatin[r]; isHomograph {
-			// Look at immediate neighbors for Latin letters (not separated by hyphens/spaces)
-			if i > 0 {
-				prevRune := runes[i-1]
-				// Only consider it suspicious if previous character is Latin AND no separator
-				if ((prevRune >= 'a' && prevRune <= 'z') || (prevRune >= 'A' && prevRune <= 'Z')) &&
-					prevRune != '-' && prevRune != ' ' && prevRune != '.' && prevRune != '_' {
-					return true
-				}
-			}
-			if i < len(runes)-1 {
-				nextRune := runes[i+1]
-				// Only consider it suspicious if next character is Latin AND no separator
-				if ((nextRune >= 'a' && nextRune <= 'z') || (nextRune >= 'A' && nextRune <= 'Z')) &&
-					nextRune != '-' && nextRune != ' ' && nextRune != '.' && nextRune != '_' {
-					return true
-				}
-			}
+		if !isHomograph(r) {
+			continue
+		}
+		if i > 0 && isASCIILetter(runes[i-1]) {
+			return true
+		}
+		if i < len(runes)-1 && isASCIILetter(runes[i+1]) {
+			return true
 		}
 	}
 
 	// Allow pure Cyrillic names like "Иван", "Мария", "Александр"
+	// Allow pure Greek names like "Γιάννης", "Αθηνά"
 	// Allow pure Latin names like "John", "Maria", "Alexander"
 	// Allow legitimate mixed like "Alex-Алексей" where separated by hyphens
 	return false
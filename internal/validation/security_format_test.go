@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"unicode"
+)
+
+func TestUnicodeSecurity_FormatCharacters(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"zero_width_space", "Jo\u200Bhn"},
+		{"zero_width_non_joiner", "Jo\u200Chn"},
+		{"zero_width_joiner", "Jo\u200Dhn"},
+		{"word_joiner", "Jo\u2060hn"},
+		{"byte_order_mark", "\uFEFFJohn"},
+		{"right_to_left_override", "John\u202Egnp.exe"},
+		{"left_to_right_embedding", "\u202AJohn"},
+		{"right_to_left_isolate", "John\u2067"},
+		{"soft_hyphen", "Jo\u00ADhn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range tt.input {
+				if r > unicode.MaxASCII && !unicode.Is(unicode.Cf, r) {
+					t.Fatalf("test input %q contains %U which is not in unicode.Cf", tt.input, r)
+				}
+			}
+			if err := ValidateUnicodeSecurity(tt.input); !errors.Is(err, ErrUnicodeFormatChar) {
+				t.Errorf("ValidateUnicodeSecurity(%q) error = %v, want ErrUnicodeFormatChar", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateFieldMemorySafeFormatCharCode(t *testing.T) {
+	for _, input := range []string{"Jo\u200Bhn", "John\u202Egnp"} {
+		err := ValidateFieldMemorySafe(input, "first_name", 100)
+
+		var fieldErr *FieldValidationError
+		if !errors.As(err, &fieldErr) || fieldErr.Code != CodeUnicodeFormatChar {
+			t.Errorf("ValidateFieldMemorySafe(%q) error = %v, want code %s", input, err, CodeUnicodeFormatChar)
+		}
+	}
+
+	err := ValidateFieldMemorySafe("test\uE000private", "first_name", 100)
+	var fieldErr *FieldValidationError
+	if !errors.As(err, &fieldErr) || fieldErr.Code != CodeUnsafeUnicode {
+		t.Errorf("Expected private use character to keep code %s, got %v", CodeUnsafeUnicode, err)
+	}
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnicodeSecurity_GreekAndFullwidthHomographs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		reason  string
+	}{
+		// Mixed Greek/Latin homographs should be BLOCKED
+		{
+			name:    "greek_alpha_in_latin",
+			input:   "pαypal",
+			wantErr: true,
+			reason:  "Greek alpha impersonating Latin a should be detected",
+		},
+		{
+			name:    "greek_omicron_in_latin",
+			input:   "gοogle",
+			wantErr: true,
+			reason:  "Greek omicron impersonating Latin o should be detected",
+		},
+		{
+			name:    "greek_capital_in_latin",
+			input:   "Αdmin",
+			wantErr: true,
+			reason:  "Greek capital Alpha impersonating Latin A should be detected",
+		},
+
+		// Mixed fullwidth/Latin should be BLOCKED
+		{
+			name:    "fullwidth_letter_in_latin",
+			input:   "pａypal",
+			wantErr: true,
+			reason:  "Fullwidth a next to Latin letters should be detected",
+		},
+
+		// Pure single-script strings should be ALLOWED
+		{
+			name:    "pure_greek_first_name",
+			input:   "Γιάννης",
+			wantErr: false,
+			reason:  "Pure Greek name should be allowed",
+		},
+		{
+			name:    "pure_greek_word_with_homographs",
+			input:   "Αθηνά Παπαδοπούλου",
+			wantErr: false,
+			reason:  "Pure Greek text is not an impersonation even though it contains lookalikes",
+		},
+		{
+			name:    "pure_fullwidth",
+			input:   "ｐａｙ",
+			wantErr: false,
+			reason:  "Fullwidth-only text is not mixed with Latin",
+		},
+		{
+			name:    "greek_and_latin_separated",
+			input:   "Nikos-Νίκος",
+			wantErr: false,
+			reason:  "Scripts separated by a hyphen are legitimate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUnicodeSecurity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUnicodeSecurity(%q) error = %v, wantErr %v: %s", tt.input, err, tt.wantErr, tt.reason)
+			}
+			if tt.wantErr && !errors.Is(err, ErrHomographAttackDetected) {
+				t.Errorf("ValidateUnicodeSecurity(%q) error = %v, want ErrHomographAttackDetected", tt.input, err)
+			}
+		})
+	}
+}
@@ -37,13 +37,13 @@ func TestValidateUnicodeSecurity(t *testing.T) {
 			name:        "zero-width space",
 			input:       "zero\u200Bwidth",
 			expectError: true,
-			errorType:   "ErrInvalidUnicodeCategory",
+			errorType:   "ErrUnicodeFormatChar",
 		},
 		{
 			name:        "format character",
 			input:       "test\u200Dformat",
 			expectError: true,
-			errorType:   "ErrInvalidUnicodeCategory",
+			errorType:   "ErrUnicodeFormatChar",
 		},
 		{
 			name:        "Cyrillic homograph attack",
@@ -61,7 +61,7 @@ func TestValidateUnicodeSecurity(t *testing.T) {
 			name:        "format character - zero-width joiner",
 			input:       "test\u200Dformat",
 			expectError: true,
-			errorType:   "ErrInvalidUnicodeCategory",
+			errorType:   "ErrUnicodeFormatChar",
 		},
 		{
 			name:        "private use character",
@@ -0,0 +1,9 @@
+-- Migration 004: Add optional tenant_id column for multi-tenant deployments
+-- Rows keep a NULL tenant_id unless MULTI_TENANT=true, in which case every user query
+-- is scoped with WHERE tenant_id = $n from the X-Tenant-ID header
+
+ALTER TABLE users ADD COLUMN tenant_id TEXT;
+
+-- Composite index for tenant-scoped listing and reports
+-- Supports queries: WHERE tenant_id = X ORDER BY recording_date DESC
+CREATE INDEX idx_users_tenant_recording_date ON users(tenant_id, recording_date DESC);
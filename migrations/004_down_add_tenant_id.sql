@@ -0,0 +1,5 @@
+-- Rollback Migration 004: Drop tenant_id column and its index
+-- Rollback for 004_add_tenant_id.sql
+
+DROP INDEX IF EXISTS idx_users_tenant_recording_date;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
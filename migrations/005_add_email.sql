@@ -0,0 +1,9 @@
+-- Migration 005: Add optional email column, the natural key of PUT /users upserts
+-- Existing rows keep a NULL email; NULL emails never conflict with each other
+
+ALTER TABLE users ADD COLUMN email VARCHAR(254);
+
+-- Emails are unique per tenant; single-tenant rows all have a NULL tenant_id, folded to ''
+-- so they still conflict. PUT /users infers this index in ON CONFLICT, and the name
+-- users_email_key maps unique violations to USER_DUPLICATE_EMAIL
+CREATE UNIQUE INDEX users_email_key ON users ((COALESCE(tenant_id, '')), email);
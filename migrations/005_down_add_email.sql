@@ -0,0 +1,5 @@
+-- Rollback Migration 005: Drop email column and its unique index
+-- Rollback for 005_add_email.sql
+
+DROP INDEX IF EXISTS users_email_key;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
@@ -23,7 +23,7 @@ const (
 	// Database constraint violations (409 Conflict)
 	ErrCodeUserAlreadyExists = "USER_ALREADY_EXISTS"
 	ErrCodeUserNotFound      = "USER_NOT_FOUND"
-	ErrCodeDuplicateEmail    = "USER_DUPLICATE_EMAIL" // Future use for email field
+	ErrCodeDuplicateEmail    = "USER_DUPLICATE_EMAIL" // Email taken by another user of the tenant
 
 	// Database errors (500 Internal Server Error)
 	ErrCodeDatabaseError     = "USER_DATABASE_ERROR"
@@ -31,6 +31,16 @@ const (
 	ErrCodeTransactionFailed = "USER_TRANSACTION_FAILED"
 )
 
+// ErrDuplicateName is returned by user creation when duplicate names are rejected and the name is taken
+var ErrDuplicateName = NewUserConflictError(ErrCodeUserAlreadyExists, "A user with the same first and last name already exists")
+
+// ErrPoolExhausted is returned when no database connection frees up within the configured acquire timeout
+var ErrPoolExhausted = &UserError{
+	Code:       "POOL_EXHAUSTED",
+	Message:    "Service temporarily unavailable",
+	HTTPStatus: http.StatusServiceUnavailable,
+}
+
 // UserError represents a user-specific error with HTTP status mapping
 type UserError struct {
 	Code       string `json:"code"`